@@ -0,0 +1,125 @@
+// balance-report 模拟玩家连续出击的功勋经济，输出 CSV 与 HTML/SVG 图表。
+//
+//	go run ./cmd/balance-report -sorties 100 -skill medium -difficulty affordable:0.5 -upgrades balanced
+//
+// 出击战果默认来自由机器人出击记录拟合的模型；-headless 改为逐局运行真实战斗（慢）。
+// 重新录制记录：
+//
+//	go run ./cmd/balance-report -record internal/balance/sim/calibration.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"spacebattle/internal/balance/sim"
+	"spacebattle/internal/bot"
+)
+
+func main() {
+	sorties := flag.Int("sorties", 100, "sorties per simulated player")
+	players := flag.Int("players", 200, "simulated players averaged per point")
+	seed := flag.Int64("seed", 1, "random seed")
+	merits := flag.Int("merits", 0, "starting merits")
+	skill := flag.String("skill", bot.Medium.Name, "bot skill level the outcome model is calibrated to (easy, medium, hard, perfect)")
+	difficulty := flag.String("difficulty", "affordable:0.5", "difficulty policy: fixed:<mul>, affordable:<fraction of merits>, ladder:<step>")
+	upgrades := flag.String("upgrades", "balanced", "upgrade policy: none, cheapest, balanced")
+	out := flag.String("out", "balance-report", "output directory")
+	headless := flag.Bool("headless", false, "play every sortie as a headless battle instead of using the fitted model")
+	record := flag.String("record", "", "record bot calibration runs for every skill to this file and exit")
+	runs := flag.Int("runs", 6, "battles per build and difficulty when recording")
+	flag.Parse()
+
+	if *record != "" {
+		if err := recordCalibration(*record, *runs, *seed); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("wrote %s\n", *record)
+		return
+	}
+
+	s, ok := bot.SkillByName(*skill)
+	if !ok {
+		log.Fatalf("unknown skill %q", *skill)
+	}
+	dp, err := sim.ParseDifficultyPolicy(*difficulty)
+	if err != nil {
+		log.Fatal(err)
+	}
+	up, err := sim.ParseUpgradePolicy(*upgrades)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var sortie sim.Sortie = sim.Headless{Skill: s}
+	if !*headless {
+		model, err := sim.ModelFor(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sortie = model
+	}
+
+	report, err := sim.Run(sim.Config{
+		Sorties:     *sorties,
+		Players:     *players,
+		Seed:        *seed,
+		StartMerits: *merits,
+		Sortie:      sortie,
+		Difficulty:  dp,
+		Upgrades:    up,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	csvPath := filepath.Join(*out, "balance.csv")
+	htmlPath := filepath.Join(*out, "balance.html")
+	if err := writeFile(csvPath, report.WriteCSV); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeFile(htmlPath, report.WriteHTML); err != nil {
+		log.Fatal(err)
+	}
+
+	last := report.Points[len(report.Points)-1]
+	fmt.Printf("after %d sorties: merits %.0f (median %.0f), difficulty %.2f, power %.2f, clear rate %.0f%%\n",
+		last.Sortie, last.MeritsMean, last.MeritsMedian, last.Difficulty, last.Power, last.ClearRate*100)
+	for _, note := range report.Diagnose() {
+		fmt.Println("warning:", note)
+	}
+	fmt.Printf("wrote %s and %s\n", csvPath, htmlPath)
+}
+
+// writeFile 创建文件并交给 write 写入
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// recordCalibration 在标定网格上为每个技术水平录制机器人出击记录
+func recordCalibration(path string, runs int, seed int64) error {
+	records := make(map[string][]sim.Sample, len(bot.Skills))
+	builds := sim.CalibrationBuilds()
+	for _, skill := range bot.Skills {
+		records[skill.Name] = sim.Record(skill, builds, sim.CalibrationDifficulties, runs, seed)
+		fmt.Printf("recorded %d %s runs\n", len(records[skill.Name]), skill.Name)
+	}
+	return writeFile(path, func(w io.Writer) error {
+		return sim.WriteCalibration(w, records)
+	})
+}
@@ -0,0 +1,102 @@
+// reward-eval 用存档中的出击记录评估奖励公式改动：按新公式重算每局功勋，与实际发放对比。
+//
+//	go run ./cmd/reward-eval -dump > formula.json   # 导出当前公式作为起点
+//	go run ./cmd/reward-eval -formula formula.json -db game_progress.db
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"spacebattle/internal/balance"
+	"spacebattle/internal/config"
+	"spacebattle/internal/progress"
+)
+
+func main() {
+	dbPath := flag.String("db", "game_progress.db", "progress database to read run history from")
+	formula := flag.String("formula", "", "reward formula JSON (fields not present keep their defaults)")
+	dump := flag.Bool("dump", false, "print the current reward formula as JSON and exit")
+	limit := flag.Int("runs", 0, "evaluate only the most recent N runs (0 = all)")
+	top := flag.Int("top", 10, "list the N runs whose payout changes the most")
+	flag.Parse()
+
+	if *dump {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(config.DefaultConfig().Reward); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *formula == "" {
+		log.Fatal("-formula is required (use -dump to start from the current formula)")
+	}
+	rc, err := config.LoadRewardConfig(*formula)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := evaluate(rc, *dbPath, *limit, *top); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// evaluate 在存档的临时副本上按新公式重算出击记录并输出对比：
+// 初始化会执行结构迁移，不能改写玩家的存档
+func evaluate(rc config.RewardConfig, dbPath string, limit, top int) error {
+	tmp, err := copyDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(tmp))
+
+	if err := progress.Init(tmp); err != nil {
+		return err
+	}
+	if err := progress.Load(); err != nil {
+		return err
+	}
+	runs, err := progress.ListRuns(limit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("profile %q: %d runs\n\n", progress.CurrentProfile().Name, len(runs))
+	return balance.EvaluateFormula(rc, runs).WriteSummary(os.Stdout, top)
+}
+
+// copyDB 将存档复制到临时目录，返回副本路径
+func copyDB(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dir, err := os.MkdirTemp("", "reward-eval")
+	if err != nil {
+		return "", err
+	}
+	tmp := filepath.Join(dir, filepath.Base(path))
+	dst, err := os.Create(tmp)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return tmp, nil
+}
@@ -0,0 +1,141 @@
+package achievements
+
+import "time"
+
+// EventKind 成就事件类型
+type EventKind string
+
+const (
+	EventEnemyKilled      EventKind = "enemy_killed"      // 击毁小怪
+	EventPlayerDamaged    EventKind = "player_damaged"    // 玩家受到伤害
+	EventBossKilled       EventKind = "boss_killed"       // 击败 Boss
+	EventRunSettled       EventKind = "run_settled"       // 对局结算
+	EventUpgradePurchased EventKind = "upgrade_purchased" // 购买升级
+)
+
+// Event 成就事件；Ship、Mode、Difficulty 为空时由当前出击补全
+type Event struct {
+	Kind       EventKind
+	Ship       string
+	Mode       string
+	Stage      string
+	Difficulty float64
+	Victory    bool
+	NoHit      bool          // Boss 战中未受伤
+	Upgrade    string        // 升级项（不含 upgrade. 前缀）
+	Maxed      bool          // 升级已达上限
+	Elapsed    time.Duration // 对局用时
+}
+
+// Condition 成就条件：事件类型加可选过滤，Count 大于 1 时按满足次数持久累计
+type Condition struct {
+	Event         EventKind
+	Ship          string
+	Mode          string
+	Stage         string
+	MinDifficulty float64
+	Victory       bool
+	NoHit         bool
+	Upgrade       string
+	Maxed         bool
+	MinElapsed    time.Duration
+	Count         int
+}
+
+// Matches 事件是否满足条件（不含累计次数）
+func (c Condition) Matches(e Event) bool {
+	switch {
+	case c.Event != e.Kind:
+		return false
+	case c.Ship != "" && c.Ship != e.Ship:
+		return false
+	case c.Mode != "" && c.Mode != e.Mode:
+		return false
+	case c.Stage != "" && c.Stage != e.Stage:
+		return false
+	case e.Difficulty < c.MinDifficulty:
+		return false
+	case c.Victory && !e.Victory:
+		return false
+	case c.NoHit && !e.NoHit:
+		return false
+	case c.Upgrade != "" && c.Upgrade != e.Upgrade:
+		return false
+	case c.Maxed && !e.Maxed:
+		return false
+	case e.Elapsed < c.MinElapsed:
+		return false
+	}
+	return true
+}
+
+// Target 达成所需的满足次数
+func (c Condition) Target() int {
+	return max(c.Count, 1)
+}
+
+// Achievement 成就定义
+type Achievement struct {
+	ID        string
+	NameKey   string // 本地化名称键
+	DescKey   string // 本地化说明键
+	Condition Condition
+}
+
+// All 全部成就（按界面展示顺序）；ID 一经发布不可修改，战机解锁等依赖它
+var All = []Achievement{
+	{
+		ID: "first_victory", NameKey: "achievement.first_victory", DescKey: "achievement.first_victory.desc",
+		Condition: Condition{Event: EventRunSettled, Victory: true},
+	},
+	{
+		ID: "clear_stage1", NameKey: "achievement.clear_stage1", DescKey: "achievement.clear_stage1.desc",
+		Condition: Condition{Event: EventRunSettled, Mode: "campaign", Stage: "stage1", Victory: true},
+	},
+	{
+		ID: "clear_stage2", NameKey: "achievement.clear_stage2", DescKey: "achievement.clear_stage2.desc",
+		Condition: Condition{Event: EventRunSettled, Mode: "campaign", Stage: "stage2", Victory: true},
+	},
+	{
+		ID: "clear_stage3", NameKey: "achievement.clear_stage3", DescKey: "achievement.clear_stage3.desc",
+		Condition: Condition{Event: EventRunSettled, Mode: "campaign", Stage: "stage3", Victory: true},
+	},
+	{
+		ID: "clear_difficulty_10", NameKey: "achievement.clear_difficulty_10", DescKey: "achievement.clear_difficulty_10.desc",
+		Condition: Condition{Event: EventRunSettled, MinDifficulty: 10, Victory: true},
+	},
+	{
+		ID: "no_hit_boss", NameKey: "achievement.no_hit_boss", DescKey: "achievement.no_hit_boss.desc",
+		Condition: Condition{Event: EventBossKilled, NoHit: true},
+	},
+	{
+		ID: "boss_hunter", NameKey: "achievement.boss_hunter", DescKey: "achievement.boss_hunter.desc",
+		Condition: Condition{Event: EventBossKilled, Count: 25},
+	},
+	{
+		ID: "kills_1000", NameKey: "achievement.kills_1000", DescKey: "achievement.kills_1000.desc",
+		Condition: Condition{Event: EventEnemyKilled, Count: 1000},
+	},
+	{
+		ID: "beta_500_kills", NameKey: "achievement.beta_500_kills", DescKey: "achievement.beta_500_kills.desc",
+		Condition: Condition{Event: EventEnemyKilled, Ship: "beta", Count: 500},
+	},
+	{
+		ID: "endless_10min", NameKey: "achievement.endless_10min", DescKey: "achievement.endless_10min.desc",
+		Condition: Condition{Event: EventRunSettled, Mode: "endless", MinElapsed: 10 * time.Minute},
+	},
+	{
+		ID: "max_penetration", NameKey: "achievement.max_penetration", DescKey: "achievement.max_penetration.desc",
+		Condition: Condition{Event: EventUpgradePurchased, Upgrade: "penetration", Maxed: true},
+	},
+}
+
+// ByID 按 ID 查找成就
+func ByID(id string) (Achievement, bool) {
+	for _, a := range All {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
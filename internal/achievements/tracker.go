@@ -0,0 +1,151 @@
+package achievements
+
+import (
+	"time"
+
+	"spacebattle/internal/progress"
+)
+
+// ToastDuration 达成提示的显示时长
+const ToastDuration = 3 * time.Second
+
+// Toast 成就达成提示
+type Toast struct {
+	Achievement Achievement
+	At          time.Time
+}
+
+// Tracker 成就追踪：按事件推进累计计数、判定达成并产生提示。
+// 计数在内存中累加，结算或返回主菜单时批量写入存档。
+type Tracker struct {
+	profileID int64
+	loaded    bool
+	unlocked  map[string]time.Time
+	counters  map[string]int
+	dirty     map[string]bool
+	run       Event // 当前出击上下文（Ship、Mode、Difficulty）
+	toasts    []Toast
+}
+
+var tracker = &Tracker{}
+
+// BeginRun 开始一次出击，之后的事件以该战机、模式与难度补全
+func BeginRun(ship, mode string, difficulty float64) {
+	tracker.run = Event{Ship: ship, Mode: mode, Difficulty: difficulty}
+}
+
+// EndRun 结束出击并保存累计计数
+func EndRun() {
+	tracker.run = Event{}
+	Flush()
+}
+
+// Publish 发布成就事件
+func Publish(e Event) {
+	tracker.Publish(e, time.Now())
+}
+
+// Flush 保存尚未写入的累计计数
+func Flush() {
+	_ = tracker.Flush()
+}
+
+// Toasts 当前应显示的达成提示
+func Toasts(now time.Time) []Toast {
+	return tracker.Toasts(now)
+}
+
+// Unlocks 当前档案已达成的成就及达成时间
+func Unlocks() map[string]time.Time {
+	tracker.ensureLoaded()
+	return tracker.unlocked
+}
+
+// Progress 成就的累计进度（已达成时为目标值）
+func Progress(a Achievement) int {
+	tracker.ensureLoaded()
+	if _, ok := tracker.unlocked[a.ID]; ok {
+		return a.Condition.Target()
+	}
+	return min(tracker.counters[a.ID], a.Condition.Target())
+}
+
+// ensureLoaded 首次使用或切换档案后重新读取达成记录与计数
+func (t *Tracker) ensureLoaded() {
+	id := progress.CurrentProfileID()
+	if t.loaded && t.profileID == id {
+		return
+	}
+	t.profileID = id
+	t.loaded = true
+	t.unlocked, _ = progress.AchievementUnlocks()
+	t.counters, _ = progress.AchievementCounters()
+	t.dirty = make(map[string]bool)
+	t.toasts = nil
+}
+
+// Publish 处理一个事件：推进满足条件的累计计数，达到目标即解锁
+func (t *Tracker) Publish(e Event, now time.Time) {
+	t.ensureLoaded()
+	if e.Ship == "" {
+		e.Ship = t.run.Ship
+	}
+	if e.Mode == "" {
+		e.Mode = t.run.Mode
+	}
+	if e.Difficulty == 0 {
+		e.Difficulty = t.run.Difficulty
+	}
+
+	for _, a := range All {
+		if _, ok := t.unlocked[a.ID]; ok || !a.Condition.Matches(e) {
+			continue
+		}
+		if target := a.Condition.Target(); target > 1 {
+			t.counters[a.ID]++
+			t.dirty[a.ID] = true
+			if t.counters[a.ID] < target {
+				continue
+			}
+		}
+		t.unlock(a, now)
+	}
+}
+
+// unlock 记录达成并加入提示队列
+func (t *Tracker) unlock(a Achievement, now time.Time) {
+	if _, err := progress.UnlockAchievement(a.ID); err != nil {
+		return
+	}
+	t.unlocked[a.ID] = now
+	t.toasts = append(t.toasts, Toast{Achievement: a, At: now})
+	_ = t.Flush()
+}
+
+// Flush 保存尚未写入的累计计数
+func (t *Tracker) Flush() error {
+	if len(t.dirty) == 0 {
+		return nil
+	}
+	changed := make(map[string]int, len(t.dirty))
+	for id := range t.dirty {
+		changed[id] = t.counters[id]
+	}
+	if err := progress.SaveAchievementCounters(changed); err != nil {
+		return err
+	}
+	t.dirty = make(map[string]bool)
+	return nil
+}
+
+// Toasts 返回仍在显示时长内的提示，并丢弃过期提示
+func (t *Tracker) Toasts(now time.Time) []Toast {
+	active := t.toasts[:0]
+	for _, toast := range t.toasts {
+		if now.Sub(toast.At) < ToastDuration {
+			active = append(active, toast)
+		}
+	}
+	t.toasts = active
+	return active
+}
@@ -0,0 +1,162 @@
+package balance
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"spacebattle/internal/campaign"
+	"spacebattle/internal/config"
+	"spacebattle/internal/progress"
+)
+
+// RunPayout 一局在记录中的实际奖励与按新公式重算的奖励
+type RunPayout struct {
+	Run progress.Run
+	Old int
+	New int
+}
+
+// FormulaEvaluation 用出击记录评估奖励公式改动
+type FormulaEvaluation struct {
+	Payouts []RunPayout
+	Skipped int // 不发放功勋或无法还原的出击（练习、未知关卡）
+}
+
+// EvaluateFormula 按新公式重算历史出击的奖励（含辅助折扣），用于上线前比较发放变化。
+// 记录中的击杀与生成数不含 Boss，分别按 Boss 击杀数与出场数补回；总时长按模式与关卡还原；
+// 无尽模式按存活时间与 Boss 击杀数重算
+func EvaluateFormula(rc config.RewardConfig, runs []progress.Run) FormulaEvaluation {
+	var eval FormulaEvaluation
+	for _, r := range runs {
+		var b RewardBreakdown
+		if r.Mode == "endless" {
+			b = ComputeSurvivalRewardWith(rc, r.Difficulty, r.Elapsed, r.BossKills)
+		} else {
+			total, ok := standardDuration(r)
+			if !ok {
+				eval.Skipped++
+				continue
+			}
+			b = SettleStandardRewardWith(rc, r.Difficulty, r.Kills+r.BossKills, r.Spawned+r.BossesSpawned, r.Elapsed, total, r.Victory)
+		}
+		eval.Payouts = append(eval.Payouts, RunPayout{Run: r, Old: r.Reward.Total, New: assistPayout(rc, r, b)})
+	}
+	return eval
+}
+
+// assistPayout 扣除辅助折扣后的发放：有调整记录时按平均减难程度与新公式重算，
+// 旧记录没有调整记录，按记录的折扣比例扣除
+func assistPayout(rc config.RewardConfig, r progress.Run, b RewardBreakdown) int {
+	if r.Assist.Enabled {
+		return ApplyAssistDiscountWith(rc, b, r.Assist.AverageEase, config.DefaultConfig().AssistMaxLevel).TotalReward
+	}
+	payout := b.TotalReward
+	if r.Reward.AssistDiscount > 0 {
+		rate := float64(r.Reward.AssistDiscount) / float64(r.Reward.Total+r.Reward.AssistDiscount)
+		payout -= int(math.Round(float64(payout) * rate))
+	}
+	return payout
+}
+
+// standardDuration 按标准公式结算的出击的关卡总时长
+func standardDuration(r progress.Run) (time.Duration, bool) {
+	cfg := config.DefaultConfig()
+	switch r.Mode {
+	case "standard", "daily":
+		return cfg.TotalDuration, true
+	case "campaign":
+		if stage, ok := campaign.ByID(r.StageID); ok {
+			return stage.TotalDuration(), true
+		}
+	case "bossrush":
+		return cfg.BossRushTimePerBoss * time.Duration(1+len(campaign.Stages)), true
+	}
+	return 0, false
+}
+
+// Totals 新旧公式的发放总额
+func (e FormulaEvaluation) Totals() (before, after int) {
+	for _, p := range e.Payouts {
+		before += p.Old
+		after += p.New
+	}
+	return before, after
+}
+
+// formulaGroup 按模式与胜负汇总
+type formulaGroup struct {
+	mode    string
+	victory bool
+	runs    int
+	before  int
+	after   int
+}
+
+// WriteSummary 输出按模式与胜负分组的对比表及变化最大的出击
+func (e FormulaEvaluation) WriteSummary(w io.Writer, top int) error {
+	var groups []*formulaGroup
+	for _, p := range e.Payouts {
+		i := slices.IndexFunc(groups, func(g *formulaGroup) bool { return g.mode == p.Run.Mode && g.victory == p.Run.Victory })
+		if i < 0 {
+			groups = append(groups, &formulaGroup{mode: p.Run.Mode, victory: p.Run.Victory})
+			i = len(groups) - 1
+		}
+		groups[i].runs++
+		groups[i].before += p.Old
+		groups[i].after += p.New
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "mode\tresult\truns\told\tnew\tchange\t")
+	for _, g := range groups {
+		result := "defeat"
+		if g.victory {
+			result = "victory"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\t\n", g.mode, result, g.runs, g.before, g.after, change(g.before, g.after))
+	}
+	before, after := e.Totals()
+	fmt.Fprintf(tw, "total\t\t%d\t%d\t%d\t%s\t\n", len(e.Payouts), before, after, change(before, after))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if e.Skipped > 0 {
+		fmt.Fprintf(w, "%d runs skipped (practice or unknown stage)\n", e.Skipped)
+	}
+
+	if top <= 0 || len(e.Payouts) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(e.Payouts)
+	slices.SortStableFunc(sorted, func(a, b RunPayout) int {
+		return abs(b.New-b.Old) - abs(a.New-a.Old)
+	})
+	fmt.Fprintf(w, "\nlargest changes:\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "run\tmode\tdifficulty\tkills\telapsed\told\tnew\t")
+	for _, p := range sorted[:min(top, len(sorted))] {
+		r := p.Run
+		fmt.Fprintf(tw, "#%d\t%s\t%.2f\t%d/%d\t%s\t%d\t%d\t\n", r.ID, r.Mode, r.Difficulty,
+			r.Kills+r.BossKills, r.Spawned+r.BossesSpawned, r.Elapsed.Round(time.Second), p.Old, p.New)
+	}
+	return tw.Flush()
+}
+
+// change 变化量，原值为 0 时给出绝对值
+func change(before, after int) string {
+	if before == 0 {
+		return fmt.Sprintf("%+d", after-before)
+	}
+	return fmt.Sprintf("%+.1f%%", float64(after-before)/float64(before)*100)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -3,6 +3,8 @@ package balance
 import (
 	"math"
 	"time"
+
+	"spacebattle/internal/config"
 )
 
 // RewardBreakdown 功勋奖励详细分解
@@ -13,6 +15,7 @@ type RewardBreakdown struct {
 	SpeedBonus       int     // 速度加成
 	PerfectBonus     int     // 完美通关加成
 	BossBonus        int     // Boss击杀加成
+	AssistDiscount   int     // 辅助模式折扣（已从总奖励中扣除）
 	TotalReward      int     // 总奖励
 	PerformanceScore float64 // 综合表现分数 (0-100)
 }
@@ -37,7 +40,7 @@ func ComputeMeritReward(
 	return breakdown.TotalReward
 }
 
-// ComputeDetailedReward 计算详细的功勋奖励分解
+// ComputeDetailedReward 计算详细的功勋奖励分解（使用默认配置的奖励公式）
 func ComputeDetailedReward(
 	difficultyMul float64,
 	killedCount int,
@@ -45,6 +48,19 @@ func ComputeDetailedReward(
 	elapsed time.Duration,
 	total time.Duration,
 	victory bool,
+) RewardBreakdown {
+	return ComputeDetailedRewardWith(config.DefaultConfig().Reward, difficultyMul, killedCount, spawnedCount, elapsed, total, victory)
+}
+
+// ComputeDetailedRewardWith 按给定公式参数计算详细的功勋奖励分解
+func ComputeDetailedRewardWith(
+	rc config.RewardConfig,
+	difficultyMul float64,
+	killedCount int,
+	spawnedCount int,
+	elapsed time.Duration,
+	total time.Duration,
+	victory bool,
 ) RewardBreakdown {
 	breakdown := RewardBreakdown{}
 
@@ -53,20 +69,13 @@ func ComputeDetailedReward(
 	}
 
 	// === 1. 基础奖励：与难度成本相关 ===
-	cost := DifficultyCost(difficultyMul)
-	if cost <= 0 {
-		// 低难度给予最低基础奖励
-		breakdown.BaseReward = 15
-	} else {
-		// 基础奖励 = 成本 * 1.5（保证回本并有盈余）
-		breakdown.BaseReward = int(math.Round(float64(cost) * 1.5))
-	}
+	breakdown.BaseReward = baseReward(rc, difficultyMul)
 
 	// === 2. 难度加成：高难度有更高的回报 ===
 	// 难度越高，倍率越高（对数增长，避免爆炸）
 	if difficultyMul > 1.0 {
-		// 公式：baseReward * (0.5 * log2(difficulty))
-		difficultyBonusRate := 0.5 * math.Log2(difficultyMul)
+		// 公式：baseReward * (K * log2(difficulty))
+		difficultyBonusRate := rc.DifficultyBonusK * math.Log2(difficultyMul)
 		breakdown.DifficultyBonus = int(math.Round(float64(breakdown.BaseReward) * difficultyBonusRate))
 	}
 
@@ -79,13 +88,13 @@ func ComputeDetailedReward(
 		}
 	}
 
-	// 击杀率 > 80% 开始有显著加成
-	if killRatio >= 0.8 {
-		killBonusRate := (killRatio - 0.7) / 0.3 // 70%-100% 映射到 0.33-1.0
+	// 击杀率达到门槛开始有显著加成
+	if killRatio >= rc.KillThreshold {
+		killBonusRate := (killRatio - rc.KillRampFrom) / (1 - rc.KillRampFrom) // 起点-100% 映射到 0-1.0
 		if killBonusRate > 1.0 {
 			killBonusRate = 1.0
 		}
-		breakdown.KillBonus = int(math.Round(float64(breakdown.BaseReward) * 0.5 * killBonusRate))
+		breakdown.KillBonus = int(math.Round(float64(breakdown.BaseReward) * rc.KillRate * killBonusRate))
 	}
 
 	// === 4. 速度加成：快速通关 ===
@@ -101,70 +110,168 @@ func ComputeDetailedReward(
 		}
 	}
 
-	// 剩余时间 > 20% 开始有加成
-	if speedRatio > 0.2 {
-		speedBonusRate := (speedRatio - 0.2) / 0.8 // 20%-100% 映射到 0-1.0
-		breakdown.SpeedBonus = int(math.Round(float64(breakdown.BaseReward) * 0.3 * speedBonusRate))
+	// 剩余时间超过门槛开始有加成
+	if speedRatio > rc.SpeedThreshold {
+		speedBonusRate := (speedRatio - rc.SpeedThreshold) / (1 - rc.SpeedThreshold) // 门槛-100% 映射到 0-1.0
+		breakdown.SpeedBonus = int(math.Round(float64(breakdown.BaseReward) * rc.SpeedRate * speedBonusRate))
 	}
 
 	// === 5. Boss加成：击杀Boss额外奖励 ===
 	// 判断是否击杀了Boss（killedCount >= spawnedCount 说明全击杀包括Boss）
 	bossKilled := killedCount >= spawnedCount && spawnedCount > 0
 	if bossKilled {
-		// Boss加成为基础奖励的 30%
-		breakdown.BossBonus = int(math.Round(float64(breakdown.BaseReward) * 0.3))
+		breakdown.BossBonus = int(math.Round(float64(breakdown.BaseReward) * rc.BossRate))
 	}
 
 	// === 6. 完美通关加成：全击杀 + Boss击杀 + 速度快 ===
-	// 条件：击杀率 >= 95%，速度比 > 30%，Boss已击杀
-	if killRatio >= 0.95 && speedRatio > 0.3 && bossKilled {
-		// 完美通关给予巨额加成（总和的 50%）
-		currentTotal := breakdown.BaseReward + breakdown.DifficultyBonus + 
+	if killRatio >= rc.PerfectKillRatio && speedRatio > rc.PerfectSpeedRatio && bossKilled {
+		// 完美通关给予巨额加成（其余各项之和的一定比例）
+		currentTotal := breakdown.BaseReward + breakdown.DifficultyBonus +
 			breakdown.KillBonus + breakdown.SpeedBonus + breakdown.BossBonus
-		breakdown.PerfectBonus = int(math.Round(float64(currentTotal) * 0.5))
+		breakdown.PerfectBonus = int(math.Round(float64(currentTotal) * rc.PerfectRate))
 	}
 
 	// === 计算总奖励 ===
-	breakdown.TotalReward = breakdown.BaseReward + 
-		breakdown.DifficultyBonus + 
-		breakdown.KillBonus + 
-		breakdown.SpeedBonus + 
-		breakdown.BossBonus + 
+	breakdown.TotalReward = breakdown.BaseReward +
+		breakdown.DifficultyBonus +
+		breakdown.KillBonus +
+		breakdown.SpeedBonus +
+		breakdown.BossBonus +
 		breakdown.PerfectBonus
 
 	// 确保最低奖励
-	if breakdown.TotalReward < 10 {
-		breakdown.TotalReward = 10
+	if breakdown.TotalReward < rc.MinTotal {
+		breakdown.TotalReward = rc.MinTotal
 	}
 
 	// === 计算综合表现分数（用于显示） ===
-	breakdown.PerformanceScore = calculatePerformanceScore(killRatio, speedRatio, bossKilled)
+	breakdown.PerformanceScore = calculatePerformanceScore(rc, killRatio, speedRatio, bossKilled)
 
 	return breakdown
 }
 
-// calculatePerformanceScore 计算综合表现分数 (0-100)
-func calculatePerformanceScore(killRatio, speedRatio float64, bossKilled bool) float64 {
-	score := 0.0
-	
-	// 击杀率占 40 分
-	score += killRatio * 40.0
-	
-	// 速度占 30 分
-	score += speedRatio * 30.0
-	
-	// Boss击杀占 30 分
-	if bossKilled {
-		score += 30.0
+// baseReward 基础奖励：难度成本的固定倍数，低难度（成本为 0）给予保底奖励
+func baseReward(rc config.RewardConfig, difficultyMul float64) int {
+	cost := DifficultyCost(difficultyMul)
+	if cost <= 0 {
+		return rc.MinBase
 	}
-	
-	// 钳制到 0-100
-	if score < 0 {
-		score = 0
+	return int(math.Round(float64(cost) * rc.BaseCostRatio))
+}
+
+// SettleStandardReward 标准模式结算奖励：胜利按详细分解发放，失败只发基础奖励的一部分作为安慰奖。
+// 战斗结算与经济模拟共用
+func SettleStandardReward(
+	difficultyMul float64,
+	killedCount int,
+	spawnedCount int,
+	elapsed time.Duration,
+	total time.Duration,
+	victory bool,
+) RewardBreakdown {
+	return SettleStandardRewardWith(config.DefaultConfig().Reward, difficultyMul, killedCount, spawnedCount, elapsed, total, victory)
+}
+
+// SettleStandardRewardWith 按给定公式参数结算标准模式奖励
+func SettleStandardRewardWith(
+	rc config.RewardConfig,
+	difficultyMul float64,
+	killedCount int,
+	spawnedCount int,
+	elapsed time.Duration,
+	total time.Duration,
+	victory bool,
+) RewardBreakdown {
+	if !victory {
+		base := baseReward(rc, difficultyMul)
+		return RewardBreakdown{
+			BaseReward:  base,
+			TotalReward: int(math.Floor(float64(base)*rc.ConsolationRatio + 1e-9)),
+		}
+	}
+	return ComputeDetailedRewardWith(rc, difficultyMul, killedCount, spawnedCount, elapsed, total, victory)
+}
+
+// ComputeSurvivalReward 计算无尽模式的功勋奖励（使用默认配置的奖励公式）
+func ComputeSurvivalReward(
+	difficultyMul float64,
+	survival time.Duration,
+	bossKills int,
+) RewardBreakdown {
+	return ComputeSurvivalRewardWith(config.DefaultConfig().Reward, difficultyMul, survival, bossKills)
+}
+
+// ComputeSurvivalRewardWith 按给定公式参数计算无尽模式的功勋奖励
+// 无尽模式没有胜利判定，奖励按存活时间发放：
+// 1. 基础奖励：每存活一分钟给予一次标准通关的基础奖励（按秒折算）
+// 2. 难度加成：与标准模式相同的对数加成
+// 3. Boss加成：每击败一只 Boss 给予每分钟基础奖励的 Boss 加成比例
+func ComputeSurvivalRewardWith(
+	rc config.RewardConfig,
+	difficultyMul float64,
+	survival time.Duration,
+	bossKills int,
+) RewardBreakdown {
+	breakdown := RewardBreakdown{}
+	if survival <= 0 {
+		return breakdown
+	}
+
+	// === 1. 基础奖励：每分钟存活的奖励与标准通关一致 ===
+	perMinute := float64(baseReward(rc, difficultyMul))
+	breakdown.BaseReward = int(math.Round(perMinute * survival.Minutes()))
+
+	// === 2. 难度加成 ===
+	if difficultyMul > 1.0 {
+		difficultyBonusRate := rc.DifficultyBonusK * math.Log2(difficultyMul)
+		breakdown.DifficultyBonus = int(math.Round(float64(breakdown.BaseReward) * difficultyBonusRate))
+	}
+
+	// === 3. Boss加成 ===
+	if bossKills > 0 {
+		breakdown.BossBonus = int(math.Round(perMinute * rc.BossRate * float64(bossKills)))
 	}
-	if score > 100 {
-		score = 100
+
+	breakdown.TotalReward = breakdown.BaseReward +
+		breakdown.DifficultyBonus +
+		breakdown.BossBonus
+
+	// === 表现分数：存活 5 分钟记 70 分，每只 Boss 10 分（上限 30） ===
+	score := math.Min(survival.Minutes()/5.0, 1.0) * 70.0
+	score += math.Min(float64(bossKills)*10.0, 30.0)
+	breakdown.PerformanceScore = math.Round(score)
+
+	return breakdown
+}
+
+// ApplyAssistDiscount 扣除辅助模式折扣（使用默认配置的奖励公式）
+func ApplyAssistDiscount(breakdown RewardBreakdown, avgEase, maxLevel float64) RewardBreakdown {
+	return ApplyAssistDiscountWith(config.DefaultConfig().Reward, breakdown, avgEase, maxLevel)
+}
+
+// ApplyAssistDiscountWith 辅助模式折扣：开启即扣除基础比例，平均减难越多扣除越多
+// avgEase 为按时间加权的平均减难等级，maxLevel 为减难上限
+func ApplyAssistDiscountWith(rc config.RewardConfig, breakdown RewardBreakdown, avgEase, maxLevel float64) RewardBreakdown {
+	if breakdown.TotalReward <= 0 {
+		return breakdown
+	}
+
+	rate := rc.AssistBaseDiscount
+	if maxLevel > 0 && avgEase > 0 {
+		rate += rc.AssistEaseDiscount * math.Min(avgEase/maxLevel, 1.0)
+	}
+	rate = math.Min(rate, 0.9)
+
+	breakdown.AssistDiscount = int(math.Round(float64(breakdown.TotalReward) * rate))
+	breakdown.TotalReward -= breakdown.AssistDiscount
+	return breakdown
+}
+
+// calculatePerformanceScore 计算综合表现分数 (0-100)，击杀率、速度与 Boss 击杀按配置权重计分
+func calculatePerformanceScore(rc config.RewardConfig, killRatio, speedRatio float64, bossKilled bool) float64 {
+	score := killRatio*rc.ScoreKillWeight + speedRatio*rc.ScoreSpeedWeight
+	if bossKilled {
+		score += rc.ScoreBossWeight
 	}
-	
-	return math.Round(score)
+	return math.Round(math.Max(0, math.Min(100, score)))
 }
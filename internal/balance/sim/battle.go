@@ -0,0 +1,64 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+
+	"spacebattle/internal/bot"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/simulation"
+	"spacebattle/internal/ships"
+	"spacebattle/internal/upgrades"
+)
+
+// Battle 无界面运行一局标准模式：与战斗场景相同的战斗管线，由机器人飞行员操作，
+// 按固定步长连续推进直到胜利、阵亡或总时长用尽。使用默认战机，不计天赋
+func Battle(levels upgrades.Levels, difficulty float64, skill bot.Skill, seed int64) Outcome {
+	world := ecs.NewWorld()
+	world.Seed(seed)
+	pipeline := simulation.NewBattlePipeline(world, bot.NewPilot(skill, seed))
+
+	ship := ships.Default()
+	fire := levels.FireSkill()
+	simulation.InitializeFireSkill(&fire)
+	player := world.CreatePlayer(0, 400, 500, 40*ship.SizeScale, 30*ship.SizeScale, ship.Speed, fire)
+	components.Health.SetValue(player, components.HealthData{Current: ship.Lives, Max: ship.Lives})
+	components.ShipAbility.SetValue(player, components.ShipAbilityData{
+		AbilityType:   ship.AbilityType,
+		ShieldMax:     ship.Lives,
+		ShieldCurrent: ship.Lives,
+	})
+	gameState := components.GameState.Get(world.CreateGameState(ship.Lives, difficulty))
+
+	for !gameState.GameOver && !gameState.Victory {
+		// 与战斗场景相同的硬收束：总时长用尽即胜利
+		if gameState.Elapsed() >= gameState.TotalDuration {
+			gameState.Victory = true
+			break
+		}
+		pipeline.Update(world, ecs.TickSeconds)
+	}
+
+	return Outcome{
+		Victory: gameState.Victory,
+		Kills:   gameState.KilledEnemyCount + gameState.BossKillCount(),
+		Spawned: gameState.SpawnedCount + len(gameState.Bosses),
+		Elapsed: gameState.Elapsed(),
+		Total:   gameState.TotalDuration,
+	}
+}
+
+// Headless 逐局运行 Battle 的出击来源：结果来自真实战斗，但每局需完整模拟，适合少量玩家
+type Headless struct {
+	Skill bot.Skill
+}
+
+// Play 以 r 抽取的种子运行一局
+func (h Headless) Play(levels upgrades.Levels, difficulty float64, r *rand.Rand) Outcome {
+	return Battle(levels, difficulty, h.Skill, r.Int63())
+}
+
+func (h Headless) String() string {
+	return fmt.Sprintf("headless battles (%s bot)", h.Skill.Name)
+}
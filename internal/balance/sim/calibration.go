@@ -0,0 +1,241 @@
+package sim
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"slices"
+
+	"spacebattle/internal/bot"
+	"spacebattle/internal/config"
+	"spacebattle/internal/upgrades"
+)
+
+// Sample 一局机器人出击的记录：出击时的火力倍率、难度与战果
+type Sample struct {
+	Power      float64
+	Difficulty float64
+	Outcome
+}
+
+// 标定网格：升级预算（按均衡策略购买）与难度倍率
+var (
+	CalibrationBudgets      = []int{0, 10, 20, 50, 100, 300, 1000, 4000}
+	CalibrationDifficulties = []float64{1, 1.5, 2, 3, 5, 8}
+)
+
+// calibrationData 各技术水平的机器人出击记录，由 balance-report -record 生成
+//
+//go:embed calibration.json
+var calibrationData []byte
+
+// CalibrationBuilds 按标定预算以均衡策略购买升级得到的升级等级，火力依次递增
+func CalibrationBuilds() []upgrades.Levels {
+	builds := make([]upgrades.Levels, 0, len(CalibrationBudgets))
+	for _, budget := range CalibrationBudgets {
+		p := &Player{Merits: budget, Levels: upgrades.Levels{}, Difficulty: config.DefaultConfig().DifficultyMin}
+		BalancedUpgrades{}.Buy(p)
+		builds = append(builds, p.Levels)
+	}
+	return builds
+}
+
+// Record 在升级等级 × 难度网格上逐局运行 Battle，每格 runs 局
+func Record(skill bot.Skill, builds []upgrades.Levels, difficulties []float64, runs int, seed int64) []Sample {
+	r := rand.New(rand.NewSource(seed))
+	var samples []Sample
+	for _, levels := range builds {
+		for _, d := range difficulties {
+			for range runs {
+				samples = append(samples, Sample{
+					Power:      Power(levels),
+					Difficulty: d,
+					Outcome:    Battle(levels, d, skill, r.Int63()),
+				})
+			}
+		}
+	}
+	return samples
+}
+
+// WriteCalibration 以技术水平为键写出出击记录，每条记录一行便于比对
+func WriteCalibration(w io.Writer, records map[string][]Sample) error {
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for i, name := range names {
+		fmt.Fprintf(&b, "  %q: [\n", name)
+		for j, s := range records[name] {
+			line, err := json.Marshal(s)
+			if err != nil {
+				return err
+			}
+			b.WriteString("    ")
+			b.Write(line)
+			if j < len(records[name])-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString("  ]")
+		if i < len(names)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Calibration 随程序发布的机器人出击记录（技术水平 -> 记录）
+func Calibration() (map[string][]Sample, error) {
+	var records map[string][]Sample
+	if err := json.Unmarshal(calibrationData, &records); err != nil {
+		return nil, fmt.Errorf("calibration data: %w", err)
+	}
+	return records, nil
+}
+
+// ModelFor 用该技术水平的机器人出击记录拟合出击模型
+func ModelFor(skill bot.Skill) (Model, error) {
+	records, err := Calibration()
+	if err != nil {
+		return Model{}, err
+	}
+	samples, ok := records[skill.Name]
+	if !ok {
+		return Model{}, fmt.Errorf("no calibration runs recorded for skill %q", skill.Name)
+	}
+	return Fit(skill.Name, samples)
+}
+
+// minFitSamples 拟合所需的最少记录数
+const minFitSamples = 20
+
+// Fit 由出击记录拟合出击模型：通关率用 logistic 回归，击杀率（logit）、用时比例与生成速率用最小二乘
+func Fit(skill string, samples []Sample) (Model, error) {
+	if len(samples) < minFitSamples {
+		return Model{}, fmt.Errorf("fit needs at least %d samples, got %d", minFitSamples, len(samples))
+	}
+	m := Model{Skill: skill, Samples: len(samples)}
+
+	var edges, cleared []float64
+	var killX, killY []float64
+	var winX, winY, lossX, lossY []float64
+	var rateX, rateY []float64
+	for _, s := range samples {
+		e := edge(s.Power, s.Difficulty)
+		m.Total = max(m.Total, s.Total)
+		edges = append(edges, e)
+		used := 0.0
+		if s.Total > 0 {
+			used = s.Elapsed.Seconds() / s.Total.Seconds()
+		}
+		if s.Victory {
+			cleared = append(cleared, 1)
+			winX, winY = append(winX, e), append(winY, used)
+		} else {
+			cleared = append(cleared, 0)
+			lossX, lossY = append(lossX, e), append(lossY, used)
+		}
+		if s.Spawned > 0 {
+			ratio := clamp(float64(s.Kills)/float64(s.Spawned), 0.01, 0.99)
+			killX, killY = append(killX, e), append(killY, math.Log(ratio/(1-ratio)))
+		}
+		if s.Elapsed > 0 {
+			rateX, rateY = append(rateX, s.Difficulty), append(rateY, float64(s.Spawned)/s.Elapsed.Seconds())
+		}
+	}
+	if m.Total <= 0 {
+		return Model{}, fmt.Errorf("samples carry no level duration")
+	}
+
+	m.Clear = fitLogistic(edges, cleared)
+	m.Kill, m.KillNoise = fitLinear(killX, killY)
+
+	// 某一类（全胜或全败）没有记录时，用全部记录的用时比例代替
+	allX, allY := append(slices.Clone(winX), lossX...), append(slices.Clone(winY), lossY...)
+	var winNoise, lossNoise float64
+	if len(winX) == 0 {
+		winX, winY = allX, allY
+	}
+	if len(lossX) == 0 {
+		lossX, lossY = allX, allY
+	}
+	m.ClearTime, winNoise = fitLinear(winX, winY)
+	m.Lasted, lossNoise = fitLinear(lossX, lossY)
+	m.TimeNoise = math.Sqrt((winNoise*winNoise*float64(len(winX)) + lossNoise*lossNoise*float64(len(lossX))) / float64(len(winX)+len(lossX)))
+
+	m.SpawnRate, _ = fitLinear(rateX, rateY)
+	return m, nil
+}
+
+// fitLinear 最小二乘拟合 y = a + b·x，返回曲线与残差标准差；x 没有变化时斜率为 0
+func fitLinear(xs, ys []float64) (Curve, float64) {
+	n := float64(len(xs))
+	if n == 0 {
+		return Curve{}, 0
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i] / n
+		my += ys[i] / n
+	}
+	var sxy, sxx float64
+	for i := range xs {
+		sxy += (xs[i] - mx) * (ys[i] - my)
+		sxx += (xs[i] - mx) * (xs[i] - mx)
+	}
+	c := Curve{Intercept: my}
+	if sxx > 1e-12 {
+		c.Slope = sxy / sxx
+		c.Intercept = my - c.Slope*mx
+	}
+	var ss float64
+	for i := range xs {
+		r := ys[i] - c.Linear(xs[i])
+		ss += r * r
+	}
+	return c, math.Sqrt(ss / n)
+}
+
+// logisticRidge logistic 回归的 L2 正则强度：全胜或全败（可完全分离）时系数仍有限
+const logisticRidge = 0.1
+
+// fitLogistic 牛顿法拟合 P(y=1) = sigmoid(a + b·x)
+func fitLogistic(xs, ys []float64) Curve {
+	var a, b float64
+	for range 100 {
+		ga, gb := -logisticRidge*a, -logisticRidge*b
+		haa, hab, hbb := logisticRidge, 0.0, logisticRidge
+		for i, x := range xs {
+			p := sigmoid(a + b*x)
+			ga += ys[i] - p
+			gb += (ys[i] - p) * x
+			w := p * (1 - p)
+			haa += w
+			hab += w * x
+			hbb += w * x * x
+		}
+		det := haa*hbb - hab*hab
+		if det <= 1e-12 {
+			break
+		}
+		da := (hbb*ga - hab*gb) / det
+		db := (haa*gb - hab*ga) / det
+		a, b = a+da, b+db
+		if math.Abs(da) < 1e-9 && math.Abs(db) < 1e-9 {
+			break
+		}
+	}
+	return Curve{Intercept: a, Slope: b}
+}
@@ -0,0 +1,104 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"spacebattle/internal/upgrades"
+)
+
+// Outcome 一次出击的战果，字段与标准模式结算的输入一致
+type Outcome struct {
+	Victory bool
+	Kills   int
+	Spawned int
+	Elapsed time.Duration
+	Total   time.Duration
+}
+
+// Sortie 出击战果的来源：拟合模型（Model）或逐局无界面战斗（Headless）
+type Sortie interface {
+	Play(levels upgrades.Levels, difficulty float64, r *rand.Rand) Outcome
+	String() string
+}
+
+// Curve 一元曲线 Intercept + Slope·x，按需线性使用或经 logistic 映射到 (0, 1)
+type Curve struct {
+	Intercept float64
+	Slope     float64
+}
+
+// Linear 线性值
+func (c Curve) Linear(x float64) float64 {
+	return c.Intercept + c.Slope*x
+}
+
+// Logistic logistic 映射后的值
+func (c Curve) Logistic(x float64) float64 {
+	return sigmoid(c.Linear(x))
+}
+
+// Model 出击结果模型，全部系数由机器人出击记录拟合（见 Fit）。
+// 自变量 edge = log2(火力/难度)；逐局模拟见 Battle，整段经济模拟逐局运行太慢，因此用模型代替
+type Model struct {
+	Skill     string        // 拟合所用记录的机器人技术水平
+	Samples   int           // 拟合所用的出击记录数
+	Clear     Curve         // 通关率（logistic）
+	Kill      Curve         // 击杀率的 logit（线性）
+	ClearTime Curve         // 通关用时占总时长的比例
+	Lasted    Curve         // 失败时坚持时长占总时长的比例
+	SpawnRate Curve         // 每秒生成数（含 Boss），自变量为难度
+	KillNoise float64       // 击杀率 logit 的残差标准差
+	TimeNoise float64       // 用时比例的残差标准差
+	Total     time.Duration // 关卡总时长
+}
+
+// ClearChance 给定火力倍率与难度时的通关概率
+func (m Model) ClearChance(power, difficulty float64) float64 {
+	return m.Clear.Logistic(edge(power, difficulty))
+}
+
+// KillRatio 给定火力倍率与难度时的典型击杀率
+func (m Model) KillRatio(power, difficulty float64) float64 {
+	return m.Kill.Logistic(edge(power, difficulty))
+}
+
+// Play 按升级等级估计火力后抽取一次战果
+func (m Model) Play(levels upgrades.Levels, difficulty float64, r *rand.Rand) Outcome {
+	e := edge(Power(levels), difficulty)
+	out := Outcome{
+		Victory: r.Float64() < m.Clear.Logistic(e),
+		Total:   m.Total,
+	}
+
+	used := m.Lasted.Linear(e)
+	if out.Victory {
+		used = m.ClearTime.Linear(e)
+	}
+	used = clamp(used+r.NormFloat64()*m.TimeNoise, 0.05, 1)
+	out.Elapsed = time.Duration(used * float64(m.Total))
+
+	out.Spawned = max(int(math.Round(m.SpawnRate.Linear(difficulty)*out.Elapsed.Seconds())), 1)
+	killRatio := sigmoid(m.Kill.Linear(e) + r.NormFloat64()*m.KillNoise)
+	out.Kills = int(math.Round(killRatio * float64(out.Spawned)))
+	return out
+}
+
+func (m Model) String() string {
+	return fmt.Sprintf("model fitted from %d %s bot runs", m.Samples, m.Skill)
+}
+
+// edge 火力相对难度的优势
+func edge(power, difficulty float64) float64 {
+	return math.Log2(power / math.Max(difficulty, 1e-9))
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
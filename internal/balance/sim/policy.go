@@ -0,0 +1,121 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"spacebattle/internal/balance"
+	"spacebattle/internal/config"
+	"spacebattle/internal/upgrades"
+)
+
+// DifficultyPolicy 每次出击前选择难度倍率
+type DifficultyPolicy interface {
+	Choose(p *Player) float64
+	String() string
+}
+
+// UpgradePolicy 每次出击后用功勋购买升级，返回花费
+type UpgradePolicy interface {
+	Buy(p *Player) int
+	String() string
+}
+
+// FixedDifficulty 始终选择同一难度（付不起时退到可负担的最高难度）
+type FixedDifficulty float64
+
+func (f FixedDifficulty) Choose(p *Player) float64 {
+	return float64(f)
+}
+
+func (f FixedDifficulty) String() string {
+	return fmt.Sprintf("fixed:%g", float64(f))
+}
+
+// AffordableDifficulty 按功勋的一定比例选择能负担的最高难度
+type AffordableDifficulty float64
+
+func (a AffordableDifficulty) Choose(p *Player) float64 {
+	return balance.MaxAffordableDifficulty(int(float64(p.Merits) * float64(a)))
+}
+
+func (a AffordableDifficulty) String() string {
+	return fmt.Sprintf("affordable:%g", float64(a))
+}
+
+// LadderDifficulty 胜利后提升、失败后降低固定步长
+type LadderDifficulty float64
+
+func (l LadderDifficulty) Choose(p *Player) float64 {
+	cfg := config.DefaultConfig()
+	if p.Sorties == 0 {
+		return cfg.DifficultyMin
+	}
+	if p.LastVictory {
+		return p.Difficulty + float64(l)
+	}
+	return math.Max(cfg.DifficultyMin, p.Difficulty-float64(l))
+}
+
+func (l LadderDifficulty) String() string {
+	return fmt.Sprintf("ladder:%g", float64(l))
+}
+
+// ParseDifficultyPolicy 解析难度策略：fixed:<倍率>、affordable:<功勋比例>、ladder:<步长>
+func ParseDifficultyPolicy(s string) (DifficultyPolicy, error) {
+	name, arg, _ := strings.Cut(s, ":")
+	v, err := strconv.ParseFloat(arg, 64)
+	if err != nil || v <= 0 {
+		return nil, fmt.Errorf("difficulty policy %q: expected <name>:<positive number>", s)
+	}
+	switch name {
+	case "fixed":
+		return FixedDifficulty(v), nil
+	case "affordable":
+		return AffordableDifficulty(math.Min(v, 1)), nil
+	case "ladder":
+		return LadderDifficulty(v), nil
+	}
+	return nil, fmt.Errorf("unknown difficulty policy %q (fixed, affordable, ladder)", name)
+}
+
+// NoUpgrades 从不购买升级（只存功勋）
+type NoUpgrades struct{}
+
+func (NoUpgrades) Buy(p *Player) int { return 0 }
+func (NoUpgrades) String() string    { return "none" }
+
+// CheapestUpgrades 反复购买当前最便宜的升级
+type CheapestUpgrades struct{}
+
+func (CheapestUpgrades) Buy(p *Player) int {
+	return p.buyWhile(func(_ upgrades.Upgrade, cost int, _ upgrades.Upgrade, bestCost int) bool { return cost < bestCost })
+}
+
+func (CheapestUpgrades) String() string { return "cheapest" }
+
+// BalancedUpgrades 反复购买等级最低的升级，使各项均衡成长
+type BalancedUpgrades struct{}
+
+func (BalancedUpgrades) Buy(p *Player) int {
+	return p.buyWhile(func(u upgrades.Upgrade, cost int, best upgrades.Upgrade, bestCost int) bool {
+		return p.Levels[u.Key] < p.Levels[best.Key] || p.Levels[u.Key] == p.Levels[best.Key] && cost < bestCost
+	})
+}
+
+func (BalancedUpgrades) String() string { return "balanced" }
+
+// ParseUpgradePolicy 解析升级策略：none、cheapest、balanced
+func ParseUpgradePolicy(s string) (UpgradePolicy, error) {
+	switch s {
+	case "none":
+		return NoUpgrades{}, nil
+	case "cheapest":
+		return CheapestUpgrades{}, nil
+	case "balanced":
+		return BalancedUpgrades{}, nil
+	}
+	return nil, fmt.Errorf("unknown upgrade policy %q (none, cheapest, balanced)", s)
+}
@@ -0,0 +1,17 @@
+package sim
+
+import "spacebattle/internal/upgrades"
+
+// Power 按升级等级粗略估计的火力倍率（初始战机为 1）：
+// 射速、弹数、伤害、穿透与连发相乘，追踪与弹速只给小幅加成
+func Power(levels upgrades.Levels) float64 {
+	lv := func(key string) float64 { return float64(levels[key]) }
+	power := (5.0 + 0.5*lv("fire_rate")) / 5.0
+	power *= 1 + 0.6*lv("bullets_per_shot")
+	power *= 1 + lv("bullet_damage")
+	power *= 1 + 0.3*lv("penetration")
+	power *= 1 + 0.05*lv("burst_chance")
+	power *= 1 + 0.02*lv("bullet_speed")
+	power *= 1 + 0.2*lv("enable_homing")*(1+0.1*lv("turn_rate"))
+	return power
+}
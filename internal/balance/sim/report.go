@@ -0,0 +1,156 @@
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WriteCSV 每次出击一行
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"sortie", "merits_mean", "merits_median", "difficulty", "power", "clear_rate", "reward", "upgrade_spend"})
+	for _, p := range r.Points {
+		_ = cw.Write([]string{
+			strconv.Itoa(p.Sortie),
+			formatFloat(p.MeritsMean),
+			formatFloat(p.MeritsMedian),
+			formatFloat(p.Difficulty),
+			formatFloat(p.Power),
+			formatFloat(p.ClearRate),
+			formatFloat(p.Reward),
+			formatFloat(p.Spent),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+// Diagnose 启发式检查：功勋只增不花（失控）或难度与火力长期不变（停滞）
+func (r *Report) Diagnose() []string {
+	n := len(r.Points)
+	if n < 8 {
+		return nil
+	}
+	var notes []string
+	tail := r.Points[n-n/4:]
+	first, last := tail[0], tail[len(tail)-1]
+
+	growing := true
+	for i := 1; i < len(tail); i++ {
+		if tail[i].MeritsMean <= tail[i-1].MeritsMean {
+			growing = false
+			break
+		}
+	}
+	if growing && last.Reward > 0 && last.MeritsMean > 10*last.Reward {
+		notes = append(notes, fmt.Sprintf("runaway: merits keep piling up unspent (%.0f, %.1f× the per-sortie reward)", last.MeritsMean, last.MeritsMean/last.Reward))
+	}
+	if last.Difficulty <= first.Difficulty*1.01 && last.Power <= first.Power*1.01 {
+		notes = append(notes, fmt.Sprintf("stalled: difficulty %.2f and power %.2f flat over the last %d sorties", last.Difficulty, last.Power, len(tail)))
+	}
+	if last.ClearRate < 0.2 {
+		notes = append(notes, fmt.Sprintf("grinding: clear rate %.0f%% at the end of the run", last.ClearRate*100))
+	}
+	return notes
+}
+
+// series 一条折线
+type series struct {
+	name  string
+	color string
+	value func(Point) float64
+}
+
+// WriteHTML 输出带 SVG 折线图的独立 HTML 报告
+func (r *Report) WriteHTML(w io.Writer) error {
+	var b strings.Builder
+	cfg := r.Config
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Balance report</title>\n")
+	b.WriteString("<style>body{font-family:sans-serif;background:#0A1024;color:#ddd;margin:24px}svg{background:#1E163F;margin:8px 0}h2{font-size:16px;margin:16px 0 0}li{color:#FFD300}</style>\n")
+	b.WriteString("</head><body>\n<h1>Merit economy</h1>\n")
+	fmt.Fprintf(&b, "<p>%d players × %d sorties, seed %d, start merits %d, outcomes from %s, difficulty policy <b>%s</b>, upgrade policy <b>%s</b></p>\n",
+		cfg.Players, cfg.Sorties, cfg.Seed, cfg.StartMerits, html.EscapeString(cfg.Sortie.String()),
+		html.EscapeString(cfg.Difficulty.String()), html.EscapeString(cfg.Upgrades.String()))
+	if notes := r.Diagnose(); len(notes) > 0 {
+		b.WriteString("<ul>\n")
+		for _, n := range notes {
+			fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(n))
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	r.chart(&b, "Merit balance", []series{
+		{"mean", "#00E5FF", func(p Point) float64 { return p.MeritsMean }},
+		{"median", "#FF2EC4", func(p Point) float64 { return p.MeritsMedian }},
+		{"upgrade spend", "#FFD300", func(p Point) float64 { return p.Spent }},
+	})
+	r.chart(&b, "Difficulty", []series{
+		{"difficulty", "#FF8C00", func(p Point) float64 { return p.Difficulty }},
+	})
+	r.chart(&b, "Power", []series{
+		{"power", "#00E5FF", func(p Point) float64 { return p.Power }},
+	})
+	r.chart(&b, "Clear rate", []series{
+		{"clear rate", "#FF3B30", func(p Point) float64 { return p.ClearRate }},
+	})
+	b.WriteString("</body></html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// chart 绘制一张折线图，纵轴从 0 到各条线的最大值
+func (r *Report) chart(b *strings.Builder, title string, lines []series) {
+	const (
+		width, height = 720.0, 240.0
+		left, bottom  = 56.0, 24.0
+		top, right    = 12.0, 12.0
+	)
+	maxY := 0.0
+	for _, s := range lines {
+		for _, p := range r.Points {
+			maxY = math.Max(maxY, s.value(p))
+		}
+	}
+	if maxY == 0 {
+		maxY = 1
+	}
+	n := max(len(r.Points)-1, 1)
+	x := func(i int) float64 { return left + (width-left-right)*float64(i)/float64(n) }
+	y := func(v float64) float64 { return height - bottom - (height-bottom-top)*v/maxY }
+
+	fmt.Fprintf(b, "<h2>%s</h2>\n", html.EscapeString(title))
+	fmt.Fprintf(b, "<svg width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.0f %.0f\">\n", width, height, width, height)
+	fmt.Fprintf(b, "<line x1=\"%.0f\" y1=\"%.0f\" x2=\"%.0f\" y2=\"%.0f\" stroke=\"#888\"/>\n", left, height-bottom, width-right, height-bottom)
+	fmt.Fprintf(b, "<line x1=\"%.0f\" y1=\"%.0f\" x2=\"%.0f\" y2=\"%.0f\" stroke=\"#888\"/>\n", left, top, left, height-bottom)
+	fmt.Fprintf(b, "<text x=\"%.0f\" y=\"%.0f\" fill=\"#aaa\" font-size=\"11\" text-anchor=\"end\">%s</text>\n", left-4, top+8, axisLabel(maxY))
+	fmt.Fprintf(b, "<text x=\"%.0f\" y=\"%.0f\" fill=\"#aaa\" font-size=\"11\" text-anchor=\"end\">0</text>\n", left-4, height-bottom)
+	if len(r.Points) > 0 {
+		fmt.Fprintf(b, "<text x=\"%.0f\" y=\"%.0f\" fill=\"#aaa\" font-size=\"11\" text-anchor=\"end\">sortie %d</text>\n", width-right, height-6, r.Points[len(r.Points)-1].Sortie)
+	}
+	for i, s := range lines {
+		var pts []string
+		for j, p := range r.Points {
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x(j), y(s.value(p))))
+		}
+		fmt.Fprintf(b, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"2\" points=\"%s\"/>\n", s.color, strings.Join(pts, " "))
+		fmt.Fprintf(b, "<text x=\"%.0f\" y=\"%.0f\" fill=\"%s\" font-size=\"11\">%s</text>\n", left+8, top+12+float64(i)*14, s.color, html.EscapeString(s.name))
+	}
+	b.WriteString("</svg>\n")
+}
+
+func axisLabel(v float64) string {
+	if v >= 100 {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,161 @@
+// Package sim 功勋经济模拟：模拟玩家连续出击——按策略选择难度、按出击模型或无界面战斗获得战果与功勋、
+// 按策略购买升级——输出功勋结余、难度与火力随出击次数的变化，用于发现失控或停滞的经济
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"spacebattle/internal/balance"
+	"spacebattle/internal/config"
+	"spacebattle/internal/upgrades"
+)
+
+// Config 模拟参数
+type Config struct {
+	Sorties     int    // 每名玩家的出击次数
+	Players     int    // 模拟的玩家数，各点取平均
+	Seed        int64  // 随机种子，相同参数与种子结果一致
+	StartMerits int    // 初始功勋
+	Sortie      Sortie // 出击战果来源：拟合模型或无界面战斗
+	Difficulty  DifficultyPolicy
+	Upgrades    UpgradePolicy
+}
+
+// Player 一名模拟玩家的进度
+type Player struct {
+	Merits      int
+	Levels      upgrades.Levels
+	Difficulty  float64
+	LastVictory bool
+	Sorties     int
+}
+
+// Power 当前火力倍率
+func (p *Player) Power() float64 {
+	return Power(p.Levels)
+}
+
+// buyWhile 在保留下次出击难度成本的前提下，反复购买 better 选出的升级，返回总花费。
+// 与升级界面相同：只买开放购买、前置已满足且未满级的升级
+func (p *Player) buyWhile(better func(u upgrades.Upgrade, cost int, best upgrades.Upgrade, bestCost int) bool) int {
+	reserve := balance.DifficultyCost(p.Difficulty)
+	spent := 0
+	for {
+		var best upgrades.Upgrade
+		bestCost := -1
+		for _, u := range upgrades.Registry {
+			cost, err := p.Levels.NextCost(u.Key)
+			if err != nil || cost > p.Merits-reserve {
+				continue
+			}
+			if bestCost < 0 || better(u, cost, best, bestCost) {
+				best, bestCost = u, cost
+			}
+		}
+		if bestCost < 0 {
+			return spent
+		}
+		p.Merits -= bestCost
+		p.Levels[best.Key]++
+		spent += bestCost
+	}
+}
+
+// Point 某次出击后全部玩家的统计
+type Point struct {
+	Sortie       int
+	MeritsMean   float64
+	MeritsMedian float64
+	Difficulty   float64 // 平均难度倍率
+	Power        float64 // 平均火力倍率
+	ClearRate    float64
+	Reward       float64 // 平均奖励
+	Spent        float64 // 平均升级花费
+}
+
+// Report 模拟结果
+type Report struct {
+	Config Config
+	Points []Point
+}
+
+// Run 执行模拟
+func Run(cfg Config) (*Report, error) {
+	if cfg.Sorties <= 0 || cfg.Players <= 0 {
+		return nil, fmt.Errorf("sorties and players must be positive")
+	}
+	if cfg.Difficulty == nil || cfg.Upgrades == nil {
+		return nil, fmt.Errorf("difficulty and upgrade policies are required")
+	}
+	if cfg.Sortie == nil {
+		return nil, fmt.Errorf("sortie outcome source is required")
+	}
+	difficultyMin := config.DefaultConfig().DifficultyMin
+	r := rand.New(rand.NewSource(cfg.Seed))
+
+	players := make([]*Player, cfg.Players)
+	for i := range players {
+		players[i] = &Player{
+			Merits:     cfg.StartMerits,
+			Levels:     upgrades.Levels{},
+			Difficulty: difficultyMin,
+		}
+	}
+
+	report := &Report{Config: cfg}
+	merits := make([]float64, cfg.Players)
+	for sortie := 1; sortie <= cfg.Sorties; sortie++ {
+		pt := Point{Sortie: sortie}
+		for i, p := range players {
+			// 出征：选择难度并支付成本，付不起时退到可负担的最高难度
+			d := max(cfg.Difficulty.Choose(p), difficultyMin)
+			if balance.DifficultyCost(d) > p.Merits {
+				d = balance.MaxAffordableDifficulty(p.Merits)
+			}
+			p.Merits -= balance.DifficultyCost(d)
+
+			out := cfg.Sortie.Play(p.Levels, d, r)
+			reward := balance.SettleStandardReward(d, out.Kills, out.Spawned, out.Elapsed, out.Total, out.Victory).TotalReward
+			p.Merits += reward
+			p.Difficulty = d
+			p.LastVictory = out.Victory
+			p.Sorties++
+
+			spent := cfg.Upgrades.Buy(p)
+
+			merits[i] = float64(p.Merits)
+			pt.Difficulty += d
+			pt.Power += p.Power()
+			pt.Reward += float64(reward)
+			pt.Spent += float64(spent)
+			if out.Victory {
+				pt.ClearRate++
+			}
+		}
+
+		n := float64(cfg.Players)
+		pt.Difficulty /= n
+		pt.Power /= n
+		pt.Reward /= n
+		pt.Spent /= n
+		pt.ClearRate /= n
+		for _, m := range merits {
+			pt.MeritsMean += m / n
+		}
+		pt.MeritsMedian = median(merits)
+		report.Points = append(report.Points, pt)
+	}
+	return report, nil
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
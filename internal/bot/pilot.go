@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"math"
+	"math/rand"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// cruiseY 无威胁时玩家停留的高度（靠近底部，留出上下闪避空间）
+	cruiseY = 470
+	// dodgeMargin 预测碰撞时额外留出的距离
+	dodgeMargin = 4
+	// dangerWeight 危险分相对位置偏好的权重，保证闪避优先于追击
+	dangerWeight = 1000
+)
+
+// Controls 一次决策产生的逻辑输入，与玩家按键一一对应
+type Controls struct {
+	Up, Down, Left, Right bool
+	Fire                  bool
+}
+
+// Pilot 脚本化的机器人飞行员：写入与人类玩家相同的移动意图与射击输入，
+// 可替代输入系统注册到 ecs.PhaseInput，用于无界面模拟与长时间测试
+type Pilot struct {
+	skill   Skill
+	rand    *rand.Rand
+	pending map[int][]Controls // 每个槽位尚未生效的决策（模拟反应延迟）
+}
+
+// NewPilot 创建机器人飞行员；seed 决定其感知失误序列，不消耗世界的随机数
+func NewPilot(skill Skill, seed int64) *Pilot {
+	return &Pilot{
+		skill:   skill,
+		rand:    rand.New(rand.NewSource(seed)),
+		pending: make(map[int][]Controls),
+	}
+}
+
+// Skill 当前技术水平
+func (p *Pilot) Skill() Skill {
+	return p.skill
+}
+
+// Update 为每名玩家决策并写入输入，实现 ecs.System
+func (p *Pilot) Update(w *ecs.World, dt float64) {
+	world := w.ECS.World
+	threats := collectThreats(world)
+	target := p.target(world)
+	query.NewQuery(filter.Contains(tags.Player, components.PlayerInput, components.Position, components.Velocity, components.Size)).
+		Each(world, func(entry *donburi.Entry) {
+			input := components.PlayerInput.Get(entry)
+			pos := components.Position.Get(entry)
+			size := components.Size.Get(entry)
+
+			c := p.delay(input.Slot, p.decide(*pos, *size, input.Speed, threats, target))
+			input.Up, input.Down, input.Left, input.Right = c.Up, c.Down, c.Left, c.Right
+			input.Fire = c.Fire
+			ecs.SteerPlayer(components.Velocity.Get(entry), input)
+			ecs.ClampToScreen(pos, size)
+		})
+}
+
+// delay 将决策排队，反应延迟之后才生效；排队期间保持射击、不移动
+func (p *Pilot) delay(slot int, c Controls) Controls {
+	queue := append(p.pending[slot], c)
+	ticks := p.skill.reactionTicks()
+	if len(queue) <= ticks {
+		p.pending[slot] = queue
+		return Controls{Fire: true}
+	}
+	p.pending[slot] = queue[1:]
+	return queue[0]
+}
+
+// threat 一个会伤到玩家的物体，速度为每 tick 位移
+type threat struct {
+	x, y, w, h float64
+	vx, vy     float64
+}
+
+// at 第 t 个 tick 后的预测位置（线性外推）
+func (t threat) at(tick int) (float64, float64) {
+	return t.x + t.vx*float64(tick), t.y + t.vy*float64(tick)
+}
+
+// collectThreats 收集敌机子弹与会撞到玩家的敌机、Boss
+func collectThreats(world donburi.World) []threat {
+	var out []threat
+	add := func(entry *donburi.Entry) {
+		pos := components.Position.Get(entry)
+		size := components.Size.Get(entry)
+		t := threat{x: pos.X, y: pos.Y, w: size.Width, h: size.Height}
+		if entry.HasComponent(components.Velocity) {
+			vel := components.Velocity.Get(entry)
+			t.vx, t.vy = vel.VX*ecs.TickSeconds, vel.VY*ecs.TickSeconds
+		}
+		out = append(out, t)
+	}
+	for _, tag := range []donburi.IComponentType{tags.EnemyBullet, tags.Enemy, tags.EnemyShooter, tags.EnemyZigzag, tags.EnemyTank, tags.Boss} {
+		query.NewQuery(filter.Contains(tag, components.Position, components.Size)).Each(world, add)
+	}
+	return out
+}
+
+// target 选择瞄准的水平位置：优先最靠下的敌机，其次 Boss，都没有时回到中央
+func (p *Pilot) target(world donburi.World) float64 {
+	best, bestY := float64(ecs.ScreenWidth)/2, math.Inf(-1)
+	for _, tag := range []donburi.IComponentType{tags.Enemy, tags.EnemyShooter, tags.EnemyZigzag, tags.EnemyTank} {
+		query.NewQuery(filter.Contains(tag, components.Position, components.Size)).Each(world, func(entry *donburi.Entry) {
+			pos := components.Position.Get(entry)
+			size := components.Size.Get(entry)
+			if pos.Y > bestY && pos.Y+size.Height < cruiseY {
+				best, bestY = pos.X+size.Width/2, pos.Y
+			}
+		})
+	}
+	if !math.IsInf(bestY, -1) {
+		return best
+	}
+	if boss, ok := tags.Boss.First(world); ok {
+		pos := components.Position.Get(boss)
+		return pos.X + components.Size.Get(boss).Width/2
+	}
+	return best
+}
+
+// decide 在 9 个移动方向中选出预测危险最小、最接近目标的一个
+func (p *Pilot) decide(pos components.PositionData, size components.SizeData, speed float64, threats []threat, targetX float64) Controls {
+	// 本次决策注意到的威胁，漏看的概率由闪避准确度决定
+	seen := threats[:0:0]
+	for _, t := range threats {
+		if p.skill.DodgeAccuracy >= 1 || p.rand.Float64() < p.skill.DodgeAccuracy {
+			seen = append(seen, t)
+		}
+	}
+
+	best, bestScore := Controls{Fire: true}, math.Inf(-1)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			score := -dangerWeight * p.danger(pos, size, speed, float64(dx), float64(dy), seen)
+
+			// 一个 tick 后的位置与目标的距离（到达目标附近后不再抖动）
+			x := ecs.ClampFloat64(pos.X+float64(dx)*speed, 0, ecs.ScreenWidth-size.Width)
+			y := ecs.ClampFloat64(pos.Y+float64(dy)*speed, 0, ecs.ScreenHeight-size.Height)
+			offX := math.Max(math.Abs(x+size.Width/2-targetX)-speed, 0)
+			offY := math.Max(math.Abs(y-cruiseY)-speed, 0)
+			score -= offX + offY/2
+
+			if score > bestScore {
+				bestScore = score
+				best = Controls{Up: dy < 0, Down: dy > 0, Left: dx < 0, Right: dx > 0, Fire: true}
+			}
+		}
+	}
+	return best
+}
+
+// danger 沿给定方向持续移动时预测到的碰撞，越早发生的碰撞分值越高
+func (p *Pilot) danger(pos components.PositionData, size components.SizeData, speed, dx, dy float64, threats []threat) float64 {
+	horizon := p.skill.Horizon
+	total := 0.0
+	for _, t := range threats {
+		for tick := 1; tick <= horizon; tick++ {
+			x := ecs.ClampFloat64(pos.X+dx*speed*float64(tick), 0, ecs.ScreenWidth-size.Width)
+			y := ecs.ClampFloat64(pos.Y+dy*speed*float64(tick), 0, ecs.ScreenHeight-size.Height)
+			tx, ty := t.at(tick)
+			if x-dodgeMargin < tx+t.w && x+size.Width+dodgeMargin > tx &&
+				y-dodgeMargin < ty+t.h && y+size.Height+dodgeMargin > ty {
+				total += float64(horizon - tick + 1)
+				break
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"time"
+
+	"spacebattle/internal/ecs"
+)
+
+// Skill 机器人的技术水平
+type Skill struct {
+	Name          string
+	ReactionDelay time.Duration // 从看到局面到做出操作的延迟
+	DodgeAccuracy float64       // 每次决策注意到单个威胁的概率（0~1）
+	Horizon       int           // 预测的 tick 数
+}
+
+// 预设技术水平
+var (
+	Easy    = Skill{Name: "easy", ReactionDelay: 300 * time.Millisecond, DodgeAccuracy: 0.5, Horizon: 12}
+	Medium  = Skill{Name: "medium", ReactionDelay: 170 * time.Millisecond, DodgeAccuracy: 0.75, Horizon: 20}
+	Hard    = Skill{Name: "hard", ReactionDelay: 70 * time.Millisecond, DodgeAccuracy: 0.95, Horizon: 30}
+	Perfect = Skill{Name: "perfect", ReactionDelay: 0, DodgeAccuracy: 1, Horizon: 36}
+)
+
+// Skills 全部预设，按由弱到强排列
+var Skills = []Skill{Easy, Medium, Hard, Perfect}
+
+// SkillByName 按名称查找预设
+func SkillByName(name string) (Skill, bool) {
+	for _, s := range Skills {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Skill{}, false
+}
+
+// reactionTicks 反应延迟折算为 tick 数
+func (s Skill) reactionTicks() int {
+	return int(s.ReactionDelay / ecs.TickDuration)
+}
@@ -0,0 +1,35 @@
+package campaign
+
+// 评级从高到低
+var grades = []string{"S", "A", "B", "C", "D"}
+
+// 评级所需的最低表现分数（与 grades 一一对应）
+var gradeThresholds = []float64{90, 75, 60, 40, 0}
+
+// Grade 根据表现分数 (0-100) 计算评级
+func Grade(performance float64) string {
+	for i, t := range gradeThresholds {
+		if performance >= t {
+			return grades[i]
+		}
+	}
+	return grades[len(grades)-1]
+}
+
+// BetterGrade 返回两个评级中更高的一个（空字符串视为无评级）
+func BetterGrade(a, b string) string {
+	if gradeRank(b) < gradeRank(a) {
+		return b
+	}
+	return a
+}
+
+// gradeRank 评级序号，越小越好；未知评级排在最后
+func gradeRank(g string) int {
+	for i, v := range grades {
+		if v == g {
+			return i
+		}
+	}
+	return len(grades)
+}
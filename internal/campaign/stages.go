@@ -0,0 +1,131 @@
+package campaign
+
+import (
+	"image/color"
+	"time"
+)
+
+// 敌机类型（与生成系统一致）
+const (
+	EnemyBasic   = "basic"
+	EnemyZigzag  = "zigzag"
+	EnemyShooter = "shooter"
+	EnemyTank    = "tank"
+)
+
+// Wave 波次脚本
+type Wave struct {
+	MinInterval time.Duration      // 该波最小生成间隔
+	Weights     map[string]float64 // 敌机类型权重
+}
+
+// Boss 关卡 Boss 参数
+type Boss struct {
+	NameKey       string // 本地化名称键
+	HP            int
+	Width, Height float64
+	Speed         float64 // 水平移动速度
+	Color         color.RGBA
+}
+
+// Palette 关卡背景配色
+type Palette struct {
+	Background color.RGBA
+	Star       color.RGBA
+}
+
+// Stage 战役关卡定义
+type Stage struct {
+	ID            string
+	NameKey       string // 本地化名称键
+	WaveLength    time.Duration
+	Waves         []Wave
+	BossGrace     time.Duration // Boss 阶段时长（到时硬收束）
+	Boss          Boss
+	Palette       Palette
+	RequiresClear string // 需先通关的关卡 ID，为空表示默认解锁
+}
+
+// SmallPhaseDuration 小怪阶段时长
+func (s Stage) SmallPhaseDuration() time.Duration {
+	return s.WaveLength * time.Duration(len(s.Waves))
+}
+
+// TotalDuration 关卡总时长
+func (s Stage) TotalDuration() time.Duration {
+	return s.SmallPhaseDuration() + s.BossGrace
+}
+
+// Stages 战役关卡（按顺序）
+var Stages = []Stage{
+	{
+		ID:         "stage1",
+		NameKey:    "stage.stage1",
+		WaveLength: 9 * time.Second,
+		Waves: []Wave{
+			{MinInterval: 1400 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 80, EnemyZigzag: 20}},
+			{MinInterval: 1200 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 60, EnemyZigzag: 40}},
+			{MinInterval: 1000 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 50, EnemyZigzag: 30, EnemyShooter: 20}},
+			{MinInterval: 900 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 40, EnemyZigzag: 30, EnemyShooter: 30}},
+		},
+		BossGrace: 15 * time.Second,
+		Boss:      Boss{NameKey: "boss.sentinel", HP: 50, Width: 90, Height: 55, Speed: 1.2, Color: color.RGBA{R: 200, G: 50, B: 200, A: 255}},
+		Palette: Palette{
+			Background: color.RGBA{R: 10, G: 16, B: 36, A: 255},
+			Star:       color.RGBA{R: 200, G: 200, B: 200, A: 255},
+		},
+	},
+	{
+		ID:         "stage2",
+		NameKey:    "stage.stage2",
+		WaveLength: 9 * time.Second,
+		Waves: []Wave{
+			{MinInterval: 1100 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 50, EnemyZigzag: 50}},
+			{MinInterval: 1000 * time.Millisecond, Weights: map[string]float64{EnemyZigzag: 50, EnemyShooter: 50}},
+			{MinInterval: 900 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 30, EnemyShooter: 40, EnemyTank: 30}},
+			{MinInterval: 800 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 25, EnemyZigzag: 25, EnemyShooter: 25, EnemyTank: 25}},
+			{MinInterval: 700 * time.Millisecond, Weights: map[string]float64{EnemyZigzag: 40, EnemyShooter: 40, EnemyTank: 20}},
+		},
+		BossGrace:     15 * time.Second,
+		Boss:          Boss{NameKey: "boss.inferno", HP: 80, Width: 110, Height: 60, Speed: 1.5, Color: color.RGBA{R: 230, G: 120, B: 40, A: 255}},
+		RequiresClear: "stage1",
+		Palette: Palette{
+			Background: color.RGBA{R: 30, G: 12, B: 24, A: 255},
+			Star:       color.RGBA{R: 255, G: 190, B: 160, A: 255},
+		},
+	},
+	{
+		ID:         "stage3",
+		NameKey:    "stage.stage3",
+		WaveLength: 10 * time.Second,
+		Waves: []Wave{
+			{MinInterval: 900 * time.Millisecond, Weights: map[string]float64{EnemyShooter: 60, EnemyZigzag: 40}},
+			{MinInterval: 800 * time.Millisecond, Weights: map[string]float64{EnemyTank: 50, EnemyShooter: 50}},
+			{MinInterval: 700 * time.Millisecond, Weights: map[string]float64{EnemyBasic: 20, EnemyZigzag: 30, EnemyShooter: 30, EnemyTank: 20}},
+			{MinInterval: 600 * time.Millisecond, Weights: map[string]float64{EnemyZigzag: 30, EnemyShooter: 40, EnemyTank: 30}},
+			{MinInterval: 500 * time.Millisecond, Weights: map[string]float64{EnemyShooter: 50, EnemyTank: 50}},
+		},
+		BossGrace:     20 * time.Second,
+		Boss:          Boss{NameKey: "boss.leviathan", HP: 120, Width: 130, Height: 70, Speed: 1.8, Color: color.RGBA{R: 60, G: 220, B: 200, A: 255}},
+		RequiresClear: "stage2",
+		Palette: Palette{
+			Background: color.RGBA{R: 4, G: 28, B: 28, A: 255},
+			Star:       color.RGBA{R: 150, G: 255, B: 230, A: 255},
+		},
+	},
+}
+
+// ByID 按 ID 查找关卡
+func ByID(id string) (Stage, bool) {
+	for _, s := range Stages {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Stage{}, false
+}
+
+// IsUnlocked 判断关卡是否已解锁；cleared 返回某关卡是否已通关
+func IsUnlocked(stage Stage, cleared func(id string) bool) bool {
+	return stage.RequiresClear == "" || cleared(stage.RequiresClear)
+}
@@ -34,16 +34,25 @@ type Config struct {
 	BossSoftEnrageStart   time.Duration // 例如 10s 后提高伤害倍率
 	BossTripleDamageStart time.Duration // 例如 14s 后再次提高
 
-	// —— 升级消耗（功勋） ——
-	UpgradeCostFireRate       int
-	UpgradeCostBulletsPerShot int
-	UpgradeCostPenetration    int
-	UpgradeCostSpreadNarrow   int
-	UpgradeCostBulletSpeed    int
-	UpgradeCostBulletDamage   int
-	UpgradeCostBurstChance    int
-	UpgradeCostEnableHoming   int
-	UpgradeCostTurnRate       int
+	// —— 无尽模式 ——
+	EndlessDifficultyRamp float64       // 每分钟难度倍率增幅（相对出征难度的比例）
+	EndlessBossInterval   time.Duration // Boss 出现间隔（从上一只 Boss 被击败/出现算起）
+
+	// —— 自适应难度（辅助模式） ——
+	AssistInterval   time.Duration // 评估间隔
+	AssistStep       float64       // 每次评估的调整幅度
+	AssistMinLevel   float64       // 下限（负值表示玩家表现好时加难）
+	AssistMaxLevel   float64       // 上限（正值表示减难）
+	AssistSpawnK     float64       // 生成间隔倍率 = 1 + K*level
+	AssistEnemyHpK   float64       // 敌机生命倍率 = 1 - K*level
+	AssistBulletSpdK float64       // 敌机子弹速度倍率 = 1 - K*level
+
+	// —— Boss 连战 ——
+	BossRushBreak       time.Duration // 强化选择后到下一只 Boss 出现的间隔
+	BossRushTimePerBoss time.Duration // 每只 Boss 的参考用时（用于速度加成）
+
+	// —— 功勋奖励公式 ——
+	Reward RewardConfig
 
 	// —— 战机属性上限（用于 clamp） ——
 	MaxFireRateHz     float64
@@ -89,6 +98,7 @@ type Config struct {
 	// —— 颜色配置 ——
 	BackgroundColor   color.RGBA
 	PlayerColor       color.RGBA // 玩家
+	Player2Color      color.RGBA // 合作 2P
 	BulletColor       color.RGBA // 玩家子弹
 	EnemyColor        color.RGBA // 普通敌机
 	EnemyShooterColor color.RGBA // 射击型敌机
@@ -142,16 +152,43 @@ func DefaultConfig() *Config {
 		BossSoftEnrageStart:   10 * time.Second,
 		BossTripleDamageStart: 14 * time.Second,
 
-		// 升级消耗默认
-		UpgradeCostFireRate:       1,
-		UpgradeCostBulletsPerShot: 3,
-		UpgradeCostPenetration:    2,
-		UpgradeCostSpreadNarrow:   1,
-		UpgradeCostBulletSpeed:    1,
-		UpgradeCostBulletDamage:   2,
-		UpgradeCostBurstChance:    2,
-		UpgradeCostEnableHoming:   2147483647,
-		UpgradeCostTurnRate:       2,
+		EndlessDifficultyRamp: 0.5,
+		EndlessBossInterval:   60 * time.Second,
+
+		AssistInterval:   5 * time.Second,
+		AssistStep:       0.05,
+		AssistMinLevel:   -0.25,
+		AssistMaxLevel:   0.5,
+		AssistSpawnK:     1.0,
+		AssistEnemyHpK:   0.6,
+		AssistBulletSpdK: 0.5,
+
+		BossRushBreak:       2 * time.Second,
+		BossRushTimePerBoss: 30 * time.Second,
+
+		// 奖励公式默认
+		Reward: RewardConfig{
+			MinBase:           15,
+			BaseCostRatio:     1.5,
+			DifficultyBonusK:  0.5,
+			KillThreshold:     0.8,
+			KillRampFrom:      0.7,
+			KillRate:          0.5,
+			SpeedThreshold:    0.2,
+			SpeedRate:         0.3,
+			BossRate:          0.3,
+			PerfectKillRatio:  0.95,
+			PerfectSpeedRatio: 0.3,
+			PerfectRate:       0.5,
+			MinTotal:          10,
+			ConsolationRatio:  1.0 / 3,
+			ScoreKillWeight:   40,
+			ScoreSpeedWeight:  30,
+			ScoreBossWeight:   30,
+
+			AssistBaseDiscount: 0.1,
+			AssistEaseDiscount: 0.6,
+		},
 
 		// 战机属性上限默认
 		MaxFireRateHz:     30.0,
@@ -197,6 +234,7 @@ func DefaultConfig() *Config {
 		// 颜色配置默认
 		BackgroundColor:   color.RGBA{R: 10, G: 16, B: 36, A: 255},    // 深蓝色背景
 		PlayerColor:       color.RGBA{R: 100, G: 200, B: 255, A: 255}, // 浅蓝色玩家
+		Player2Color:      color.RGBA{R: 120, G: 255, B: 120, A: 255}, // 浅绿色 2P
 		BulletColor:       color.RGBA{R: 255, G: 255, B: 100, A: 255}, // 黄色子弹
 		EnemyColor:        color.RGBA{R: 255, G: 100, B: 100, A: 255}, // 浅红色（与背景对比明显）
 		EnemyShooterColor: color.RGBA{R: 255, G: 165, B: 0, A: 255},   // 橙色（与背景对比明显）
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// RewardConfig 标准模式功勋奖励公式的参数
+type RewardConfig struct {
+	MinBase           int     `json:"min_base"`            // 难度成本为 0 时的基础奖励
+	BaseCostRatio     float64 `json:"base_cost_ratio"`     // 基础奖励 = 难度成本 × 该比例
+	DifficultyBonusK  float64 `json:"difficulty_bonus_k"`  // 难度加成率 = K × log2(难度)
+	KillThreshold     float64 `json:"kill_threshold"`      // 击杀率达到该值才有击杀加成
+	KillRampFrom      float64 `json:"kill_ramp_from"`      // 击杀加成比例从该击杀率起线性增长，击杀率 100% 时为 1
+	KillRate          float64 `json:"kill_rate"`           // 击杀加成上限（占基础奖励比例）
+	SpeedThreshold    float64 `json:"speed_threshold"`     // 剩余时间比例超过该值开始有速度加成
+	SpeedRate         float64 `json:"speed_rate"`          // 速度加成上限（占基础奖励比例）
+	BossRate          float64 `json:"boss_rate"`           // 击杀 Boss 加成（占基础奖励比例）
+	PerfectKillRatio  float64 `json:"perfect_kill_ratio"`  // 完美通关所需击杀率
+	PerfectSpeedRatio float64 `json:"perfect_speed_ratio"` // 完美通关所需剩余时间比例
+	PerfectRate       float64 `json:"perfect_rate"`        // 完美通关加成（占其余各项之和的比例）
+	MinTotal          int     `json:"min_total"`           // 胜利时的最低总奖励
+	ConsolationRatio  float64 `json:"consolation_ratio"`   // 失败安慰奖（占基础奖励比例）
+	ScoreKillWeight   float64 `json:"score_kill_weight"`   // 表现分数中击杀率的权重
+	ScoreSpeedWeight  float64 `json:"score_speed_weight"`  // 表现分数中速度的权重
+	ScoreBossWeight   float64 `json:"score_boss_weight"`   // 表现分数中击杀 Boss 的权重
+
+	AssistBaseDiscount float64 `json:"assist_base_discount"` // 开启辅助的基础功勋折扣
+	AssistEaseDiscount float64 `json:"assist_ease_discount"` // 平均减难达到上限时追加的折扣
+}
+
+// Validate 检查参数范围，返回全部问题
+func (r RewardConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("reward.%s: "+format, append([]any{field}, args...)...))
+		}
+	}
+	unit := func(v float64) bool { return v >= 0 && v <= 1 }
+
+	check(r.MinBase >= 0, "min_base", "must be >= 0, got %d", r.MinBase)
+	check(r.BaseCostRatio > 0, "base_cost_ratio", "must be > 0, got %g", r.BaseCostRatio)
+	check(r.DifficultyBonusK >= 0, "difficulty_bonus_k", "must be >= 0, got %g", r.DifficultyBonusK)
+	check(r.KillRampFrom >= 0 && r.KillRampFrom < 1, "kill_ramp_from", "must be in [0, 1), got %g", r.KillRampFrom)
+	check(unit(r.KillThreshold) && r.KillThreshold >= r.KillRampFrom, "kill_threshold", "must be in [kill_ramp_from, 1], got %g", r.KillThreshold)
+	check(r.KillRate >= 0, "kill_rate", "must be >= 0, got %g", r.KillRate)
+	check(r.SpeedThreshold >= 0 && r.SpeedThreshold < 1, "speed_threshold", "must be in [0, 1), got %g", r.SpeedThreshold)
+	check(r.SpeedRate >= 0, "speed_rate", "must be >= 0, got %g", r.SpeedRate)
+	check(r.BossRate >= 0, "boss_rate", "must be >= 0, got %g", r.BossRate)
+	check(unit(r.PerfectKillRatio), "perfect_kill_ratio", "must be in [0, 1], got %g", r.PerfectKillRatio)
+	check(unit(r.PerfectSpeedRatio), "perfect_speed_ratio", "must be in [0, 1], got %g", r.PerfectSpeedRatio)
+	check(r.PerfectRate >= 0, "perfect_rate", "must be >= 0, got %g", r.PerfectRate)
+	check(r.MinTotal >= 0, "min_total", "must be >= 0, got %d", r.MinTotal)
+	check(unit(r.ConsolationRatio), "consolation_ratio", "must be in [0, 1], got %g", r.ConsolationRatio)
+	check(r.ScoreKillWeight >= 0 && r.ScoreSpeedWeight >= 0 && r.ScoreBossWeight >= 0,
+		"score_*_weight", "must be >= 0")
+	sum := r.ScoreKillWeight + r.ScoreSpeedWeight + r.ScoreBossWeight
+	check(math.Abs(sum-100) < 1e-9, "score_*_weight", "must sum to 100, got %g", sum)
+	check(unit(r.AssistBaseDiscount), "assist_base_discount", "must be in [0, 1], got %g", r.AssistBaseDiscount)
+	check(unit(r.AssistEaseDiscount), "assist_ease_discount", "must be in [0, 1], got %g", r.AssistEaseDiscount)
+
+	return errors.Join(errs...)
+}
+
+// LoadRewardConfig 读取 JSON 格式的奖励公式，未出现的字段沿用默认配置，读取后校验
+func LoadRewardConfig(path string) (RewardConfig, error) {
+	rc := DefaultConfig().Reward
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rc, err
+	}
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return rc, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := rc.Validate(); err != nil {
+		return rc, fmt.Errorf("%s: %w", path, err)
+	}
+	return rc, nil
+}
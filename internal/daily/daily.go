@@ -0,0 +1,65 @@
+package daily
+
+import (
+	"hash/fnv"
+	"time"
+
+	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
+)
+
+// Loadout 每日挑战的固定配置（忽略玩家的加点与战机解锁）
+type Loadout struct {
+	Ship       ships.Ship
+	Upgrades   progress.UpgradeData
+	Difficulty float64
+}
+
+// loadouts 按日期轮换的固定配置
+var loadouts = []Loadout{
+	{
+		Ship:       ship("alpha"),
+		Upgrades:   progress.UpgradeData{"fire_rate": 4, "bullets_per_shot": 1, "bullet_damage": 1},
+		Difficulty: 2.0,
+	},
+	{
+		Ship:       ship("beta"),
+		Upgrades:   progress.UpgradeData{"fire_rate": 6, "spread_narrow": 1, "bullet_speed": 4},
+		Difficulty: 2.5,
+	},
+	{
+		Ship:       ship("gamma"),
+		Upgrades:   progress.UpgradeData{"bullets_per_shot": 2, "penetration": 1, "burst_chance": 4},
+		Difficulty: 2.0,
+	},
+	{
+		Ship:       ship("delta"),
+		Upgrades:   progress.UpgradeData{"fire_rate": 2, "enable_homing": 1, "turn_rate": 1},
+		Difficulty: 3.0,
+	},
+}
+
+// ship 按 ID 取战机定义（每日挑战忽略解锁状态）
+func ship(id string) ships.Ship {
+	if s, ok := ships.ByID(id); ok {
+		return s
+	}
+	return ships.Default()
+}
+
+// DateKey 返回本地日期键（YYYY-MM-DD），同时作为排行榜分组
+func DateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// Seed 由日期派生随机种子，离线也能得到相同的关卡
+func Seed(dateKey string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("spacebattle-daily:" + dateKey))
+	return int64(h.Sum64() &^ (1 << 63))
+}
+
+// LoadoutFor 返回指定日期的固定配置
+func LoadoutFor(dateKey string) Loadout {
+	return loadouts[Seed(dateKey)%int64(len(loadouts))]
+}
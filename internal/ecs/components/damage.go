@@ -4,7 +4,8 @@ import "github.com/yohamta/donburi"
 
 // DamageData 伤害数据
 type DamageData struct {
-	Value int // 伤害值
+	Value     int // 伤害值
+	OwnerSlot int // 发射者的玩家编号（用于击杀归属）
 }
 
 // Damage 伤害组件
@@ -16,7 +16,7 @@ type FireSkillData struct {
 	BurstChance       float64       // 概率连续发射（0-1）
 	PenetrationCount  int           // 可穿透敌人数
 	EnableHoming      bool          // 是否追踪
-	HomingTurnRateRad float64       // 每帧最大转向弧度
+	HomingTurnRateRad float64       // 每 tick 最大转向弧度
 	BurstInterval     time.Duration // 连射间隔
 	LastShot          time.Time     // 上次射击时间
 	ShotDelay         time.Duration // 射击冷却
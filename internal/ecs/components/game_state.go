@@ -1,13 +1,109 @@
 package components
 
 import (
+	"image/color"
 	"time"
 
 	"github.com/yohamta/donburi"
 )
 
+// GameMode 游戏模式
+type GameMode string
+
+const (
+	ModeStandard GameMode = "standard" // 标准：限时小怪阶段 + Boss，击败 Boss 或时间到即胜利
+	ModeEndless  GameMode = "endless"  // 无尽：波次循环、难度递增、周期 Boss，阵亡才结束
+	ModeCampaign GameMode = "campaign" // 战役：按关卡脚本生成波次与 Boss，通关解锁下一关
+	ModeDaily    GameMode = "daily"    // 每日挑战：日期种子、固定配置与难度，每天一次计分机会
+	ModeBossRush GameMode = "bossrush" // Boss 连战：跳过小怪阶段，依次挑战所有 Boss
+	ModePractice GameMode = "practice" // 练习：任选起始波次或 Boss，可无限生命与慢动作，不发功勋
+)
+
+// HasTimeLimit 该模式是否有总时长硬收束
+func (m GameMode) HasTimeLimit() bool {
+	return m != ModeEndless && m != ModeBossRush && m != ModePractice
+}
+
+// BossSpec Boss 参数
+type BossSpec struct {
+	NameKey string
+	HP      int
+	Width   float64
+	Height  float64
+	Speed   float64
+	Color   color.RGBA
+}
+
+// BossRecord 单只 Boss 的出现与击败记录
+type BossRecord struct {
+	NameKey   string
+	SpawnTime time.Time
+	KillTime  time.Time
+	Killed    bool
+	// 出现时已累计的伤害，用于判定无伤击败
+	DamageAtSpawn int
+}
+
+// Split 击败该 Boss 的用时（未击败时为 0）
+func (r BossRecord) Split() time.Duration {
+	if !r.Killed {
+		return 0
+	}
+	return r.KillTime.Sub(r.SpawnTime)
+}
+
+// AssistSample 自适应难度的一次调整记录
+type AssistSample struct {
+	At    time.Duration // 距开局的时间
+	Level float64
+}
+
+// AssistData 自适应难度（辅助模式）状态；Level > 0 表示减难，< 0 表示加难
+type AssistData struct {
+	Enabled          bool
+	Level            float64
+	SpawnIntervalMul float64 // 生成间隔倍率
+	EnemyHPMul       float64 // 敌机生命倍率
+	EnemyBulletMul   float64 // 敌机子弹速度倍率
+	LastEval         time.Time
+	LastDamage       int // 上次评估时的累计受伤
+	LastKilled       int // 上次评估时的累计击杀
+	LastSpawned      int // 上次评估时的累计生成
+	History          []AssistSample
+}
+
+// AverageEase 按时间加权的平均减难程度（只计正值）
+func (a AssistData) AverageEase(total time.Duration) float64 {
+	if len(a.History) == 0 || total <= 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, sample := range a.History {
+		end := total
+		if i+1 < len(a.History) {
+			end = a.History[i+1].At
+		}
+		if span := end - sample.At; span > 0 && sample.Level > 0 {
+			sum += sample.Level * span.Seconds()
+		}
+	}
+	return sum / total.Seconds()
+}
+
+// StageData 关卡参数（战役模式由关卡脚本填充，其余模式为空）
+type StageData struct {
+	ID           string
+	NameKey      string
+	EnemyWeights []map[string]float64 // 每波敌机类型权重
+	Boss         BossSpec
+	Background   color.RGBA
+	Grade        string // 通关评级（结算后填充）
+	NewBest      bool   // 是否刷新该关卡最佳评级或分数
+}
+
 // GameStateData 游戏状态数据（全局单例）
 type GameStateData struct {
+	Mode             GameMode
 	Score            int
 	Lives            int
 	GameOver         bool
@@ -15,11 +111,38 @@ type GameStateData struct {
 	Settled          bool
 	KilledEnemyCount int
 	SpawnedCount     int
-	BossSpawned      bool
-	BossKilled       bool
+	Bosses           []BossRecord // 按出现顺序记录的 Boss
 	RewardCached     int
+	RewardPending    bool // 结算奖励尚未写入存档（写入失败时保持，按确认键重试）
+	RewardFailed     bool // 最近一次写入结算奖励失败
 	DifficultyMul    float64
 	StartTime        time.Time
+	Clock            time.Time // 模拟时钟：每个 tick 前进一个固定步长，对局内的计时均以此为准
+	// 无尽模式
+	BaseDifficulty float64       // 出征时选择的难度（DifficultyMul 在此基础上递增）
+	WaveReached    int           // 累计到达的波次（从 1 开始，含循环）
+	BossInterval   time.Duration // Boss 出现间隔
+	NextBossTime   time.Time     // 下一只 Boss 的出现时间
+	SurvivalTime   time.Duration // 结算时的存活时长
+	BestScore      int           // 本模式历史最高分（结算时填充）
+	NewRecord      bool          // 是否刷新最高分
+	// 战役关卡
+	Stage *StageData
+	// Boss 连战
+	BossQueue     []BossSpec // 待挑战的 Boss（按顺序）
+	RushPicking   bool       // 是否处于两场之间的强化选择
+	RushChoices   []string   // 可选强化
+	RushSelection int        // 当前选中的强化
+	// 自适应难度
+	DamageTaken int // 玩家累计受到的伤害
+	Assist      AssistData
+	// 练习模式
+	InfiniteLives bool
+	SlowMotion    bool
+	// 每日挑战
+	DailyDate     string
+	DailyPractice bool
+	DailyRank     int // 当日排名（结算后填充，练习为 0）
 	// 波次配置
 	TotalDuration      time.Duration
 	SmallPhaseDuration time.Duration
@@ -31,6 +154,12 @@ type GameStateData struct {
 	MaxSimultaneous int
 	BatchSize       int
 	LastEnemyTime   time.Time
+	// 本地合作
+	PlayerCount      int   // 玩家数量（1 或 2）
+	SharedLives      bool  // 合作时是否共用生命池
+	ShowContribution bool  // 合作结算时是否显示贡献份额（功勋仍全部计入当前档案）
+	PlayerKills      []int // 每名玩家的击杀数（按玩家编号）
+	PlayerShares     []int // 按击杀贡献划分的功勋份额，仅用于结算显示（结算后填充）
 	// GM 调试
 	GMOpen  bool
 	GMIndex int
@@ -39,6 +168,72 @@ type GameStateData struct {
 	RewardBreakdown RewardBreakdownData
 }
 
+// BossSpawned 是否已有 Boss 出现
+func (g *GameStateData) BossSpawned() bool {
+	return len(g.Bosses) > 0
+}
+
+// BossKilled 是否已击败至少一只 Boss
+func (g *GameStateData) BossKilled() bool {
+	return g.BossKillCount() > 0
+}
+
+// BossKillCount 击败 Boss 的数量
+func (g *GameStateData) BossKillCount() int {
+	n := 0
+	for _, r := range g.Bosses {
+		if r.Killed {
+			n++
+		}
+	}
+	return n
+}
+
+// CurrentBoss 返回当前存活的 Boss 记录，没有时返回 nil
+func (g *GameStateData) CurrentBoss() *BossRecord {
+	if len(g.Bosses) == 0 || g.Bosses[len(g.Bosses)-1].Killed {
+		return nil
+	}
+	return &g.Bosses[len(g.Bosses)-1]
+}
+
+// StartBoss 记录一只 Boss 出现
+func (g *GameStateData) StartBoss(nameKey string, now time.Time) {
+	g.Bosses = append(g.Bosses, BossRecord{NameKey: nameKey, SpawnTime: now, DamageAtSpawn: g.DamageTaken})
+}
+
+// KillCurrentBoss 记录当前 Boss 被击败
+func (g *GameStateData) KillCurrentBoss(now time.Time) {
+	if boss := g.CurrentBoss(); boss != nil {
+		boss.Killed = true
+		boss.KillTime = now
+	}
+}
+
+// BossNoHit 当前 Boss 出现后玩家是否未受伤
+func (g *GameStateData) BossNoHit() bool {
+	boss := g.CurrentBoss()
+	return boss != nil && g.DamageTaken == boss.DamageAtSpawn
+}
+
+// Elapsed 按模拟时钟计的对局时长
+func (g *GameStateData) Elapsed() time.Duration {
+	return g.Clock.Sub(g.StartTime)
+}
+
+// Advance 模拟时钟前进 d
+func (g *GameStateData) Advance(d time.Duration) {
+	g.Clock = g.Clock.Add(d)
+}
+
+// Now 当前模拟时间；世界中还没有游戏状态时返回零值
+func Now(w donburi.World) time.Time {
+	if entry, ok := GameState.First(w); ok {
+		return GameState.Get(entry).Clock
+	}
+	return time.Time{}
+}
+
 // RewardBreakdownData 功勋奖励分解信息
 type RewardBreakdownData struct {
 	BaseReward       int
@@ -47,10 +242,10 @@ type RewardBreakdownData struct {
 	SpeedBonus       int
 	PerfectBonus     int
 	BossBonus        int
+	AssistDiscount   int // 辅助模式折扣（从总奖励中扣除）
 	TotalReward      int
 	PerformanceScore float64
 }
 
 // GameState 游戏状态组件
 var GameState = donburi.NewComponentType[GameStateData]()
-
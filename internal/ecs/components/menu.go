@@ -1,6 +1,7 @@
 package components
 
 import (
+	"image"
 	"image/color"
 
 	"github.com/yohamta/donburi"
@@ -38,17 +39,27 @@ type MenuStateData struct {
 	Upgrades        map[string]int
 	// 出征相关
 	DifficultyMul float64
+	// 指针/触摸点击区域（由 MenuSystem 绘制时记录）
+	ItemRects   []image.Rectangle // 按选项索引排列
+	PrevRect    image.Rectangle   // 向前切换（战机选择等左右导航）
+	NextRect    image.Rectangle   // 向后切换
+	ConfirmRect image.Rectangle   // 确认
 }
 
 // ShipTemplate 战机模板
 type ShipTemplate struct {
-	NameKey    string
-	Speed      float64
-	SizeScale  float64
-	Lives      int
-	PassiveKey string
+	ID          string
+	NameKey     string
+	Speed       float64
+	SizeScale   float64
+	Lives       int
+	PassiveKey  string
+	AbilityType string
+	// 解锁状态
+	Locked            bool
+	UnlockMerits      int    // 购买所需功勋
+	UnlockAchievement string // 所需成就 ID
 }
 
 // MenuState 菜单状态组件
 var MenuState = donburi.NewComponentType[MenuStateData]()
-
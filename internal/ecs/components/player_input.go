@@ -4,9 +4,15 @@ import "github.com/yohamta/donburi"
 
 // PlayerInputData 玩家输入数据
 type PlayerInputData struct {
-	Speed float64 // 移动速度
+	Speed  float64 // 移动速度（像素/tick）
+	Slot   int     // 玩家编号（0 为 1P，1 为 2P），决定按键绑定
+	Fire   bool    // 本帧是否射击（由输入系统写入）
+	SpawnX float64 // 出生/受击后重置的位置
+	SpawnY float64
+
+	// 本帧的移动意图（键盘或机器人写入；指针模式直接写速度，不使用意图）
+	Up, Down, Left, Right bool
 }
 
 // PlayerInput 玩家输入组件
 var PlayerInput = donburi.NewComponentType[PlayerInputData]()
-
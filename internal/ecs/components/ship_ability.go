@@ -31,6 +31,7 @@ type ShipAbilityData struct {
 	ShieldCurrent  int
 	ShieldMax      int
 	LastDamageTime time.Time
+	ShieldRegenAcc float64 // 回复累计（秒），满 1 秒回复 1 点
 }
 
 // ShipAbility 战机被动技能组件
@@ -4,7 +4,7 @@ import "github.com/yohamta/donburi"
 
 // StarData 星星背景数据
 type StarData struct {
-	Speed float64 // 滚动速度
+	Speed float64 // 滚动速度（像素/秒）
 	Size  float64 // 大小
 }
 
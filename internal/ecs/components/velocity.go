@@ -2,7 +2,7 @@ package components
 
 import "github.com/yohamta/donburi"
 
-// VelocityData 速度组件数据
+// VelocityData 速度组件数据（像素/秒）
 type VelocityData struct {
 	VX, VY float64
 }
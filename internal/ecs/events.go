@@ -0,0 +1,95 @@
+package ecs
+
+import (
+	"reflect"
+
+	"github.com/yohamta/donburi"
+)
+
+// EventBus 类型化事件总线：系统发布事件入队，在每帧固定时机统一派发给订阅者，
+// 发布方无需知道谁在响应（被动技能、粒子、震屏、音效、成就等）
+type EventBus struct {
+	handlers map[reflect.Type][]func(any)
+	queue    []any
+}
+
+// NewEventBus 创建事件总线
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[reflect.Type][]func(any))}
+}
+
+// Subscribe 订阅类型为 T 的事件，按订阅顺序调用
+func Subscribe[T any](b *EventBus, fn func(T)) {
+	t := reflect.TypeFor[T]()
+	b.handlers[t] = append(b.handlers[t], func(e any) { fn(e.(T)) })
+}
+
+// Publish 发布事件，等到 Dispatch 时才交给订阅者
+func Publish[T any](b *EventBus, e T) {
+	b.queue = append(b.queue, e)
+}
+
+// Dispatch 按发布顺序派发所有排队事件；派发过程中新发布的事件在本次一并派发
+func (b *EventBus) Dispatch() {
+	for len(b.queue) > 0 {
+		queue := b.queue
+		b.queue = nil
+		for _, e := range queue {
+			for _, fn := range b.handlers[reflect.TypeOf(e)] {
+				fn(e)
+			}
+		}
+	}
+}
+
+// Pending 尚未派发的事件数
+func (b *EventBus) Pending() int {
+	return len(b.queue)
+}
+
+// EnemyHit 子弹命中敌机或 Boss（未必击毁）
+type EnemyHit struct {
+	X, Y float64
+	Boss bool
+}
+
+// EnemyKilled 小怪被击毁
+type EnemyKilled struct {
+	X, Y      float64 // 敌机中心
+	OwnerSlot int     // 击杀者玩家编号
+}
+
+// PlayerDamaged 玩家受到伤害（护盾或无敌完全抵消时不发布）
+type PlayerDamaged struct {
+	Player *donburi.Entry
+	X, Y   float64 // 玩家中心
+	Damage int
+}
+
+// BulletFired 玩家射击（一次齐射发布一次）
+type BulletFired struct {
+	OwnerSlot int
+	Bullets   int
+}
+
+// BossPhase Boss 阶段
+type BossPhase int
+
+const (
+	BossPhaseSpawned  BossPhase = iota // 出现
+	BossPhaseDefeated                  // 被击败
+)
+
+// BossPhaseChanged Boss 阶段变化
+type BossPhaseChanged struct {
+	NameKey string
+	Phase   BossPhase
+	X, Y    float64 // Boss 中心
+	NoHit   bool    // 击败时：本场 Boss 战玩家未受伤
+}
+
+// PickupCollected 玩家拾取道具（道具玩法接入前暂无发布方）
+type PickupCollected struct {
+	Player *donburi.Entry
+	Kind   string
+}
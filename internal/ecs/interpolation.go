@@ -0,0 +1,47 @@
+package ecs
+
+import (
+	"spacebattle/internal/ecs/components"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// teleportDistance 单 tick 位移超过该距离视为瞬移（重生、星星循环），不做插值
+const teleportDistance = 64
+
+// PositionHistory 记录上一 tick 的实体位置，渲染时在两次 tick 之间插值
+type PositionHistory struct {
+	prev map[donburi.Entity]components.PositionData
+}
+
+// NewPositionHistory 创建位置历史
+func NewPositionHistory() *PositionHistory {
+	return &PositionHistory{prev: make(map[donburi.Entity]components.PositionData)}
+}
+
+// Capture 在 tick 开始前记录所有实体的位置
+func (h *PositionHistory) Capture(w donburi.World) {
+	clear(h.prev)
+	query.NewQuery(filter.Contains(components.Position)).Each(w, func(entry *donburi.Entry) {
+		h.prev[entry.Entity()] = *components.Position.Get(entry)
+	})
+}
+
+// Lerp 返回实体在 alpha 处的插值位置；新生成或瞬移的实体直接使用当前位置
+func (h *PositionHistory) Lerp(entry *donburi.Entry, alpha float64) (float64, float64) {
+	cur := components.Position.Get(entry)
+	if h == nil {
+		return cur.X, cur.Y
+	}
+	prev, ok := h.prev[entry.Entity()]
+	if !ok {
+		return cur.X, cur.Y
+	}
+	dx, dy := cur.X-prev.X, cur.Y-prev.Y
+	if dx*dx+dy*dy > teleportDistance*teleportDistance {
+		return cur.X, cur.Y
+	}
+	return prev.X + dx*alpha, prev.Y + dy*alpha
+}
@@ -0,0 +1,35 @@
+package ecs
+
+import "spacebattle/internal/ecs/components"
+
+// 屏幕尺寸（战斗区域）
+const (
+	ScreenWidth  = 800
+	ScreenHeight = 600
+)
+
+// SteerPlayer 按移动意图设置玩家速度（input.Speed 为每 tick 位移，换算为每秒）。
+// 键盘与机器人都只写意图，经同一转换得到速度
+func SteerPlayer(vel *components.VelocityData, input *components.PlayerInputData) {
+	speed := PerSecond(input.Speed)
+	vel.VX = 0
+	vel.VY = 0
+	if input.Up {
+		vel.VY = -speed
+	}
+	if input.Down {
+		vel.VY = speed
+	}
+	if input.Left {
+		vel.VX = -speed
+	}
+	if input.Right {
+		vel.VX = speed
+	}
+}
+
+// ClampToScreen 将实体限制在屏幕范围内
+func ClampToScreen(pos *components.PositionData, size *components.SizeData) {
+	pos.X = ClampFloat64(pos.X, 0, ScreenWidth-size.Width)
+	pos.Y = ClampFloat64(pos.Y, 0, ScreenHeight-size.Height)
+}
@@ -0,0 +1,96 @@
+package scenes
+
+import (
+	"spacebattle/internal/achievements"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// AchievementsScene 成就浏览场景：列出全部成就的达成时间或累计进度
+type AchievementsScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	rows        []systems.AchievementRow
+	done        bool
+}
+
+// NewAchievementsScene 创建成就浏览场景
+func NewAchievementsScene() *AchievementsScene {
+	world := ecs.NewWorld()
+
+	scene := &AchievementsScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+	}
+	scene.loadRows()
+
+	// 最后一项为返回
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: 0,
+		OptionCount:   len(scene.rows) + 1,
+	})
+
+	return scene
+}
+
+// loadRows 读取达成记录与累计进度
+func (s *AchievementsScene) loadRows() {
+	unlocks := achievements.Unlocks()
+	for _, a := range achievements.All {
+		at, ok := unlocks[a.ID]
+		s.rows = append(s.rows, systems.AchievementRow{
+			NameKey:    a.NameKey,
+			DescKey:    a.DescKey,
+			Unlocked:   ok,
+			UnlockedAt: at,
+			Progress:   achievements.Progress(a),
+			Target:     a.Condition.Target(),
+		})
+	}
+}
+
+// Update 更新成就浏览场景
+func (s *AchievementsScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil || !menuState.Confirmed {
+		return nil
+	}
+	menuState.Confirmed = false
+	if menuState.SelectedIndex == len(s.rows) {
+		s.done = true
+	}
+	return nil
+}
+
+// Draw 绘制成就浏览场景
+func (s *AchievementsScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+	s.menuSystem.DrawAchievements(screen, menuState, s.rows)
+}
+
+// getMenuState 获取菜单状态
+func (s *AchievementsScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsDone 是否返回统计界面
+func (s *AchievementsScene) IsDone() bool {
+	return s.done
+}
@@ -1,14 +1,25 @@
 package scenes
 
 import (
+	"encoding/json"
+	"errors"
+	"maps"
 	"time"
 
+	"spacebattle/internal/achievements"
 	"spacebattle/internal/balance"
+	"spacebattle/internal/campaign"
+	"spacebattle/internal/config"
+	"spacebattle/internal/daily"
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/simulation"
 	"spacebattle/internal/ecs/systems"
 	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
 	"spacebattle/internal/sound"
+	"spacebattle/internal/talent"
+	"spacebattle/internal/upgrades"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/yohamta/donburi"
@@ -16,20 +27,20 @@ import (
 
 // BattleScene 战斗场景
 type BattleScene struct {
-	world             *ecs.World
-	inputSystem       *systems.InputSystem
-	shipAbilitySystem *systems.ShipAbilitySystem
-	enemyAISystem     *systems.EnemyAISystem
-	movementSystem    *systems.MovementSystem
-	fireSystem        *systems.FireSystem
-	homingSystem      *systems.HomingSystem
-	collisionSystem   *systems.CollisionSystem
-	spawnSystem       *systems.SpawnSystem
-	lifetimeSystem    *systems.LifetimeSystem
-	particleSystem    *systems.ParticleSystem
-	shakeSystem       *systems.ScreenShakeSystem
-	renderSystem      *systems.RenderSystem
-	initialOptions    PlayerOptions
+	world          *ecs.World
+	inputSystem    *systems.InputSystem
+	pipeline       *simulation.BattlePipeline
+	bossRushSystem *systems.BossRushSystem
+	audioSystem    *systems.AudioSystem
+	renderSystem   *systems.RenderSystem
+	initialOptions PlayerOptions
+	step           ecs.FixedStep        // 固定步长累加器
+	lastUpdate     time.Time            // 上次 Update 的时间，用于计算经过的真实时长
+	history        *ecs.PositionHistory // 上一 tick 的实体位置（渲染插值）
+	rewind         []*ecs.Snapshot      // 练习模式每秒一张快照，用于回溯
+	rewindTicks    int                  // 距上次回溯快照的 tick 数
+	achievements   bool                 // 本局是否计入成就（练习不计）
+	showStats      bool                 // 显示各系统耗时
 }
 
 // PlayerOptions 玩家配置选项
@@ -39,51 +50,101 @@ type PlayerOptions struct {
 	Lives                int
 	DifficultyMultiplier float64
 	PassiveKey           string
-	// 升级加成
-	ModFireRateHz     float64
-	ModBulletsPerShot int
-	ModPenetration    int
-	ModSpreadDeltaDeg float64
-	ModBulletSpeed    float64
-	ModBulletDamage   int
-	ModBurstChance    float64
-	ModEnableHoming   bool
-	ModTurnRateRad    float64
+	ShipID               string // 战机 ID（决定加点存档）
+	AbilityType          string // 战机被动技能
+	Mode                 components.GameMode
+	StageID              string          // 战役关卡 ID（仅战役模式）
+	DailyDate            string          // 每日挑战日期（仅每日挑战）
+	DailyPractice        bool            // 每日挑战练习（不计分、不发功勋）
+	PracticeStart        int             // 练习起始波次索引，等于波次数时直接进入 Boss
+	InfiniteLives        bool            // 练习无限生命
+	UpgradeLevels        upgrades.Levels // 升级等级（升级标识 -> 等级）
+}
+
+// ApplyUpgrades 用持久化的加点覆盖升级等级
+func (opts *PlayerOptions) ApplyUpgrades(u progress.UpgradeData) {
+	opts.UpgradeLevels = upgrades.Levels(maps.Clone(u))
+}
+
+// Upgrades 返回当前升级等级，用于持久化与出击记录
+func (opts PlayerOptions) Upgrades() progress.UpgradeData {
+	return progress.UpgradeData(maps.Clone(opts.UpgradeLevels))
+}
+
+// OptionsForShip 按战机定义生成基础玩家选项
+func OptionsForShip(ship ships.Ship) PlayerOptions {
+	return PlayerOptions{
+		Speed:       ship.Speed,
+		SizeScale:   ship.SizeScale,
+		Lives:       ship.Lives,
+		PassiveKey:  ship.PassiveKey,
+		ShipID:      ship.ID,
+		AbilityType: ship.AbilityType,
+	}
 }
 
 // NewBattleScene 创建战斗场景
 func NewBattleScene(opts PlayerOptions) *BattleScene {
+	scene := newBattleScene(opts)
+
+	// 初始化场景
+	scene.initialize(opts)
+
+	return scene
+}
+
+// ResumeBattleScene 从挂起的出击恢复战斗场景
+func ResumeBattleScene(run progress.SuspendedRun) (*BattleScene, error) {
+	var opts PlayerOptions
+	if err := json.Unmarshal(run.Options, &opts); err != nil {
+		return nil, err
+	}
+	snap, err := ecs.DecodeSnapshot(run.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	scene := newBattleScene(opts)
+	if err := scene.world.Restore(snap); err != nil {
+		return nil, err
+	}
+	gameState := scene.getGameState()
+	if gameState == nil {
+		return nil, errors.New("suspended run has no game state")
+	}
+	scene.beginAchievements(gameState, opts)
+	return scene, nil
+}
+
+// newBattleScene 创建战斗场景及其系统（不含实体）
+func newBattleScene(opts PlayerOptions) *BattleScene {
 	// 初始化音频
 	sound.Init()
 
 	// 创建 ECS World
 	world := ecs.NewWorld()
 
-	// 创建系统（按执行顺序）
-	shipAbilitySystem := systems.NewShipAbilitySystem()
-	enemyAISystem := systems.NewEnemyAISystem(world)
-	particleSystem := systems.NewParticleSystem(world)
-	shakeSystem := systems.NewScreenShakeSystem(world)
-	
+	// 创建系统，执行顺序在战斗管线中统一声明
+	inputSystem := systems.NewInputSystem()
+	playerInput := ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		inputSystem.ProcessPlayerInput(w.ECS.World, dt)
+	})
 	scene := &BattleScene{
-		world:             world,
-		inputSystem:       systems.NewInputSystem(),
-		shipAbilitySystem: shipAbilitySystem,
-		enemyAISystem:     enemyAISystem,
-		movementSystem:    systems.NewMovementSystem(),
-		fireSystem:        systems.NewFireSystem(world),
-		homingSystem:      systems.NewHomingSystem(),
-		collisionSystem:   systems.NewCollisionSystem(world, shipAbilitySystem, particleSystem, shakeSystem),
-		spawnSystem:       systems.NewSpawnSystem(world),
-		lifetimeSystem:    systems.NewLifetimeSystem(),
-		particleSystem:    particleSystem,
-		shakeSystem:       shakeSystem,
-		renderSystem:      systems.NewRenderSystem(),
-		initialOptions:    opts,
+		world:          world,
+		inputSystem:    inputSystem,
+		pipeline:       simulation.NewBattlePipeline(world, playerInput),
+		bossRushSystem: systems.NewBossRushSystem(world),
+		audioSystem:    systems.NewAudioSystem(world),
+		renderSystem:   systems.NewRenderSystem(),
+		initialOptions: opts,
+		lastUpdate:     time.Now(),
+		history:        ecs.NewPositionHistory(),
 	}
 
-	// 初始化场景
-	scene.initialize(opts)
+	// 控制方式与合作人数取自设置
+	settings := progress.GetSettings()
+	scene.inputSystem.SetControlMode(settings.ControlMode)
+	scene.inputSystem.SetPlayerCount(settings.Players)
 
 	return scene
 }
@@ -104,6 +165,14 @@ func (s *BattleScene) initialize(opts PlayerOptions) {
 		opts.DifficultyMultiplier = 1.0
 	}
 
+	// 每日挑战：以日期种子重置随机源，计分挑战开局即消耗当日机会
+	if opts.Mode == components.ModeDaily {
+		s.world.Seed(daily.Seed(opts.DailyDate))
+		if !opts.DailyPractice {
+			_ = progress.UseDailyAttempt(opts.DailyDate)
+		}
+	}
+
 	// 计算玩家尺寸
 	baseWidth := 40.0
 	baseHeight := 30.0
@@ -122,63 +191,146 @@ func (s *BattleScene) initialize(opts PlayerOptions) {
 		opts.Lives++
 	}
 
+	// 应用天赋加成
+	talentBonuses := loadTalentBonuses(opts.Mode)
+	applyTalentOptions(&opts, talentBonuses)
+	playerWidth = baseWidth * opts.SizeScale
+	playerHeight = baseHeight * opts.SizeScale
+
 	// 配置射击技能
-	fireConfig := components.FireSkillData{
-		FireRateHz:        5.0 + opts.ModFireRateHz,
-		BulletsPerShot:    1 + opts.ModBulletsPerShot,
-		SpreadDeg:         2.0 + opts.ModSpreadDeltaDeg,
-		BulletSpeed:       8.0 + opts.ModBulletSpeed,
-		BulletDamage:      1 + opts.ModBulletDamage,
-		BurstChance:       0.0 + opts.ModBurstChance,
-		PenetrationCount:  0 + opts.ModPenetration,
-		EnableHoming:      opts.ModEnableHoming,
-		HomingTurnRateRad: 0.01 + opts.ModTurnRateRad,
-		BurstInterval:     60 * time.Millisecond,
-	}
-
-	// 初始化射击技能
-	systems.InitializeFireSkill(&fireConfig)
-
-	// 创建玩家实体
-	playerEntry := s.world.CreatePlayer(400, 500, playerWidth, playerHeight, opts.Speed, fireConfig)
-
-	// 初始化生命值
-	components.Health.SetValue(playerEntry, components.HealthData{
-		Current: opts.Lives,
-		Max:     opts.Lives,
-	})
+	fireConfig := opts.UpgradeLevels.FireSkill()
+	applyTalentFire(&fireConfig, talentBonuses)
 
 	// 添加战机被动技能
-	abilityType := "harvest" // 默认Alpha
-	if opts.PassiveKey != "" {
-		switch opts.PassiveKey {
-		case "alpha":
-			abilityType = "harvest"
-		case "beta":
-			abilityType = "speed_frenzy"
-		case "gamma":
-			abilityType = "dodge_master"
-		case "delta":
-			abilityType = "energy_shield"
-		}
+	abilityType := opts.AbilityType
+	if abilityType == "" {
+		abilityType = ships.Default().AbilityType
+	}
+
+	// 创建玩家实体（合作时两名玩家各自拥有射击技能与被动技能）
+	settings := progress.GetSettings()
+	playerCount := max(settings.Players, 1)
+	spawnXs := []float64{400}
+	if playerCount > 1 {
+		spawnXs = []float64{300, 500}
+	}
+	shieldBonus := int(talentBonuses[talent.StatShieldMax])
+	for slot, spawnX := range spawnXs {
+		playerFire := fireConfig
+		simulation.InitializeFireSkill(&playerFire)
+		playerEntry := s.world.CreatePlayer(slot, spawnX, 500, playerWidth, playerHeight, opts.Speed, playerFire)
+
+		// 初始化生命值
+		components.Health.SetValue(playerEntry, components.HealthData{
+			Current: opts.Lives,
+			Max:     opts.Lives,
+		})
+
+		// 初始化ShipAbility组件
+		components.ShipAbility.SetValue(playerEntry, components.ShipAbilityData{
+			AbilityType:   abilityType,
+			ShieldMax:     opts.Lives + shieldBonus, // Delta的护盾上限
+			ShieldCurrent: opts.Lives + shieldBonus,
+		})
 	}
-	
-	// 初始化ShipAbility组件
-	components.ShipAbility.SetValue(playerEntry, components.ShipAbilityData{
-		AbilityType:   abilityType,
-		ShieldMax:     opts.Lives, // Delta的护盾上限
-		ShieldCurrent: opts.Lives,
-	})
 
 	// 创建游戏状态实体
-	s.world.CreateGameState(opts.Lives, opts.DifficultyMultiplier)
+	gameStateEntry := s.world.CreateGameState(opts.Lives, opts.DifficultyMultiplier)
+	gameState := components.GameState.Get(gameStateEntry)
+	if opts.Mode != "" {
+		gameState.Mode = opts.Mode
+	}
+	if stage, ok := campaign.ByID(opts.StageID); ok && gameState.Mode == components.ModeCampaign {
+		applyStage(gameState, stage)
+	}
+	if gameState.Mode == components.ModeBossRush {
+		s.prepareBossRush(gameState)
+	}
+	if gameState.Mode == components.ModePractice {
+		// 从指定波次开始：回拨开局时间，使波次与 Boss 计时直接落在该段
+		start := min(max(opts.PracticeStart, 0), gameState.WaveCount)
+		offset := gameState.WaveLength * time.Duration(start)
+		if start >= gameState.WaveCount {
+			offset = gameState.SmallPhaseDuration
+		}
+		gameState.StartTime = gameState.StartTime.Add(-offset)
+		gameState.InfiniteLives = opts.InfiniteLives
+	}
+	// 自适应难度：每日挑战计分局固定难度，不启用
+	gameState.Assist.Enabled = settings.AdaptiveAssist &&
+		!(gameState.Mode == components.ModeDaily && !opts.DailyPractice)
+	gameState.DailyDate = opts.DailyDate
+	gameState.DailyPractice = opts.DailyPractice
+	gameState.PlayerCount = playerCount
+	gameState.PlayerKills = make([]int, playerCount)
+	gameState.SharedLives = playerCount > 1 && settings.SharedLives
+	gameState.ShowContribution = playerCount > 1 && settings.ShowContribution
+
+	s.beginAchievements(gameState, opts)
 
 	// 初始化背景星星
 	s.world.InitializeStars(100)
+	if stage, ok := campaign.ByID(opts.StageID); ok && gameState.Stage != nil {
+		components.Star.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+			components.Sprite.Get(entry).Color = stage.Palette.Star
+		})
+	}
+}
+
+// applyStage 按关卡脚本覆盖波次、Boss 与配色参数
+func applyStage(gameState *components.GameStateData, stage campaign.Stage) {
+	intervals := make([]time.Duration, len(stage.Waves))
+	weights := make([]map[string]float64, len(stage.Waves))
+	for i, wave := range stage.Waves {
+		intervals[i] = wave.MinInterval
+		weights[i] = wave.Weights
+	}
+
+	gameState.WaveCount = len(stage.Waves)
+	gameState.WaveLength = stage.WaveLength
+	gameState.WaveMinIntervals = intervals
+	gameState.SmallPhaseDuration = stage.SmallPhaseDuration()
+	gameState.TotalDuration = stage.TotalDuration()
+	gameState.Stage = &components.StageData{
+		ID:           stage.ID,
+		NameKey:      stage.NameKey,
+		EnemyWeights: weights,
+		Boss:         bossSpec(stage.Boss),
+		Background:   stage.Palette.Background,
+	}
+}
+
+// bossSpec 将关卡 Boss 定义转换为组件参数
+func bossSpec(b campaign.Boss) components.BossSpec {
+	return components.BossSpec{
+		NameKey: b.NameKey,
+		HP:      b.HP,
+		Width:   b.Width,
+		Height:  b.Height,
+		Speed:   b.Speed,
+		Color:   b.Color,
+	}
+}
+
+// prepareBossRush Boss 连战：依次排入默认 Boss 与各关卡 Boss
+func (s *BattleScene) prepareBossRush(gameState *components.GameStateData) {
+	cfg := config.DefaultConfig()
+	gameState.BossQueue = []components.BossSpec{s.pipeline.Spawn.DefaultBoss()}
+	for _, stage := range campaign.Stages {
+		gameState.BossQueue = append(gameState.BossQueue, bossSpec(stage.Boss))
+	}
+	gameState.SmallPhaseDuration = 0
+	gameState.TotalDuration = cfg.BossRushTimePerBoss * time.Duration(len(gameState.BossQueue))
+	gameState.NextBossTime = gameState.Clock.Add(cfg.BossRushBreak)
 }
 
 // Update 更新战斗场景
 func (s *BattleScene) Update() error {
+	// 本次 Update 经过的真实时长（暂停、结算期间同样刷新，恢复后不会追帧）
+	now := time.Now()
+	elapsed := now.Sub(s.lastUpdate)
+	s.lastUpdate = now
+
 	// 更新输入
 	s.inputSystem.Update(s.world.ECS.World)
 
@@ -191,121 +343,413 @@ func (s *BattleScene) Update() error {
 	// 如果游戏结束或胜利，处理结算和重开逻辑
 	if gameState.GameOver || gameState.Victory {
 		if s.inputSystem.IsRestartPressed() {
-			// 重开游戏
-			*s = *NewBattleScene(s.initialOptions)
+			// 重开游戏（每日挑战的计分机会仅一次，重开转为练习）
+			opts := s.initialOptions
+			if opts.Mode == components.ModeDaily {
+				opts.DailyPractice = true
+			}
+			*s = *NewBattleScene(opts)
 			return nil
 		}
 
 		// 结算功勋（一次性）
 		if !gameState.Settled {
-			spawned := gameState.SpawnedCount
-			if gameState.BossSpawned {
-				spawned++
-			}
-			kills := gameState.KilledEnemyCount
-			if gameState.BossKilled {
-				kills++
+			switch gameState.Mode {
+			case components.ModePractice:
+				// 练习不发功勋，也不计入最高分
+				gameState.RewardCached = 0
+			case components.ModeEndless:
+				s.settleEndless(gameState)
+			case components.ModeDaily:
+				s.settleDaily(gameState)
+			default:
+				s.settleStandard(gameState)
 			}
-			elapsed := time.Since(gameState.StartTime)
-
-			// 计算详细奖励
-			breakdown := balance.ComputeDetailedReward(
-				gameState.DifficultyMul,
-				kills,
-				spawned,
-				elapsed,
-				gameState.TotalDuration,
-				gameState.Victory,
-			)
-
-			// 失败时给予基础奖励的 1/3 作为安慰奖
-			if !gameState.Victory && breakdown.BaseReward > 0 {
-				gameState.RewardCached = breakdown.BaseReward / 3
-				breakdown.TotalReward = gameState.RewardCached
-				breakdown.DifficultyBonus = 0
-				breakdown.KillBonus = 0
-				breakdown.SpeedBonus = 0
-				breakdown.PerfectBonus = 0
-				breakdown.BossBonus = 0
-			} else {
-				gameState.RewardCached = breakdown.TotalReward
+
+			// 辅助模式折扣
+			if gameState.Assist.Enabled {
+				s.applyAssistDiscount(gameState)
 			}
 
-			// 保存奖励分解信息
-			gameState.RewardBreakdown = components.RewardBreakdownData{
-				BaseReward:       breakdown.BaseReward,
-				DifficultyBonus:  breakdown.DifficultyBonus,
-				KillBonus:        breakdown.KillBonus,
-				SpeedBonus:       breakdown.SpeedBonus,
-				PerfectBonus:     breakdown.PerfectBonus,
-				BossBonus:        breakdown.BossBonus,
-				TotalReward:      breakdown.TotalReward,
-				PerformanceScore: breakdown.PerformanceScore,
+			// 合作贡献：按击杀划分份额，仅在结算画面显示
+			if gameState.ShowContribution {
+				gameState.PlayerShares = contributionShares(gameState.RewardCached, gameState.PlayerKills)
 			}
 
-			if gameState.RewardCached > 0 {
-				progress.AddMerits(gameState.RewardCached)
+			gameState.RewardPending = gameState.RewardCached > 0 && gameState.Mode != components.ModePractice
+			s.grantReward(gameState)
+			s.recordRun(gameState)
+			if s.achievements {
+				achievements.Publish(achievements.Event{
+					Kind:    achievements.EventRunSettled,
+					Stage:   s.initialOptions.StageID,
+					Victory: gameState.Victory,
+					Elapsed: gameState.Elapsed(),
+				})
+				achievements.EndRun()
 			}
 			gameState.Settled = true
+		} else if gameState.RewardPending && s.inputSystem.IsConfirmed() {
+			s.grantReward(gameState)
 		}
 
 		return nil
 	}
 
-	// 硬收束：总时长达到后若未胜利，直接结算为胜利
-	if time.Since(gameState.StartTime) >= gameState.TotalDuration && !gameState.Victory {
+	// 硬收束：总时长达到后若未胜利，直接结算为胜利（无尽、Boss 连战不限时）
+	if gameState.Mode.HasTimeLimit() &&
+		gameState.Elapsed() >= gameState.TotalDuration && !gameState.Victory {
 		gameState.Victory = true
 		return nil
 	}
 
-	// 处理玩家输入（移动）
-	s.inputSystem.ProcessPlayerInput(s.world.ECS.World)
+	// Boss 连战强化选择期间暂停战斗
+	if gameState.RushPicking {
+		s.bossRushSystem.Update(s.world.ECS.World, s.inputSystem)
+		return nil
+	}
+
+	if s.inputSystem.IsSystemStatsPressed() {
+		s.showStats = !s.showStats
+	}
+
+	// 练习模式回溯：回到约 5 秒前的快照
+	if gameState.Mode == components.ModePractice && s.inputSystem.IsRewindPressed() && len(s.rewind) > 0 {
+		if err := s.world.Restore(s.rewind[0]); err == nil {
+			s.rewind = s.rewind[:0]
+			s.rewindTicks = 0
+			s.history = ecs.NewPositionHistory()
+			return nil
+		}
+	}
+
+	// 练习模式慢动作：只有一半的真实时长推进模拟（模拟时钟随 tick 推进，计时同样减半）
+	if gameState.Mode == components.ModePractice {
+		if s.inputSystem.IsSlowMotionPressed() {
+			gameState.SlowMotion = !gameState.SlowMotion
+		}
+		if gameState.SlowMotion {
+			elapsed /= 2
+		}
+	}
+
+	// 按固定步长推进模拟：每个 tick 执行一遍战斗管线，末尾派发该 tick 的事件
+	// （被动技能、粒子、震屏、音效、成就）
+	for ticks := s.step.Advance(elapsed); ticks > 0; ticks-- {
+		if gameState.GameOver || gameState.Victory {
+			break
+		}
+		s.history.Capture(s.world.ECS.World)
+		s.pipeline.Update(s.world, ecs.TickSeconds)
+		if gameState.Mode == components.ModePractice {
+			s.recordRewind()
+		}
+	}
+
+	return nil
+}
+
+// rewindSeconds 练习模式可回溯的秒数
+const rewindSeconds = 5
+
+// recordRewind 每秒记录一张回溯快照，只保留最近 rewindSeconds 张
+func (s *BattleScene) recordRewind() {
+	s.rewindTicks++
+	if s.rewindTicks < ecs.TickRate {
+		return
+	}
+	s.rewindTicks = 0
+	snap, err := s.world.Snapshot()
+	if err != nil {
+		return
+	}
+	if len(s.rewind) == rewindSeconds {
+		s.rewind = append(s.rewind[:0], s.rewind[1:]...)
+	}
+	s.rewind = append(s.rewind, snap)
+}
+
+// Suspend 中途退出时挂起进行中的出击，下次可从主菜单继续；已结束的出击不挂起
+func (s *BattleScene) Suspend() error {
+	gameState := s.getGameState()
+	if gameState == nil || gameState.GameOver || gameState.Victory {
+		return nil
+	}
+	snap, err := s.world.Snapshot()
+	if err != nil {
+		return err
+	}
+	data, err := snap.Encode()
+	if err != nil {
+		return err
+	}
+	options, err := json.Marshal(s.initialOptions)
+	if err != nil {
+		return err
+	}
+	return progress.SaveSuspendedRun(progress.SuspendedRun{Options: options, Snapshot: data})
+}
+
+// beginAchievements 成就：练习不计入，其余模式订阅战斗事件并在结算时发布
+func (s *BattleScene) beginAchievements(gameState *components.GameStateData, opts PlayerOptions) {
+	s.achievements = gameState.Mode != components.ModePractice && !opts.DailyPractice
+	if s.achievements {
+		achievements.BeginRun(opts.ShipID, string(gameState.Mode), gameState.BaseDifficulty)
+		s.subscribeAchievements()
+	}
+}
 
-	// 更新战机被动技能状态
-	dt := 1.0 / 60.0 // 假设60 FPS
-	s.shipAbilitySystem.Update(s.world.ECS.World, dt)
+// subscribeAchievements 将战斗事件转发给成就追踪
+func (s *BattleScene) subscribeAchievements() {
+	ecs.Subscribe(s.world.Events, func(ecs.EnemyKilled) {
+		achievements.Publish(achievements.Event{Kind: achievements.EventEnemyKilled})
+	})
+	ecs.Subscribe(s.world.Events, func(ecs.PlayerDamaged) {
+		achievements.Publish(achievements.Event{Kind: achievements.EventPlayerDamaged})
+	})
+	ecs.Subscribe(s.world.Events, func(e ecs.BossPhaseChanged) {
+		if e.Phase == ecs.BossPhaseDefeated {
+			achievements.Publish(achievements.Event{Kind: achievements.EventBossKilled, NoHit: e.NoHit})
+		}
+	})
+}
 
-	// 更新敌机AI行为
-	s.enemyAISystem.Update(s.world.ECS.World, dt)
+// grantReward 将待发放的结算奖励写入存档；失败时保持待发放并在结算画面提示重试
+func (s *BattleScene) grantReward(gameState *components.GameStateData) {
+	if !gameState.RewardPending {
+		return
+	}
+	err := progress.AddMerits(gameState.RewardCached, progress.SourceBattleReward, string(gameState.Mode))
+	gameState.RewardFailed = err != nil
+	gameState.RewardPending = err != nil
+}
 
-	// 更新移动系统
-	s.movementSystem.Update(s.world.ECS.World)
+// recordRun 写入出击记录（含加点快照、奖励明细与评级）
+func (s *BattleScene) recordRun(gameState *components.GameStateData) {
+	b := gameState.RewardBreakdown
+	run := progress.Run{
+		Mode:          string(gameState.Mode),
+		StageID:       s.initialOptions.StageID,
+		ShipID:        s.initialOptions.ShipID,
+		Difficulty:    gameState.BaseDifficulty,
+		Upgrades:      s.initialOptions.Upgrades(),
+		Score:         gameState.Score,
+		Kills:         gameState.KilledEnemyCount,
+		Spawned:       gameState.SpawnedCount,
+		BossKills:     gameState.BossKillCount(),
+		BossesSpawned: len(gameState.Bosses),
+		Elapsed:       gameState.Elapsed(),
+		Victory:       gameState.Victory,
+		Assist:        runAssist(gameState.Assist, gameState.Elapsed()),
+		Reward: progress.RunReward{
+			Base:           b.BaseReward,
+			Difficulty:     b.DifficultyBonus,
+			Kill:           b.KillBonus,
+			Speed:          b.SpeedBonus,
+			Perfect:        b.PerfectBonus,
+			Boss:           b.BossBonus,
+			AssistDiscount: b.AssistDiscount,
+			Total:          gameState.RewardCached,
+			Performance:    b.PerformanceScore,
+		},
+		Grade: campaign.Grade(b.PerformanceScore),
+	}
+	if gameState.Mode == components.ModePractice {
+		// 练习不发功勋，也不评级
+		run.Reward = progress.RunReward{}
+		run.Grade = ""
+	}
+	_ = progress.AddRun(run)
+}
 
-	// 处理射击
-	firePressed := s.inputSystem.IsFirePressed()
-	s.fireSystem.Update(s.world.ECS.World, firePressed)
+// runAssist 出击记录中的辅助模式调整记录（与折扣使用同一平均减难程度）
+func runAssist(assist components.AssistData, elapsed time.Duration) progress.RunAssist {
+	if !assist.Enabled {
+		return progress.RunAssist{}
+	}
+	out := progress.RunAssist{Enabled: true, AverageEase: assist.AverageEase(elapsed)}
+	for _, sample := range assist.History {
+		out.Changes = append(out.Changes, progress.AssistChange{At: sample.At, Level: sample.Level})
+	}
+	return out
+}
 
-	// 更新追踪系统
-	s.homingSystem.Update(s.world.ECS.World)
+// settleStandard 标准模式结算：按胜负、击杀率与用时计算奖励
+func (s *BattleScene) settleStandard(gameState *components.GameStateData) {
+	spawned := gameState.SpawnedCount + len(gameState.Bosses)
+	kills := gameState.KilledEnemyCount + gameState.BossKillCount()
+	elapsed := gameState.Elapsed()
+
+	// 计算详细奖励
+	breakdown := balance.SettleStandardReward(
+		gameState.DifficultyMul,
+		kills,
+		spawned,
+		elapsed,
+		gameState.TotalDuration,
+		gameState.Victory,
+	)
+	gameState.RewardCached = breakdown.TotalReward
+
+	gameState.RewardBreakdown = rewardBreakdownData(breakdown)
+	s.recordHighScore(gameState, elapsed)
+
+	if gameState.Stage != nil && gameState.Victory {
+		s.recordStageClear(gameState, elapsed)
+	}
+}
 
-	// 更新碰撞检测（会触发粒子和震动）
-	s.collisionSystem.Update(s.world.ECS.World)
+// recordStageClear 记录关卡通关评级，与历史最佳合并后保存
+func (s *BattleScene) recordStageClear(gameState *components.GameStateData, elapsed time.Duration) {
+	stage := gameState.Stage
+	stage.Grade = campaign.Grade(gameState.RewardBreakdown.PerformanceScore)
 
-	// 更新生成系统
-	s.spawnSystem.Update(s.world.ECS.World)
+	rec, ok := progress.GetStageRecord(stage.ID)
+	if !ok {
+		rec.BestTime = elapsed
+	}
+	stage.NewBest = !ok || gameState.Score > rec.BestScore || campaign.BetterGrade(rec.BestGrade, stage.Grade) != rec.BestGrade
 
-	// 清理超出屏幕的实体
-	s.movementSystem.CleanOutOfBounds(s.world.ECS.World)
+	rec.BestScore = max(rec.BestScore, gameState.Score)
+	rec.BestGrade = campaign.BetterGrade(rec.BestGrade, stage.Grade)
+	rec.BestTime = min(rec.BestTime, elapsed)
+	rec.Clears++
+	_ = progress.SaveStageRecord(rec)
+}
 
-	// 更新生命周期系统
-	s.lifetimeSystem.Update(s.world.ECS.World)
+// settleEndless 无尽模式结算：按存活时间与击败 Boss 数计算奖励
+func (s *BattleScene) settleEndless(gameState *components.GameStateData) {
+	survival := gameState.Elapsed()
+	gameState.SurvivalTime = survival
 
-	// 更新粒子系统
-	s.particleSystem.Update(s.world.ECS.World, dt)
+	rc := config.DefaultConfig().Reward
+	breakdown := balance.ComputeSurvivalRewardWith(rc, gameState.BaseDifficulty, survival, gameState.BossKillCount())
+	gameState.RewardCached = breakdown.TotalReward
+	gameState.RewardBreakdown = rewardBreakdownData(breakdown)
+	s.recordHighScore(gameState, survival)
+}
 
-	// 更新屏幕震动系统
-	s.shakeSystem.Update(s.world.ECS.World, dt)
+// settleDaily 每日挑战结算：计分挑战按标准规则结算并写入当日排行榜，练习不计分
+func (s *BattleScene) settleDaily(gameState *components.GameStateData) {
+	if gameState.DailyPractice {
+		gameState.RewardCached = 0
+		return
+	}
 
-	return nil
+	s.settleStandard(gameState)
+
+	kills := gameState.KilledEnemyCount + gameState.BossKillCount()
+	_ = progress.AddDailyScore(progress.DailyScore{
+		Date:    gameState.DailyDate,
+		Score:   gameState.Score,
+		Kills:   kills,
+		Elapsed: gameState.Elapsed(),
+		Victory: gameState.Victory,
+	})
+
+	// 当日排名：得分更高的记录数 + 1
+	gameState.DailyRank = 1
+	if board, err := progress.TopDailyScores(gameState.DailyDate, 1000); err == nil {
+		for _, entry := range board {
+			if entry.Score > gameState.Score {
+				gameState.DailyRank++
+			}
+		}
+	}
+}
+
+// recordHighScore 写入本模式成绩并标记是否刷新纪录
+func (s *BattleScene) recordHighScore(gameState *components.GameStateData, survival time.Duration) {
+	mode := string(gameState.Mode)
+	best := progress.BestScore(mode)
+	gameState.NewRecord = gameState.Score > best
+	gameState.BestScore = max(best, gameState.Score)
+	_ = progress.AddHighScore(progress.HighScore{
+		Mode:     mode,
+		Score:    gameState.Score,
+		Survival: survival,
+		Wave:     gameState.WaveReached,
+	})
+}
+
+// applyAssistDiscount 按辅助模式的平均减难程度扣减功勋
+func (s *BattleScene) applyAssistDiscount(gameState *components.GameStateData) {
+	if gameState.RewardCached <= 0 {
+		return
+	}
+	cfg := config.DefaultConfig()
+	avgEase := gameState.Assist.AverageEase(gameState.Elapsed())
+
+	b := gameState.RewardBreakdown
+	breakdown := balance.ApplyAssistDiscountWith(cfg.Reward, balance.RewardBreakdown{TotalReward: gameState.RewardCached}, avgEase, cfg.AssistMaxLevel)
+	b.AssistDiscount = breakdown.AssistDiscount
+	b.TotalReward = breakdown.TotalReward
+	gameState.RewardBreakdown = b
+	gameState.RewardCached = breakdown.TotalReward
+}
+
+// rewardBreakdownData 将奖励分解转换为组件数据
+func rewardBreakdownData(b balance.RewardBreakdown) components.RewardBreakdownData {
+	return components.RewardBreakdownData{
+		BaseReward:       b.BaseReward,
+		DifficultyBonus:  b.DifficultyBonus,
+		KillBonus:        b.KillBonus,
+		SpeedBonus:       b.SpeedBonus,
+		PerfectBonus:     b.PerfectBonus,
+		BossBonus:        b.BossBonus,
+		AssistDiscount:   b.AssistDiscount,
+		TotalReward:      b.TotalReward,
+		PerformanceScore: b.PerformanceScore,
+	}
+}
+
+// contributionShares 按击杀数比例划分功勋份额（仅显示，两名玩家共用一个档案），余数归击杀最多的玩家；无人击杀时平分
+func contributionShares(total int, kills []int) []int {
+	shares := make([]int, len(kills))
+	if len(kills) == 0 || total <= 0 {
+		return shares
+	}
+
+	sum := 0
+	top := 0
+	for i, k := range kills {
+		sum += k
+		if k > kills[top] {
+			top = i
+		}
+	}
+
+	given := 0
+	for i, k := range kills {
+		if sum > 0 {
+			shares[i] = total * k / sum
+		} else {
+			shares[i] = total / len(kills)
+		}
+		given += shares[i]
+	}
+	shares[top] += total - given
+	return shares
 }
 
 // Draw 绘制战斗场景
 func (s *BattleScene) Draw(screen *ebiten.Image) {
+	// 在上一 tick 与当前 tick 之间插值绘制
+	s.renderSystem.SetInterpolation(s.history, s.step.Alpha(time.Since(s.lastUpdate)))
+
 	// 绘制主要场景
 	s.renderSystem.Draw(s.world.ECS.World, screen)
 	
 	// 绘制粒子效果
-	s.particleSystem.Draw(s.world.ECS.World, screen)
+	s.renderSystem.DrawParticles(s.world.ECS.World, screen)
+
+	// 绘制成就达成提示
+	systems.DrawAchievementToasts(screen, achievements.Toasts(time.Now()))
+
+	// 系统耗时面板（F3）
+	if s.showStats {
+		s.renderSystem.DrawSystemStats(screen, s.pipeline.Scheduler.Stats())
+	}
 }
 
 // getGameState 获取游戏状态
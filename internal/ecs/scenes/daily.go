@@ -0,0 +1,154 @@
+package scenes
+
+import (
+	"time"
+
+	"spacebattle/internal/daily"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// 每日挑战选项索引
+const (
+	dailyScored = iota
+	dailyPractice
+	dailyBack
+	dailyOptionCount
+)
+
+// DailyScene 每日挑战场景：展示当日配置与排行榜，选择计分挑战或练习
+type DailyScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	date        string
+	loadout     daily.Loadout
+	info        systems.DailyInfo
+	started     bool
+	practice    bool
+	done        bool
+}
+
+// NewDailyScene 创建当天的每日挑战场景
+func NewDailyScene() *DailyScene {
+	return NewDailySceneFor(daily.DateKey(time.Now()))
+}
+
+// NewDailySceneFor 创建指定日期的每日挑战场景
+func NewDailySceneFor(date string) *DailyScene {
+	world := ecs.NewWorld()
+
+	scene := &DailyScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+		date:        date,
+		loadout:     daily.LoadoutFor(date),
+	}
+	scene.loadInfo()
+
+	// 当日已挑战时默认选中练习
+	selected := dailyScored
+	if scene.info.AttemptUsed {
+		selected = dailyPractice
+	}
+
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: selected,
+		OptionCount:   dailyOptionCount,
+		Confirmed:     false,
+	})
+
+	return scene
+}
+
+// loadInfo 读取当日配置、挑战状态与排行榜
+func (s *DailyScene) loadInfo() {
+	s.info = systems.DailyInfo{
+		Date:        s.date,
+		ShipNameKey: s.loadout.Ship.NameKey,
+		Difficulty:  s.loadout.Difficulty,
+		AttemptUsed: progress.DailyAttemptUsed(s.date),
+	}
+	if board, err := progress.TopDailyScores(s.date, 5); err == nil {
+		for _, entry := range board {
+			s.info.Board = append(s.info.Board, systems.DailyBoardRow{
+				Score:   entry.Score,
+				Kills:   entry.Kills,
+				Elapsed: entry.Elapsed,
+				Victory: entry.Victory,
+			})
+		}
+	}
+}
+
+// Update 更新每日挑战场景
+func (s *DailyScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil || !menuState.Confirmed {
+		return nil
+	}
+	menuState.Confirmed = false
+
+	switch menuState.SelectedIndex {
+	case dailyScored:
+		// 当日计分机会已用完
+		if !s.info.AttemptUsed {
+			s.started = true
+		}
+	case dailyPractice:
+		s.started = true
+		s.practice = true
+	case dailyBack:
+		s.done = true
+	}
+	return nil
+}
+
+// Draw 绘制每日挑战场景
+func (s *DailyScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+	s.menuSystem.DrawDaily(screen, menuState, s.info)
+}
+
+// getMenuState 获取菜单状态
+func (s *DailyScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsStarted 检查是否开始挑战
+func (s *DailyScene) IsStarted() bool {
+	return s.started
+}
+
+// IsDone 检查是否返回主菜单
+func (s *DailyScene) IsDone() bool {
+	return s.done
+}
+
+// GetOptions 按当日固定配置生成玩家选项（忽略玩家自己的加点）
+func (s *DailyScene) GetOptions() PlayerOptions {
+	opts := OptionsForShip(s.loadout.Ship)
+	opts.DifficultyMultiplier = s.loadout.Difficulty
+	opts.Mode = components.ModeDaily
+	opts.DailyDate = s.date
+	opts.DailyPractice = s.practice
+	opts.ApplyUpgrades(s.loadout.Upgrades)
+	return opts
+}
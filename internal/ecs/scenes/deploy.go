@@ -1,6 +1,7 @@
 package scenes
 
 import (
+	"fmt"
 	"time"
 
 	"spacebattle/internal/balance"
@@ -8,6 +9,7 @@ import (
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/i18n"
 	"spacebattle/internal/progress"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -21,6 +23,10 @@ type DeployScene struct {
 	menuSystem     *systems.MenuSystem
 	playerOptions  PlayerOptions
 	difficulty     float64
+	modeIndex      int
+	focus          int // 当前聚焦的行（deployRow*）
+	practiceStart  int // 练习起始段：波次索引，等于波次数时直接进入 Boss
+	infiniteLives  bool
 	minDifficulty  float64
 	maxDifficulty  float64
 	leftHoldStart  time.Time
@@ -28,6 +34,23 @@ type DeployScene struct {
 	lastAdjust     time.Time
 }
 
+// deployModes 出征可选的游戏模式
+var deployModes = []components.GameMode{
+	components.ModeStandard,
+	components.ModeEndless,
+	components.ModeCampaign,
+	components.ModeBossRush,
+	components.ModePractice,
+}
+
+// 出征界面的行（上下键切换聚焦，左右键调整；练习相关行仅在练习模式显示）
+const (
+	deployRowDifficulty = iota
+	deployRowMode
+	deployRowSection
+	deployRowLives
+)
+
 // NewDeployScene 创建出征场景
 func NewDeployScene(opts PlayerOptions) *DeployScene {
 	world := ecs.NewWorld()
@@ -72,8 +95,46 @@ func (s *DeployScene) Update() error {
 	step := s.calculateStep()
 	repeat := 150 * time.Millisecond
 
+	// 点击/触摸：右侧等同右键，左侧等同左键，确认区域等同回车
+	var tapAction systems.MenuTapAction
+	var tapIndex int
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		tapAction, tapIndex = s.inputSystem.MenuTap(components.MenuState.Get(entry))
+	})
+
+	// 上下键切换聚焦行
+	rowCount := deployRowMode + 1
+	if s.isPractice() {
+		rowCount = deployRowLives + 1
+		// 练习不消耗功勋，难度上限不受功勋限制
+		effectiveMaxDiff = s.maxDifficulty
+	}
+	if s.inputSystem.IsGMDownPressed() {
+		s.focus = (s.focus + 1) % rowCount
+	} else if s.inputSystem.IsGMUpPressed() {
+		s.focus = (s.focus + rowCount - 1) % rowCount
+	}
+
+	// 点击选项行：聚焦并切换该行的值
+	if tapAction == systems.MenuTapItem {
+		s.focus = deployRowMode + tapIndex
+		s.cycleRow(s.focus, 1)
+	} else if s.focus != deployRowDifficulty && tapAction != systems.MenuTapPrev && tapAction != systems.MenuTapNext {
+		// 聚焦选项行时左右键切换值，不调整难度
+		if s.inputSystem.IsGMRightPressed() {
+			s.cycleRow(s.focus, 1)
+		} else if s.inputSystem.IsGMLeftPressed() {
+			s.cycleRow(s.focus, -1)
+		}
+		s.updateMenuState()
+		if s.inputSystem.IsConfirmed() || tapAction == systems.MenuTapConfirm {
+			s.confirm()
+		}
+		return nil
+	}
+
 	// 右键（增加难度）
-	if s.inputSystem.IsGMRightPressed() {
+	if s.inputSystem.IsGMRightPressed() || tapAction == systems.MenuTapNext {
 		s.rightHoldStart = now
 		newDiff := clampFloat(s.difficulty+step, s.minDifficulty, effectiveMaxDiff)
 		s.difficulty = newDiff
@@ -91,7 +152,7 @@ func (s *DeployScene) Update() error {
 	}
 
 	// 左键（快速设置为最大可支付难度，作为"极限挑战"快捷键）
-	if s.inputSystem.IsGMLeftPressed() {
+	if s.inputSystem.IsGMLeftPressed() || tapAction == systems.MenuTapPrev {
 		s.leftHoldStart = now
 		// 如果当前难度不是最大可支付难度，直接跳到最大可支付难度
 		if s.difficulty < effectiveMaxDiff {
@@ -113,35 +174,83 @@ func (s *DeployScene) Update() error {
 		}
 	}
 
-	// 更新菜单状态
+	s.updateMenuState()
+
+	// Enter 确认
+	if s.inputSystem.IsConfirmed() || tapAction == systems.MenuTapConfirm {
+		s.confirm()
+	}
+
+	return nil
+}
+
+// isPractice 当前是否选择练习模式
+func (s *DeployScene) isPractice() bool {
+	return deployModes[s.modeIndex] == components.ModePractice
+}
+
+// cycleRow 切换选项行的值（dir 为 +1 或 -1）
+func (s *DeployScene) cycleRow(row, dir int) {
+	switch row {
+	case deployRowMode:
+		s.modeIndex = (s.modeIndex + dir + len(deployModes)) % len(deployModes)
+		if !s.isPractice() && s.focus > deployRowMode {
+			s.focus = deployRowMode
+		}
+	case deployRowSection:
+		// 波次 1..N 之后为 Boss
+		sections := config.DefaultConfig().WaveCount + 1
+		s.practiceStart = (s.practiceStart + dir + sections) % sections
+	case deployRowLives:
+		s.infiniteLives = !s.infiniteLives
+	}
+}
+
+// updateMenuState 同步难度与功勋显示
+func (s *DeployScene) updateMenuState() {
 	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
 		state := components.MenuState.Get(entry)
 		state.DifficultyMul = s.difficulty
 		state.AvailableMerits = progress.GetMerits()
 	})
+}
 
-	// Enter 确认
-	if s.inputSystem.IsConfirmed() {
-		cost := balance.DifficultyCost(s.difficulty)
-		var confirmed bool
-		components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
-			state := components.MenuState.Get(entry)
-			if progress.SpendMerits(cost) {
-				s.playerOptions.DifficultyMultiplier = s.difficulty
-				state.Confirmed = true
-				confirmed = true
-			}
-		})
-		if confirmed {
-			// 更新功勋显示
-			components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
-				state := components.MenuState.Get(entry)
-				state.AvailableMerits = progress.GetMerits()
-			})
+// confirm 支付难度成本并确认出征（练习模式免费）；
+// 战役模式在关卡选择确认后才扣费，这里只检查功勋是否足够，从关卡选择返回菜单不会损失功勋
+func (s *DeployScene) confirm() {
+	opts := s.playerOptions
+	opts.DifficultyMultiplier = s.difficulty
+	opts.Mode = deployModes[s.modeIndex]
+	if s.isPractice() {
+		opts.PracticeStart = s.practiceStart
+		opts.InfiniteLives = s.infiniteLives
+	}
+	if opts.Mode == components.ModeCampaign {
+		if progress.GetMerits() < difficultyCost(opts) {
+			return
 		}
+	} else if err := chargeDifficulty(opts); err != nil {
+		return
 	}
 
-	return nil
+	s.playerOptions = opts
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		components.MenuState.Get(entry).Confirmed = true
+	})
+	s.updateMenuState()
+}
+
+// difficultyCost 出征的难度成本（练习模式免费）
+func difficultyCost(opts PlayerOptions) int {
+	if opts.Mode == components.ModePractice {
+		return 0
+	}
+	return balance.DifficultyCost(opts.DifficultyMultiplier)
+}
+
+// chargeDifficulty 支付出征的难度成本并记入功勋流水
+func chargeDifficulty(opts PlayerOptions) error {
+	return progress.SpendMerits(difficultyCost(opts), progress.SourceDifficultyCost, fmt.Sprintf("%.2f", opts.DifficultyMultiplier))
 }
 
 // Draw 绘制出征场景
@@ -158,9 +267,30 @@ func (s *DeployScene) Draw(screen *ebiten.Image) {
 
 	// 计算成本
 	cost := balance.DifficultyCost(s.difficulty)
+	if s.isPractice() {
+		cost = 0
+	}
+
+	// 选项行：模式，练习模式追加起始段与生命
+	mode := deployModes[s.modeIndex]
+	rows := []systems.SettingItem{{Key: "deploy.mode", Value: i18n.T(modeKey(mode))}}
+	if s.isPractice() {
+		rows = append(rows,
+			systems.SettingItem{Key: "practice.start", Value: s.sectionText()},
+			systems.SettingItem{Key: "practice.lives", Value: choiceText(s.infiniteLives, "practice.infinite", "practice.normal")},
+		)
+	}
 
 	// 使用详细渲染
-	s.menuSystem.DrawDeployWithDetails(screen, menuState, s.difficulty, cost)
+	s.menuSystem.DrawDeployWithDetails(screen, menuState, s.difficulty, cost, rows, s.focus-deployRowMode, modeKey(mode)+".desc")
+}
+
+// sectionText 练习起始段的显示文本
+func (s *DeployScene) sectionText() string {
+	if s.practiceStart >= config.DefaultConfig().WaveCount {
+		return "BOSS"
+	}
+	return fmt.Sprintf("%s %d", i18n.T("endless.wave"), s.practiceStart+1)
 }
 
 // IsConfirmed 检查是否已确认
@@ -178,6 +308,11 @@ func (s *DeployScene) GetOptions() PlayerOptions {
 	return s.playerOptions
 }
 
+// modeKey 返回游戏模式的本地化键
+func modeKey(mode components.GameMode) string {
+	return "mode." + string(mode)
+}
+
 // calculateStep 计算难度调整步进
 func (s *DeployScene) calculateStep() float64 {
 	v := s.difficulty
@@ -4,6 +4,7 @@ import (
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/yohamta/donburi"
@@ -14,6 +15,8 @@ type MainMenuScene struct {
 	world       *ecs.World
 	inputSystem *systems.InputSystem
 	menuSystem  *systems.MenuSystem
+	resumable   bool // 当前档案有挂起的出击
+	resuming    bool // 已选择继续挂起的出击
 }
 
 // NewMainMenuScene 创建主菜单场景
@@ -24,13 +27,14 @@ func NewMainMenuScene() *MainMenuScene {
 		world:       world,
 		inputSystem: systems.NewInputSystem(),
 		menuSystem:  systems.NewMenuSystem(),
+		resumable:   progress.HasSuspendedRun(),
 	}
 
 	// 创建菜单状态
 	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
 	components.MenuState.Set(menuState, &components.MenuStateData{
 		SelectedIndex: 0,
-		OptionCount:   2, // 开始游戏、退出
+		OptionCount:   7, // 开始游戏、每日挑战、天赋、统计、档案、设置、退出
 		Confirmed:     false,
 	})
 
@@ -41,12 +45,23 @@ func NewMainMenuScene() *MainMenuScene {
 func (s *MainMenuScene) Update() error {
 	s.inputSystem.Update(s.world.ECS.World)
 	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+	if s.resumable && s.inputSystem.IsResumePressed() {
+		s.resuming = true
+	}
 	return nil
 }
 
 // Draw 绘制主菜单
 func (s *MainMenuScene) Draw(screen *ebiten.Image) {
 	s.menuSystem.DrawMainMenu(s.world.ECS.World, screen)
+	if s.resumable {
+		s.menuSystem.DrawResumeHint(screen)
+	}
+}
+
+// IsResuming 是否选择继续挂起的出击
+func (s *MainMenuScene) IsResuming() bool {
+	return s.resuming
 }
 
 // GetSelectedOption 获取选中的选项
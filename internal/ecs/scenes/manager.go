@@ -1,6 +1,10 @@
 package scenes
 
 import (
+	"spacebattle/internal/achievements"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/progress"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -19,6 +23,24 @@ const (
 	SceneTypeUpgrade
 	SceneTypeDeploy
 	SceneTypeBattle
+	SceneTypeSettings
+	SceneTypeStageSelect
+	SceneTypeDaily
+	SceneTypeTalent
+	SceneTypeProfiles
+	SceneTypeStats
+	SceneTypeAchievements
+)
+
+// 主菜单选项索引
+const (
+	mainMenuStart = iota
+	mainMenuDaily
+	mainMenuTalents
+	mainMenuStats
+	mainMenuProfiles
+	mainMenuSettings
+	mainMenuExit
 )
 
 // SceneManager 场景管理器
@@ -46,6 +68,13 @@ func (sm *SceneManager) Update() error {
 		return err
 	}
 
+	// 主菜单选择退出时结束游戏
+	if mainMenu, ok := sm.currentScene.(*MainMenuScene); ok {
+		if mainMenu.IsConfirmed() && mainMenu.GetSelectedOption() == mainMenuExit {
+			return ebiten.Termination
+		}
+	}
+
 	// 处理场景切换逻辑
 	sm.handleSceneTransition()
 
@@ -64,14 +93,85 @@ func (sm *SceneManager) handleSceneTransition() {
 	switch sm.sceneType {
 	case SceneTypeMainMenu:
 		if mainMenu, ok := sm.currentScene.(*MainMenuScene); ok {
+			if mainMenu.IsResuming() {
+				sm.resumeBattle()
+				return
+			}
 			if mainMenu.IsConfirmed() {
-				selectedOption := mainMenu.GetSelectedOption()
-				if selectedOption == 0 {
+				switch mainMenu.GetSelectedOption() {
+				case mainMenuStart:
 					// 开始游戏 -> 战机选择
 					sm.currentScene = NewShipSelectScene()
 					sm.sceneType = SceneTypeShipSelect
+				case mainMenuDaily:
+					sm.currentScene = NewDailyScene()
+					sm.sceneType = SceneTypeDaily
+				case mainMenuTalents:
+					sm.currentScene = NewTalentScene()
+					sm.sceneType = SceneTypeTalent
+				case mainMenuStats:
+					sm.currentScene = NewStatsScene()
+					sm.sceneType = SceneTypeStats
+				case mainMenuProfiles:
+					sm.currentScene = NewProfilesScene()
+					sm.sceneType = SceneTypeProfiles
+				case mainMenuSettings:
+					sm.currentScene = NewSettingsScene()
+					sm.sceneType = SceneTypeSettings
 				}
-				// 退出选项由 ebiten.Termination 处理
+				// 退出选项在 Update 中返回 ebiten.Termination
+			}
+		}
+
+	case SceneTypeDaily:
+		if dailyScene, ok := sm.currentScene.(*DailyScene); ok {
+			switch {
+			case dailyScene.IsStarted():
+				sm.currentScene = NewBattleScene(dailyScene.GetOptions())
+				sm.sceneType = SceneTypeBattle
+			case dailyScene.IsDone():
+				sm.SwitchToMainMenu()
+			}
+		}
+
+	case SceneTypeTalent:
+		if talentScene, ok := sm.currentScene.(*TalentScene); ok {
+			if talentScene.IsDone() {
+				sm.SwitchToMainMenu()
+			}
+		}
+
+	case SceneTypeStats:
+		if statsScene, ok := sm.currentScene.(*StatsScene); ok {
+			switch {
+			case statsScene.IsBrowsingAchievements():
+				sm.currentScene = NewAchievementsScene()
+				sm.sceneType = SceneTypeAchievements
+			case statsScene.IsDone():
+				sm.SwitchToMainMenu()
+			}
+		}
+
+	case SceneTypeAchievements:
+		if achievementsScene, ok := sm.currentScene.(*AchievementsScene); ok {
+			if achievementsScene.IsDone() {
+				sm.currentScene = NewStatsScene()
+				sm.sceneType = SceneTypeStats
+			}
+		}
+
+	case SceneTypeProfiles:
+		// 档案场景自行处理 ESC（输入名称时用于取消）
+		if profilesScene, ok := sm.currentScene.(*ProfilesScene); ok {
+			if profilesScene.IsDone() {
+				sm.SwitchToMainMenu()
+			}
+		}
+
+	case SceneTypeSettings:
+		if settings, ok := sm.currentScene.(*SettingsScene); ok {
+			if settings.IsDone() {
+				sm.SwitchToMainMenu()
 			}
 		}
 
@@ -97,7 +197,21 @@ func (sm *SceneManager) handleSceneTransition() {
 		if deploy, ok := sm.currentScene.(*DeployScene); ok {
 			if deploy.IsConfirmed() {
 				opts := deploy.GetOptions()
-				sm.currentScene = NewBattleScene(opts)
+				if opts.Mode == components.ModeCampaign {
+					// 战役模式先选择关卡
+					sm.currentScene = NewStageSelectScene(opts)
+					sm.sceneType = SceneTypeStageSelect
+				} else {
+					sm.currentScene = NewBattleScene(opts)
+					sm.sceneType = SceneTypeBattle
+				}
+			}
+		}
+
+	case SceneTypeStageSelect:
+		if stageSelect, ok := sm.currentScene.(*StageSelectScene); ok {
+			if stageSelect.IsConfirmed() {
+				sm.currentScene = NewBattleScene(stageSelect.GetOptions())
 				sm.sceneType = SceneTypeBattle
 			}
 		}
@@ -108,8 +222,29 @@ func (sm *SceneManager) handleSceneTransition() {
 	}
 }
 
-// SwitchToMainMenu 切换到主菜单
+// resumeBattle 继续挂起的出击；挂起记录无法恢复时丢弃并留在主菜单
+func (sm *SceneManager) resumeBattle() {
+	run, ok := progress.GetSuspendedRun()
+	_ = progress.ClearSuspendedRun()
+	if !ok {
+		sm.SwitchToMainMenu()
+		return
+	}
+	battle, err := ResumeBattleScene(run)
+	if err != nil {
+		sm.SwitchToMainMenu()
+		return
+	}
+	sm.currentScene = battle
+	sm.sceneType = SceneTypeBattle
+}
+
+// SwitchToMainMenu 切换到主菜单（中途退出的出击挂起以便继续，并保存成就计数）
 func (sm *SceneManager) SwitchToMainMenu() {
+	if battle, ok := sm.currentScene.(*BattleScene); ok {
+		_ = battle.Suspend()
+	}
+	achievements.Flush()
 	sm.currentScene = NewMainMenuScene()
 	sm.sceneType = SceneTypeMainMenu
 }
@@ -0,0 +1,219 @@
+package scenes
+
+import (
+	"errors"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// ProfilesScene 存档档案管理：新建、重命名、删除与切换
+type ProfilesScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	profiles    []progress.ProfileInfo
+	view        systems.ProfilesView
+	renameID    int64 // 正在重命名的档案，0 表示新建
+	done        bool
+}
+
+// NewProfilesScene 创建档案管理场景
+func NewProfilesScene() *ProfilesScene {
+	world := ecs.NewWorld()
+
+	scene := &ProfilesScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+	}
+	scene.loadProfiles()
+
+	// 默认选中当前档案
+	selected := 0
+	current := progress.CurrentProfile().ID
+	for i, p := range scene.profiles {
+		if p.ID == current {
+			selected = i
+		}
+	}
+
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: selected,
+		OptionCount:   len(scene.profiles) + 2, // 档案列表、新建档案、返回
+	})
+
+	return scene
+}
+
+// loadProfiles 读取档案列表
+func (s *ProfilesScene) loadProfiles() {
+	s.profiles, _ = progress.ListProfiles()
+	current := progress.CurrentProfile().ID
+	s.view.Rows = s.view.Rows[:0]
+	for _, p := range s.profiles {
+		s.view.Rows = append(s.view.Rows, systems.ProfileRow{Name: p.Name, Current: p.ID == current})
+	}
+	if menuState := s.getMenuState(); menuState != nil {
+		menuState.OptionCount = len(s.profiles) + 2
+		menuState.SelectedIndex = min(menuState.SelectedIndex, menuState.OptionCount-1)
+	}
+}
+
+// Update 更新档案管理场景
+func (s *ProfilesScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return nil
+	}
+	if s.view.Editing {
+		s.updateEditing()
+		return nil
+	}
+
+	if s.inputSystem.IsGMUpPressed() {
+		s.view.ConfirmDelete = false
+		menuState.SelectedIndex = (menuState.SelectedIndex + menuState.OptionCount - 1) % menuState.OptionCount
+	}
+	if s.inputSystem.IsGMDownPressed() {
+		s.view.ConfirmDelete = false
+		menuState.SelectedIndex = (menuState.SelectedIndex + 1) % menuState.OptionCount
+	}
+	if s.inputSystem.IsEscapePressed() {
+		s.done = true
+		return nil
+	}
+
+	activate := s.inputSystem.IsConfirmed()
+	rename := s.inputSystem.IsRenamePressed()
+	remove := s.inputSystem.IsDeletePressed()
+
+	// 点击/触摸：点击选中，再次点击执行；底部按钮重命名与删除
+	switch action, idx := s.inputSystem.MenuTap(menuState); action {
+	case systems.MenuTapItem:
+		if idx == menuState.SelectedIndex {
+			activate = true
+		} else {
+			s.view.ConfirmDelete = false
+			menuState.SelectedIndex = idx
+		}
+	case systems.MenuTapPrev:
+		rename = true
+	case systems.MenuTapNext:
+		remove = true
+	}
+
+	idx := menuState.SelectedIndex
+	switch {
+	case activate && idx < len(s.profiles):
+		// 切换到选中档案并返回主菜单
+		if err := progress.SwitchProfile(s.profiles[idx].ID); err == nil {
+			s.done = true
+		}
+	case activate && idx == len(s.profiles):
+		s.startEditing(0, "")
+	case activate:
+		s.done = true
+	case rename && idx < len(s.profiles):
+		s.startEditing(s.profiles[idx].ID, s.profiles[idx].Name)
+	case remove && idx < len(s.profiles):
+		// 删除需再次确认
+		if !s.view.ConfirmDelete {
+			s.view.ConfirmDelete = true
+			return nil
+		}
+		s.view.ConfirmDelete = false
+		s.view.ErrorKey = ""
+		if err := progress.DeleteProfile(s.profiles[idx].ID); err != nil {
+			s.view.ErrorKey = profileErrorKey(err)
+		}
+		s.loadProfiles()
+	}
+	return nil
+}
+
+// startEditing 进入名称输入状态；id 为 0 表示新建
+func (s *ProfilesScene) startEditing(id int64, name string) {
+	s.renameID = id
+	s.view.Editing = true
+	s.view.Renaming = id != 0
+	s.view.EditText = name
+	s.view.ErrorKey = ""
+	s.view.ConfirmDelete = false
+}
+
+// updateEditing 处理名称输入：字符追加、退格删除、回车提交、ESC 取消
+func (s *ProfilesScene) updateEditing() {
+	s.view.EditText += string(s.inputSystem.TypedChars())
+	if s.inputSystem.IsBackspacePressed() && s.view.EditText != "" {
+		runes := []rune(s.view.EditText)
+		s.view.EditText = string(runes[:len(runes)-1])
+	}
+	if s.inputSystem.IsEscapePressed() {
+		s.view.Editing = false
+		s.view.ErrorKey = ""
+		return
+	}
+	if !s.inputSystem.IsConfirmed() {
+		return
+	}
+
+	var err error
+	if s.renameID != 0 {
+		err = progress.RenameProfile(s.renameID, s.view.EditText)
+	} else {
+		_, err = progress.CreateProfile(s.view.EditText)
+	}
+	if err != nil {
+		s.view.ErrorKey = profileErrorKey(err)
+		return
+	}
+	s.view.Editing = false
+	s.view.ErrorKey = ""
+	s.loadProfiles()
+}
+
+// profileErrorKey 档案操作错误对应的本地化键
+func profileErrorKey(err error) string {
+	switch {
+	case errors.Is(err, progress.ErrProfileName):
+		return "profile.error_name"
+	case errors.Is(err, progress.ErrProfileExists):
+		return "profile.error_exists"
+	case errors.Is(err, progress.ErrLastProfile):
+		return "profile.error_last"
+	default:
+		return "profile.error_save"
+	}
+}
+
+// Draw 绘制档案管理场景
+func (s *ProfilesScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+	s.menuSystem.DrawProfiles(screen, menuState, s.view)
+}
+
+// getMenuState 获取菜单状态
+func (s *ProfilesScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsDone 是否返回主菜单
+func (s *ProfilesScene) IsDone() bool {
+	return s.done
+}
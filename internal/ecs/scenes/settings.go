@@ -0,0 +1,159 @@
+package scenes
+
+import (
+	"fmt"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// 设置项索引
+const (
+	settingControlMode = iota
+	settingPlayers
+	settingSharedLives
+	settingContribution
+	settingAssist
+	settingBack
+	settingCount
+)
+
+// SettingsScene 设置场景
+type SettingsScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	settings    progress.Settings
+	done        bool
+}
+
+// NewSettingsScene 创建设置场景
+func NewSettingsScene() *SettingsScene {
+	world := ecs.NewWorld()
+
+	scene := &SettingsScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+		settings:    progress.GetSettings(),
+	}
+
+	// 创建菜单状态
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: 0,
+		OptionCount:   settingCount,
+		Confirmed:     false,
+	})
+
+	return scene
+}
+
+// Update 更新设置场景
+func (s *SettingsScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return nil
+	}
+
+	// 左右直接切换当前设置项的值
+	if menuState.SelectedIndex != settingBack &&
+		(s.inputSystem.IsGMLeftPressed() || s.inputSystem.IsGMRightPressed()) {
+		s.toggle(menuState.SelectedIndex)
+		return nil
+	}
+
+	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	if menuState.Confirmed {
+		menuState.Confirmed = false
+		if menuState.SelectedIndex == settingBack {
+			s.done = true
+			return nil
+		}
+		s.toggle(menuState.SelectedIndex)
+	}
+
+	return nil
+}
+
+// toggle 切换设置项的值并保存
+func (s *SettingsScene) toggle(idx int) {
+	switch idx {
+	case settingControlMode:
+		if s.settings.ControlMode == progress.ControlModePointer {
+			s.settings.ControlMode = progress.ControlModeKeyboard
+		} else {
+			s.settings.ControlMode = progress.ControlModePointer
+		}
+	case settingPlayers:
+		if s.settings.Players == 2 {
+			s.settings.Players = 1
+		} else {
+			s.settings.Players = 2
+		}
+	case settingSharedLives:
+		s.settings.SharedLives = !s.settings.SharedLives
+	case settingContribution:
+		s.settings.ShowContribution = !s.settings.ShowContribution
+	case settingAssist:
+		s.settings.AdaptiveAssist = !s.settings.AdaptiveAssist
+	}
+	_ = progress.SaveSettings(s.settings)
+}
+
+// Draw 绘制设置场景
+func (s *SettingsScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+
+	items := []systems.SettingItem{
+		{Key: "settings.control_mode", Value: controlModeText(s.settings.ControlMode)},
+		{Key: "settings.players", Value: fmt.Sprintf("%dP", s.settings.Players)},
+		{Key: "settings.coop_lives", Value: choiceText(s.settings.SharedLives, "settings.shared", "settings.separate")},
+		{Key: "settings.coop_contribution", Value: choiceText(s.settings.ShowContribution, "settings.on", "settings.off")},
+		{Key: "settings.assist", Value: choiceText(s.settings.AdaptiveAssist, "settings.on", "settings.off")},
+		{Key: "settings.back"},
+	}
+	s.menuSystem.DrawSettings(screen, menuState, items)
+}
+
+// controlModeText 控制方式显示文本
+func controlModeText(mode string) string {
+	if mode == progress.ControlModePointer {
+		return i18n.T("settings.control.pointer")
+	}
+	return i18n.T("settings.control.keyboard")
+}
+
+// choiceText 二选一设置项的显示文本
+func choiceText(v bool, onKey, offKey string) string {
+	if v {
+		return i18n.T(onKey)
+	}
+	return i18n.T(offKey)
+}
+
+// getMenuState 获取菜单状态
+func (s *SettingsScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsDone 检查是否返回主菜单
+func (s *SettingsScene) IsDone() bool {
+	return s.done
+}
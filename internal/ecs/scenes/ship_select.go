@@ -4,6 +4,8 @@ import (
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/yohamta/donburi"
@@ -14,6 +16,7 @@ type ShipSelectScene struct {
 	world       *ecs.World
 	inputSystem *systems.InputSystem
 	menuSystem  *systems.MenuSystem
+	confirmed   bool
 }
 
 // NewShipSelectScene 创建战机选择场景
@@ -26,30 +29,66 @@ func NewShipSelectScene() *ShipSelectScene {
 		menuSystem:  systems.NewMenuSystem(),
 	}
 
-	// 创建战机模板
-	templates := []components.ShipTemplate{
-		{NameKey: "ship.alpha", Speed: 5.0, SizeScale: 1.0, Lives: 3, PassiveKey: "passive.none"},
-		{NameKey: "ship.beta", Speed: 6.5, SizeScale: 1.0, Lives: 3, PassiveKey: "passive.speed"},
-		{NameKey: "ship.gamma", Speed: 5.0, SizeScale: 0.8, Lives: 3, PassiveKey: "passive.small"},
-		{NameKey: "ship.delta", Speed: 5.0, SizeScale: 1.0, Lives: 4, PassiveKey: "passive.life"},
-	}
-
 	// 创建菜单状态
 	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
 	components.MenuState.Set(menuState, &components.MenuStateData{
-		SelectedIndex: 0,
-		OptionCount:   len(templates),
-		Confirmed:     false,
-		ShipTemplates: templates,
+		SelectedIndex:   0,
+		OptionCount:     len(ships.Ships),
+		Confirmed:       false,
+		ShipTemplates:   buildShipTemplates(),
+		AvailableMerits: progress.GetMerits(),
 	})
 
 	return scene
 }
 
+// buildShipTemplates 由战机定义与解锁进度生成选择列表
+func buildShipTemplates() []components.ShipTemplate {
+	templates := make([]components.ShipTemplate, 0, len(ships.Ships))
+	for _, ship := range ships.Ships {
+		templates = append(templates, components.ShipTemplate{
+			ID:                ship.ID,
+			NameKey:           ship.NameKey,
+			Speed:             ship.Speed,
+			SizeScale:         ship.SizeScale,
+			Lives:             ship.Lives,
+			PassiveKey:        ship.PassiveKey,
+			AbilityType:       ship.AbilityType,
+			Locked:            !ships.IsUnlocked(ship, progress.IsShipOwned, progress.HasAchievement),
+			UnlockMerits:      ship.Unlock.Merits,
+			UnlockAchievement: ship.Unlock.Achievement,
+		})
+	}
+	return templates
+}
+
 // Update 更新战机选择场景
 func (s *ShipSelectScene) Update() error {
 	s.inputSystem.Update(s.world.ECS.World)
 	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	state := s.getMenuState()
+	if state == nil || !state.Confirmed {
+		return nil
+	}
+
+	// 未解锁的战机：可用功勋购买的直接购买，否则不可选
+	state.Confirmed = false
+	if state.SelectedIndex >= len(state.ShipTemplates) {
+		return nil
+	}
+	t := &state.ShipTemplates[state.SelectedIndex]
+	if !t.Locked {
+		s.confirmed = true
+		return nil
+	}
+	if t.UnlockAchievement == "" && t.UnlockMerits > 0 {
+		if err := progress.BuyShip(t.ID, t.UnlockMerits); err != nil {
+			return nil
+		}
+		t.Locked = false
+		state.AvailableMerits = progress.GetMerits()
+	}
 	return nil
 }
 
@@ -58,31 +97,29 @@ func (s *ShipSelectScene) Draw(screen *ebiten.Image) {
 	s.menuSystem.DrawShipSelect(s.world.ECS.World, screen)
 }
 
-// IsConfirmed 检查是否已确认
-func (s *ShipSelectScene) IsConfirmed() bool {
-	var confirmed bool
+// getMenuState 获取菜单状态
+func (s *ShipSelectScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
 	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
-		state := components.MenuState.Get(entry)
-		confirmed = state.Confirmed
+		menuState = components.MenuState.Get(entry)
 	})
-	return confirmed
+	return menuState
+}
+
+// IsConfirmed 检查是否已确认
+func (s *ShipSelectScene) IsConfirmed() bool {
+	return s.confirmed
 }
 
 // GetOptions 获取玩家选项
 func (s *ShipSelectScene) GetOptions() PlayerOptions {
-	var opts PlayerOptions
-	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
-		state := components.MenuState.Get(entry)
-		if state.SelectedIndex < len(state.ShipTemplates) {
-			t := state.ShipTemplates[state.SelectedIndex]
-			opts = PlayerOptions{
-				Speed:      t.Speed,
-				SizeScale:  t.SizeScale,
-				Lives:      t.Lives,
-				PassiveKey: t.PassiveKey,
-			}
-		}
-	})
-	return opts
+	state := s.getMenuState()
+	if state == nil || state.SelectedIndex >= len(state.ShipTemplates) {
+		return OptionsForShip(ships.Default())
+	}
+	ship, ok := ships.ByID(state.ShipTemplates[state.SelectedIndex].ID)
+	if !ok {
+		ship = ships.Default()
+	}
+	return OptionsForShip(ship)
 }
-
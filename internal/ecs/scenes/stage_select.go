@@ -0,0 +1,118 @@
+package scenes
+
+import (
+	"spacebattle/internal/campaign"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// StageSelectScene 关卡选择场景（战役模式，位于出征与战斗之间）
+type StageSelectScene struct {
+	world         *ecs.World
+	inputSystem   *systems.InputSystem
+	menuSystem    *systems.MenuSystem
+	playerOptions PlayerOptions
+	rows          []systems.StageRow
+	confirmed     bool
+}
+
+// NewStageSelectScene 创建关卡选择场景
+func NewStageSelectScene(opts PlayerOptions) *StageSelectScene {
+	world := ecs.NewWorld()
+
+	scene := &StageSelectScene{
+		world:         world,
+		inputSystem:   systems.NewInputSystem(),
+		menuSystem:    systems.NewMenuSystem(),
+		playerOptions: opts,
+	}
+	scene.loadRows()
+
+	// 默认选中最后一个已解锁的关卡
+	selected := 0
+	for i, row := range scene.rows {
+		if !row.Locked {
+			selected = i
+		}
+	}
+
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: selected,
+		OptionCount:   len(scene.rows),
+		Confirmed:     false,
+	})
+
+	return scene
+}
+
+// loadRows 读取关卡解锁状态与最佳记录
+func (s *StageSelectScene) loadRows() {
+	s.rows = s.rows[:0]
+	for _, stage := range campaign.Stages {
+		row := systems.StageRow{
+			NameKey: stage.NameKey,
+			Locked:  !campaign.IsUnlocked(stage, progress.IsStageCleared),
+		}
+		if rec, ok := progress.GetStageRecord(stage.ID); ok {
+			row.BestGrade = rec.BestGrade
+			row.BestScore = rec.BestScore
+		}
+		s.rows = append(s.rows, row)
+	}
+}
+
+// Update 更新关卡选择场景
+func (s *StageSelectScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil || !menuState.Confirmed {
+		return nil
+	}
+
+	// 未解锁的关卡不可进入；选定关卡后才支付出征时选择的难度成本
+	menuState.Confirmed = false
+	if idx := menuState.SelectedIndex; idx >= 0 && idx < len(s.rows) && !s.rows[idx].Locked {
+		if err := chargeDifficulty(s.playerOptions); err != nil {
+			return nil
+		}
+		s.playerOptions.StageID = campaign.Stages[idx].ID
+		s.confirmed = true
+	}
+	return nil
+}
+
+// Draw 绘制关卡选择场景
+func (s *StageSelectScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+	s.menuSystem.DrawStageSelect(screen, menuState, s.rows)
+}
+
+// getMenuState 获取菜单状态
+func (s *StageSelectScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsConfirmed 检查是否已选定关卡
+func (s *StageSelectScene) IsConfirmed() bool {
+	return s.confirmed
+}
+
+// GetOptions 获取带关卡信息的玩家选项
+func (s *StageSelectScene) GetOptions() PlayerOptions {
+	return s.playerOptions
+}
@@ -0,0 +1,184 @@
+package scenes
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// 统计界面选项索引
+const (
+	statsExport = iota
+	statsAchievements
+	statsBack
+	statsOptionCount
+)
+
+// StatsScene 出击统计场景：汇总、各难度最佳与最近记录，可导出 CSV 或查看成就
+type StatsScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	view        systems.StatsView
+	done        bool
+	browse      bool // 打开成就列表
+}
+
+// NewStatsScene 创建统计场景
+func NewStatsScene() *StatsScene {
+	world := ecs.NewWorld()
+
+	scene := &StatsScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+	}
+	scene.loadView()
+
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex: 0,
+		OptionCount:   statsOptionCount,
+	})
+
+	return scene
+}
+
+// loadView 读取统计数据
+func (s *StatsScene) loadView() {
+	if st, err := progress.GetRunStats(); err == nil {
+		s.view.Runs = st.Runs
+		s.view.Victories = st.Victories
+		s.view.WinRate = st.WinRate()
+		s.view.Kills = st.Kills
+		s.view.BossKills = st.BossKills
+		s.view.Merits = st.Merits
+		s.view.PlayTime = st.PlayTime
+		s.view.FavoriteShipKey = shipNameKey(st.FavoriteShip)
+	}
+	if best, err := progress.BestRunsByDifficulty(); err == nil {
+		for _, r := range best {
+			s.view.Best = append(s.view.Best, statsRunRow(r))
+		}
+	}
+	if recent, err := progress.ListRuns(5); err == nil {
+		for _, r := range recent {
+			s.view.Recent = append(s.view.Recent, statsRunRow(r))
+		}
+	}
+}
+
+// statsRunRow 出击记录转为界面行
+func statsRunRow(r progress.Run) systems.StatsRunRow {
+	return systems.StatsRunRow{
+		Mode:       r.Mode,
+		ShipKey:    shipNameKey(r.ShipID),
+		Difficulty: r.Difficulty,
+		Score:      r.Score,
+		Grade:      r.Grade,
+		Victory:    r.Victory,
+		Elapsed:    r.Elapsed,
+	}
+}
+
+// shipNameKey 战机 ID 对应的本地化名称键
+func shipNameKey(id string) string {
+	if ship, ok := ships.ByID(id); ok {
+		return ship.NameKey
+	}
+	return id
+}
+
+// Update 更新统计场景
+func (s *StatsScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+	s.inputSystem.ProcessMenuInput(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil || !menuState.Confirmed {
+		return nil
+	}
+	menuState.Confirmed = false
+
+	switch menuState.SelectedIndex {
+	case statsExport:
+		s.exportCSV()
+	case statsAchievements:
+		s.browse = true
+	case statsBack:
+		s.done = true
+	}
+	return nil
+}
+
+// exportCSV 将当前档案的出击记录导出到工作目录
+func (s *StatsScene) exportCSV() {
+	name := fmt.Sprintf("runs_%s_%s.csv", fileSafe(progress.CurrentProfile().Name), time.Now().Format("20060102-150405"))
+	f, err := os.Create(name)
+	if err != nil {
+		s.view.Message, s.view.MessageError = err.Error(), true
+		return
+	}
+	err = progress.ExportRunsCSV(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		s.view.Message, s.view.MessageError = err.Error(), true
+		return
+	}
+	s.view.Message, s.view.MessageError = name, false
+}
+
+// fileSafe 将档案名转换为可用作文件名的字符串
+func fileSafe(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+	if safe == "" {
+		return "profile"
+	}
+	return safe
+}
+
+// Draw 绘制统计场景
+func (s *StatsScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+	s.menuSystem.DrawStats(screen, menuState, s.view)
+}
+
+// getMenuState 获取菜单状态
+func (s *StatsScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsDone 是否返回主菜单
+func (s *StatsScene) IsDone() bool {
+	return s.done
+}
+
+// IsBrowsingAchievements 是否打开成就列表
+func (s *StatsScene) IsBrowsingAchievements() bool {
+	return s.browse
+}
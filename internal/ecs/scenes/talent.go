@@ -0,0 +1,235 @@
+package scenes
+
+import (
+	"maps"
+	"math"
+
+	"spacebattle/internal/config"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/talent"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// TalentScene 天赋树场景（主菜单进入，功勋加点，可重置返还）
+type TalentScene struct {
+	world       *ecs.World
+	inputSystem *systems.InputSystem
+	menuSystem  *systems.MenuSystem
+	ranks       talent.Ranks
+	done        bool
+}
+
+// NewTalentScene 创建天赋树场景
+func NewTalentScene() *TalentScene {
+	world := ecs.NewWorld()
+
+	ranks, _ := progress.GetTalents()
+	scene := &TalentScene{
+		world:       world,
+		inputSystem: systems.NewInputSystem(),
+		menuSystem:  systems.NewMenuSystem(),
+		ranks:       talent.Ranks(ranks),
+	}
+
+	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
+	components.MenuState.Set(menuState, &components.MenuStateData{
+		SelectedIndex:   0,
+		OptionCount:     len(talent.Nodes),
+		AvailableMerits: progress.GetMerits(),
+	})
+
+	return scene
+}
+
+// Update 更新天赋树场景
+func (s *TalentScene) Update() error {
+	s.inputSystem.Update(s.world.ECS.World)
+
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return nil
+	}
+
+	// 方向键沿天赋图移动到相邻节点
+	switch {
+	case s.inputSystem.IsGMUpPressed():
+		menuState.SelectedIndex = neighborNode(menuState.SelectedIndex, 0, -1)
+	case s.inputSystem.IsGMDownPressed():
+		menuState.SelectedIndex = neighborNode(menuState.SelectedIndex, 0, 1)
+	case s.inputSystem.IsGMLeftPressed():
+		menuState.SelectedIndex = neighborNode(menuState.SelectedIndex, -1, 0)
+	case s.inputSystem.IsGMRightPressed():
+		menuState.SelectedIndex = neighborNode(menuState.SelectedIndex, 1, 0)
+	}
+
+	if s.inputSystem.IsConfirmed() {
+		s.rankUp(menuState)
+	}
+	if s.inputSystem.IsRestartPressed() {
+		s.respec(menuState)
+	}
+
+	// 点击/触摸：点击节点选中，再次点击已选中节点加点；底部按钮重置与返回
+	switch action, idx := s.inputSystem.MenuTap(menuState); action {
+	case systems.MenuTapItem:
+		if idx == menuState.SelectedIndex {
+			s.rankUp(menuState)
+		} else {
+			menuState.SelectedIndex = idx
+		}
+	case systems.MenuTapPrev:
+		s.respec(menuState)
+	case systems.MenuTapConfirm:
+		s.done = true
+	}
+	return nil
+}
+
+// rankUp 为选中节点升一级
+func (s *TalentScene) rankUp(menuState *components.MenuStateData) {
+	node := talent.Nodes[menuState.SelectedIndex]
+	cost, err := s.ranks.NextCost(node.ID)
+	if err != nil {
+		return
+	}
+	next := maps.Clone(s.ranks)
+	next[node.ID]++
+	// 扣款与保存在同一事务中，失败时等级与功勋都保持不变
+	if err := progress.PurchaseTalent(node.ID, cost, next); err != nil {
+		return
+	}
+	s.ranks = next
+	menuState.AvailableMerits = progress.GetMerits()
+}
+
+// respec 清空全部天赋并全额返还功勋
+func (s *TalentScene) respec(menuState *components.MenuStateData) {
+	refund := s.ranks.Spent()
+	if refund == 0 {
+		return
+	}
+	// 清空与返还在同一事务中，失败时保留原有天赋
+	if err := progress.ResetTalents(refund); err != nil {
+		return
+	}
+	s.ranks = make(talent.Ranks)
+	menuState.AvailableMerits = progress.GetMerits()
+}
+
+// neighborNode 返回从 idx 沿 (dc, dr) 方向最近的节点，没有则保持不动
+func neighborNode(idx, dc, dr int) int {
+	from := talent.Nodes[idx]
+	best, bestDist := idx, math.MaxInt
+	for i, n := range talent.Nodes {
+		dCol, dRow := n.Col-from.Col, n.Row-from.Row
+		// 只考虑目标方向上的节点
+		if dc != 0 && dCol*dc <= 0 || dr != 0 && (dRow*dr <= 0 || dCol != 0) {
+			continue
+		}
+		// 主方向距离优先，其次偏移
+		dist := abs(dCol)*10 + abs(dRow)
+		if dr != 0 {
+			dist = abs(dRow)
+		}
+		if dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Draw 绘制天赋树场景
+func (s *TalentScene) Draw(screen *ebiten.Image) {
+	menuState := s.getMenuState()
+	if menuState == nil {
+		return
+	}
+
+	index := make(map[string]int, len(talent.Nodes))
+	for i, n := range talent.Nodes {
+		index[n.ID] = i
+	}
+	views := make([]systems.TalentNodeView, len(talent.Nodes))
+	for i, n := range talent.Nodes {
+		view := systems.TalentNodeView{
+			NameKey: n.NameKey,
+			Col:     n.Col,
+			Row:     n.Row,
+			Rank:    s.ranks[n.ID],
+			MaxRank: n.MaxRank(),
+			Locked:  !s.ranks.Unlocked(n),
+		}
+		if cost, err := s.ranks.NextCost(n.ID); err == nil {
+			view.Cost = cost
+		}
+		for _, p := range n.Prereqs {
+			view.Prereqs = append(view.Prereqs, index[p])
+		}
+		for _, e := range n.Effects {
+			view.Effects = append(view.Effects, systems.TalentEffectView{Stat: string(e.Stat), PerRank: e.PerRank})
+		}
+		views[i] = view
+	}
+	s.menuSystem.DrawTalentTree(screen, menuState, views, s.ranks.Spent())
+}
+
+// getMenuState 获取菜单状态
+func (s *TalentScene) getMenuState() *components.MenuStateData {
+	var menuState *components.MenuStateData
+	components.MenuState.Each(s.world.ECS.World, func(entry *donburi.Entry) {
+		menuState = components.MenuState.Get(entry)
+	})
+	return menuState
+}
+
+// IsDone 是否返回主菜单
+func (s *TalentScene) IsDone() bool {
+	return s.done
+}
+
+// loadTalentBonuses 读取天赋加成；每日挑战使用固定配置，不计天赋
+func loadTalentBonuses(mode components.GameMode) map[talent.Stat]float64 {
+	if mode == components.ModeDaily {
+		return nil
+	}
+	ranks, err := progress.GetTalents()
+	if err != nil {
+		return nil
+	}
+	return talent.Ranks(ranks).Bonuses()
+}
+
+// applyTalentOptions 将天赋加成叠加到玩家选项
+func applyTalentOptions(opts *PlayerOptions, bonuses map[talent.Stat]float64) {
+	cfg := config.DefaultConfig()
+	opts.Speed += bonuses[talent.StatSpeed]
+	opts.Lives = min(opts.Lives+int(bonuses[talent.StatLives]), cfg.MaxLives)
+	opts.SizeScale *= 1 + bonuses[talent.StatSizeScale]
+}
+
+// applyTalentFire 将天赋加成叠加到射击技能，受全局上限约束
+func applyTalentFire(fire *components.FireSkillData, bonuses map[talent.Stat]float64) {
+	cfg := config.DefaultConfig()
+	fire.FireRateHz = math.Min(fire.FireRateHz+bonuses[talent.StatFireRate], cfg.MaxFireRateHz)
+	fire.BulletsPerShot = min(fire.BulletsPerShot+int(bonuses[talent.StatBulletsPerShot]), cfg.MaxBulletsPerShot)
+	fire.PenetrationCount = min(fire.PenetrationCount+int(bonuses[talent.StatPenetration]), cfg.MaxPenetration)
+	fire.BulletSpeed = math.Min(fire.BulletSpeed+bonuses[talent.StatBulletSpeed], cfg.MaxBulletSpeed)
+	fire.BulletDamage += int(bonuses[talent.StatBulletDamage])
+	fire.BurstChance = math.Min(fire.BurstChance+bonuses[talent.StatBurstChance], cfg.MaxBurstChance)
+	fire.HomingTurnRateRad = math.Min(fire.HomingTurnRateRad+bonuses[talent.StatHomingTurn], cfg.MaxTurnRateRad)
+	if bonuses[talent.StatHoming] > 0 {
+		fire.EnableHoming = true
+	}
+}
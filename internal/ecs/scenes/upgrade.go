@@ -1,14 +1,20 @@
 package scenes
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
-	"spacebattle/internal/config"
+	"spacebattle/internal/achievements"
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/systems"
 	"spacebattle/internal/i18n"
 	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
+	"spacebattle/internal/upgrades"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/yohamta/donburi"
@@ -21,23 +27,31 @@ type UpgradeScene struct {
 	menuSystem    *systems.MenuSystem
 	playerOptions PlayerOptions
 	selectedIndex int
+	messageKey    string // 底部提示（套用方案、撤销的结果）
+	messageError  bool
+	// 加点方案面板
+	presetsOpen bool
+	presets     []progress.UpgradePreset
+	presetState components.MenuStateData
+	presetView  systems.UpgradePresetsView
+	// 功勋流水面板
+	historyOpen bool
+	history     systems.MeritHistoryView
 }
 
 // NewUpgradeScene 创建升级场景
 func NewUpgradeScene(opts PlayerOptions) *UpgradeScene {
 	world := ecs.NewWorld()
 
-	// 预填上次保存的加点
-	if last, err := progress.GetUpgrades(); err == nil {
-		opts.ModFireRateHz = last.ModFireRateHz
-		opts.ModBulletsPerShot = last.ModBulletsPerShot
-		opts.ModPenetration = last.ModPenetration
-		opts.ModSpreadDeltaDeg = last.ModSpreadDeltaDeg
-		opts.ModBulletSpeed = last.ModBulletSpeed
-		opts.ModBulletDamage = last.ModBulletDamage
-		opts.ModBurstChance = last.ModBurstChance
-		opts.ModEnableHoming = last.ModEnableHoming
-		opts.ModTurnRateRad = last.ModTurnRateRad
+	// 预填该战机上次保存的加点
+	if opts.ShipID == "" {
+		opts.ShipID = ships.Default().ID
+	}
+	if last, err := progress.GetShipUpgrades(opts.ShipID); err == nil {
+		opts.ApplyUpgrades(last)
+	}
+	if opts.UpgradeLevels == nil {
+		opts.UpgradeLevels = upgrades.Levels{}
 	}
 
 	scene := &UpgradeScene{
@@ -55,7 +69,7 @@ func NewUpgradeScene(opts PlayerOptions) *UpgradeScene {
 	menuState := world.ECS.World.Entry(world.ECS.World.Create(components.MenuState))
 	components.MenuState.Set(menuState, &components.MenuStateData{
 		SelectedIndex:   0,
-		OptionCount:     9, // 9 个升级选项
+		OptionCount:     len(scene.upgrades()),
 		Confirmed:       false,
 		AvailableMerits: merits,
 		Upgrades:        make(map[string]int),
@@ -64,6 +78,11 @@ func NewUpgradeScene(opts PlayerOptions) *UpgradeScene {
 	return scene
 }
 
+// upgrades 界面列出的升级项（由升级定义生成）
+func (s *UpgradeScene) upgrades() []upgrades.Upgrade {
+	return s.playerOptions.UpgradeLevels.Visible()
+}
+
 // Update 更新升级场景
 func (s *UpgradeScene) Update() error {
 	s.inputSystem.Update(s.world.ECS.World)
@@ -78,58 +97,141 @@ func (s *UpgradeScene) Update() error {
 		return nil
 	}
 
+	// 加点方案：P 打开面板，数字键直接套用对应方案
+	if s.presetsOpen {
+		s.updatePresets(menuState)
+		return nil
+	}
+	if s.historyOpen {
+		s.updateHistory()
+		return nil
+	}
+	if s.inputSystem.IsPresetsPressed() {
+		s.openPresets()
+		return nil
+	}
+	if n := s.inputSystem.PresetHotkey(); n > 0 {
+		s.applyPresetHotkey(menuState, n)
+	}
+
+	// 功勋流水：H 查看，U 撤销最近一次加点
+	if s.inputSystem.IsHistoryPressed() {
+		s.openHistory()
+		return nil
+	}
+	if s.inputSystem.IsUndoPressed() {
+		s.undoLastPurchase(menuState)
+	}
+
 	// 上下选择
 	if s.inputSystem.IsGMUpPressed() {
 		menuState.SelectedIndex--
 		if menuState.SelectedIndex < 0 {
-			menuState.SelectedIndex = 8
+			menuState.SelectedIndex = menuState.OptionCount - 1
 		}
 	}
 	if s.inputSystem.IsGMDownPressed() {
 		menuState.SelectedIndex++
-		if menuState.SelectedIndex > 8 {
+		if menuState.SelectedIndex >= menuState.OptionCount {
 			menuState.SelectedIndex = 0
 		}
 	}
 
 	// 左右加点/减点
 	if s.inputSystem.IsGMRightPressed() {
-		if s.canIncrease(menuState.SelectedIndex) {
-			cost := s.nextCost(menuState.SelectedIndex)
-			if cost > 0 && progress.SpendMerits(cost) {
-				s.increase(menuState.SelectedIndex)
-				menuState.AvailableMerits = progress.GetMerits()
-			}
-		}
+		s.tryIncrease(menuState)
 	}
 	if s.inputSystem.IsGMLeftPressed() {
-		refund := s.refundCost(menuState.SelectedIndex)
-		if s.decrease(menuState.SelectedIndex) && refund > 0 {
-			progress.AddMerits(refund)
-			menuState.AvailableMerits = progress.GetMerits()
+		s.tryDecrease(menuState)
+	}
+
+	// 点击/触摸：点击选项选中，再次点击已选中的选项加点
+	tapConfirmed := false
+	switch action, idx := s.inputSystem.MenuTap(menuState); action {
+	case systems.MenuTapItem:
+		if idx == menuState.SelectedIndex {
+			s.tryIncrease(menuState)
+		} else {
+			menuState.SelectedIndex = idx
 		}
+	case systems.MenuTapConfirm:
+		tapConfirmed = true
 	}
 
-	// Enter 确认
-	if s.inputSystem.IsConfirmed() {
-		// 保存升级数据
-		_ = progress.SaveUpgrades(progress.UpgradeData{
-			ModFireRateHz:     s.playerOptions.ModFireRateHz,
-			ModBulletsPerShot: s.playerOptions.ModBulletsPerShot,
-			ModPenetration:    s.playerOptions.ModPenetration,
-			ModSpreadDeltaDeg: s.playerOptions.ModSpreadDeltaDeg,
-			ModBulletSpeed:    s.playerOptions.ModBulletSpeed,
-			ModBulletDamage:   s.playerOptions.ModBulletDamage,
-			ModBurstChance:    s.playerOptions.ModBurstChance,
-			ModEnableHoming:   s.playerOptions.ModEnableHoming,
-			ModTurnRateRad:    s.playerOptions.ModTurnRateRad,
-		})
+	// Enter 确认（加点与减点已随记账逐笔保存）
+	if s.inputSystem.IsConfirmed() || tapConfirmed {
 		menuState.Confirmed = true
 	}
 
 	return nil
 }
 
+// selected 当前选中的升级项
+func (s *UpgradeScene) selected(menuState *components.MenuStateData) (upgrades.Upgrade, bool) {
+	list := s.upgrades()
+	if menuState.SelectedIndex < 0 || menuState.SelectedIndex >= len(list) {
+		return upgrades.Upgrade{}, false
+	}
+	return list[menuState.SelectedIndex], true
+}
+
+// tryIncrease 尝试为当前选中项加点
+func (s *UpgradeScene) tryIncrease(menuState *components.MenuStateData) {
+	u, ok := s.selected(menuState)
+	if !ok {
+		return
+	}
+	levels := s.playerOptions.UpgradeLevels
+	cost, err := levels.NextCost(u.Key)
+	if err != nil {
+		return
+	}
+	if cost <= 0 {
+		return
+	}
+	next := maps.Clone(levels)
+	next[u.Key]++
+	if err := progress.PurchaseUpgrade(s.playerOptions.ShipID, u.Key, cost, progress.UpgradeData(next)); err != nil {
+		if !errors.Is(err, progress.ErrInsufficientMerits) {
+			s.setMessage("ledger.error_save", true)
+		}
+		return
+	}
+	levels[u.Key]++
+	menuState.AvailableMerits = progress.GetMerits()
+	_, err = levels.NextCost(u.Key)
+	achievements.Publish(achievements.Event{
+		Kind:    achievements.EventUpgradePurchased,
+		Ship:    s.playerOptions.ShipID,
+		Upgrade: u.Key,
+		Maxed:   err == upgrades.ErrMaxLevel,
+	})
+}
+
+// tryDecrease 尝试为当前选中项减点并退还功勋
+func (s *UpgradeScene) tryDecrease(menuState *components.MenuStateData) {
+	u, ok := s.selected(menuState)
+	if !ok {
+		return
+	}
+	levels := s.playerOptions.UpgradeLevels
+	refund, ok := levels.Refund(u.Key)
+	if !ok {
+		return
+	}
+	next := maps.Clone(levels)
+	next[u.Key]--
+	if err := progress.RefundUpgrade(s.playerOptions.ShipID, u.Key, refund, progress.UpgradeData(next)); err != nil {
+		s.setMessage("ledger.error_save", true)
+		return
+	}
+	levels[u.Key]--
+	menuState.AvailableMerits = progress.GetMerits()
+	// 关闭购买的升级退完后从列表中消失
+	menuState.OptionCount = len(s.upgrades())
+	menuState.SelectedIndex = min(menuState.SelectedIndex, menuState.OptionCount-1)
+}
+
 // Draw 绘制升级场景
 func (s *UpgradeScene) Draw(screen *ebiten.Image) {
 	// 获取菜单状态
@@ -142,68 +244,65 @@ func (s *UpgradeScene) Draw(screen *ebiten.Image) {
 		return
 	}
 
+	if s.presetsOpen {
+		s.menuSystem.DrawUpgradePresets(screen, &s.presetState, s.presetView)
+		systems.DrawAchievementToasts(screen, achievements.Toasts(time.Now()))
+		return
+	}
+	if s.historyOpen {
+		s.menuSystem.DrawMeritHistory(screen, s.history)
+		return
+	}
+
 	// 准备升级项信息
 	items := s.buildUpgradeItems()
 
 	// 使用详细渲染
 	s.menuSystem.DrawUpgradeWithDetails(screen, menuState, items)
+	s.menuSystem.DrawUpgradeStatus(screen, s.messageKey, s.messageError)
+
+	// 绘制成就达成提示
+	systems.DrawAchievementToasts(screen, achievements.Toasts(time.Now()))
 }
 
 // buildUpgradeItems 构建升级项列表
 func (s *UpgradeScene) buildUpgradeItems() []systems.UpgradeItem {
-	items := []systems.UpgradeItem{
-		{
-			Key:   "upgrade.fire_rate",
-			Value: formatFloat(s.playerOptions.ModFireRateHz, 1),
-			Cost:  s.nextCost(0),
-		},
-		{
-			Key:   "upgrade.bullets_per_shot",
-			Value: formatInt(s.playerOptions.ModBulletsPerShot),
-			Cost:  s.nextCost(1),
-		},
-		{
-			Key:   "upgrade.penetration",
-			Value: formatInt(s.playerOptions.ModPenetration),
-			Cost:  s.nextCost(2),
-		},
-		{
-			Key:   "upgrade.spread_narrow",
-			Value: formatFloat(s.playerOptions.ModSpreadDeltaDeg, 0),
-			Cost:  s.nextCost(3),
-		},
-		{
-			Key:   "upgrade.bullet_speed",
-			Value: formatFloat(s.playerOptions.ModBulletSpeed, 1),
-			Cost:  s.nextCost(4),
-		},
-		{
-			Key:   "upgrade.bullet_damage",
-			Value: formatInt(s.playerOptions.ModBulletDamage),
-			Cost:  s.nextCost(5),
-		},
-		{
-			Key:   "upgrade.burst_chance",
-			Value: formatFloat(s.playerOptions.ModBurstChance, 2),
-			Cost:  s.nextCost(6),
-		},
-		{
-			Key:   "upgrade.enable_homing",
-			Value: formatBool(s.playerOptions.ModEnableHoming),
-			Cost:  s.nextCost(7),
-		},
-		{
-			Key:   "upgrade.turn_rate",
-			Value: formatFloat(s.playerOptions.ModTurnRateRad, 2),
-			Cost:  s.nextCost(8),
-		},
+	levels := s.playerOptions.UpgradeLevels
+	list := s.upgrades()
+	items := make([]systems.UpgradeItem, 0, len(list))
+	for _, u := range list {
+		item := systems.UpgradeItem{
+			Key:   u.NameKey,
+			Value: formatBonus(u, levels[u.Key]),
+			Cost:  u.Cost(levels[u.Key]),
+		}
+		if _, err := levels.NextCost(u.Key); err == upgrades.ErrLocked {
+			item.Note = fmt.Sprintf(i18n.T("upgrade.requires"), requiredNames(u))
+		} else if err == upgrades.ErrDisabled {
+			item.Note = i18n.T("upgrade.unavailable")
+		}
+		items = append(items, item)
 	}
 	return items
 }
 
-// 格式化辅助函数
-func formatInt(v int) string {
-	return fmt.Sprintf("%d", v)
+// formatBonus 按升级定义的精度显示当前加成，一次性开关显示开/关
+func formatBonus(u upgrades.Upgrade, level int) string {
+	if u.Stat == upgrades.StatHoming {
+		return formatBool(level > 0)
+	}
+	return formatFloat(u.Bonus(level), u.Precision)
+}
+
+// requiredNames 前置升级的显示名称
+func requiredNames(u upgrades.Upgrade) string {
+	names := make([]string, 0, len(u.Requires))
+	for _, key := range u.Requires {
+		if req, ok := upgrades.ByKey(key); ok {
+			names = append(names, i18n.T(req.NameKey))
+		}
+	}
+	return strings.Join(names, ", ")
 }
 
 func formatFloat(v float64, prec int) string {
@@ -231,181 +330,3 @@ func (s *UpgradeScene) IsConfirmed() bool {
 func (s *UpgradeScene) GetOptions() PlayerOptions {
 	return s.playerOptions
 }
-
-// 计算当前等级
-func (s *UpgradeScene) levelOf(idx int) int {
-	switch idx {
-	case 0:
-		return int(s.playerOptions.ModFireRateHz / 0.5)
-	case 1:
-		return s.playerOptions.ModBulletsPerShot
-	case 2:
-		return s.playerOptions.ModPenetration
-	case 3:
-		v := s.playerOptions.ModSpreadDeltaDeg
-		if v < 0 {
-			v = -v
-		}
-		return int(v / 5)
-	case 4:
-		return int(s.playerOptions.ModBulletSpeed / 0.5)
-	case 5:
-		return s.playerOptions.ModBulletDamage
-	case 6:
-		return int(s.playerOptions.ModBurstChance / 0.05)
-	case 7:
-		if s.playerOptions.ModEnableHoming {
-			return 1
-		}
-		return 0
-	case 8:
-		return int(s.playerOptions.ModTurnRateRad / 0.02)
-	default:
-		return 0
-	}
-}
-
-// 计算下一级成本
-func (s *UpgradeScene) nextCost(idx int) int {
-	cfg := config.DefaultConfig()
-	bases := []int{
-		cfg.UpgradeCostFireRate,
-		cfg.UpgradeCostBulletsPerShot,
-		cfg.UpgradeCostPenetration,
-		cfg.UpgradeCostSpreadNarrow,
-		cfg.UpgradeCostBulletSpeed,
-		cfg.UpgradeCostBulletDamage,
-		cfg.UpgradeCostBurstChance,
-		cfg.UpgradeCostEnableHoming,
-		cfg.UpgradeCostTurnRate,
-	}
-	level := s.levelOf(idx)
-	if level < 0 {
-		level = 0
-	}
-	return bases[idx] * (1 << level)
-}
-
-// 计算退款金额
-func (s *UpgradeScene) refundCost(idx int) int {
-	cfg := config.DefaultConfig()
-	bases := []int{
-		cfg.UpgradeCostFireRate,
-		cfg.UpgradeCostBulletsPerShot,
-		cfg.UpgradeCostPenetration,
-		cfg.UpgradeCostSpreadNarrow,
-		cfg.UpgradeCostBulletSpeed,
-		cfg.UpgradeCostBulletDamage,
-		cfg.UpgradeCostBurstChance,
-		cfg.UpgradeCostEnableHoming,
-		cfg.UpgradeCostTurnRate,
-	}
-	level := s.levelOf(idx) - 1
-	if level < 0 {
-		return 0
-	}
-	return bases[idx] * (1 << level)
-}
-
-// 增加属性
-func (s *UpgradeScene) increase(idx int) {
-	switch idx {
-	case 0:
-		s.playerOptions.ModFireRateHz += 0.5
-	case 1:
-		s.playerOptions.ModBulletsPerShot++
-	case 2:
-		s.playerOptions.ModPenetration++
-	case 3:
-		s.playerOptions.ModSpreadDeltaDeg += 5
-	case 4:
-		s.playerOptions.ModBulletSpeed += 0.5
-	case 5:
-		s.playerOptions.ModBulletDamage++
-	case 6:
-		s.playerOptions.ModBurstChance += 0.05
-	case 7:
-		s.playerOptions.ModEnableHoming = true
-	case 8:
-		s.playerOptions.ModTurnRateRad += 0.02
-	}
-}
-
-// 减少属性
-func (s *UpgradeScene) decrease(idx int) bool {
-	switch idx {
-	case 0:
-		if s.playerOptions.ModFireRateHz >= 0.5 {
-			s.playerOptions.ModFireRateHz -= 0.5
-			return true
-		}
-	case 1:
-		if s.playerOptions.ModBulletsPerShot > 0 {
-			s.playerOptions.ModBulletsPerShot--
-			return true
-		}
-	case 2:
-		if s.playerOptions.ModPenetration > 0 {
-			s.playerOptions.ModPenetration--
-			return true
-		}
-	case 3:
-		if s.playerOptions.ModSpreadDeltaDeg >= 5 {
-			s.playerOptions.ModSpreadDeltaDeg -= 5
-			return true
-		}
-	case 4:
-		if s.playerOptions.ModBulletSpeed >= 0.5 {
-			s.playerOptions.ModBulletSpeed -= 0.5
-			return true
-		}
-	case 5:
-		if s.playerOptions.ModBulletDamage > 0 {
-			s.playerOptions.ModBulletDamage--
-			return true
-		}
-	case 6:
-		if s.playerOptions.ModBurstChance >= 0.05 {
-			s.playerOptions.ModBurstChance -= 0.05
-			return true
-		}
-	case 7:
-		if s.playerOptions.ModEnableHoming {
-			s.playerOptions.ModEnableHoming = false
-			return true
-		}
-	case 8:
-		if s.playerOptions.ModTurnRateRad >= 0.02 {
-			s.playerOptions.ModTurnRateRad -= 0.02
-			return true
-		}
-	}
-	return false
-}
-
-// 检查是否可以增加
-func (s *UpgradeScene) canIncrease(idx int) bool {
-	cfg := config.DefaultConfig()
-	switch idx {
-	case 0:
-		return (5.0 + s.playerOptions.ModFireRateHz + 0.5) <= cfg.MaxFireRateHz
-	case 1:
-		return (1 + s.playerOptions.ModBulletsPerShot + 1) <= cfg.MaxBulletsPerShot
-	case 2:
-		return (0 + s.playerOptions.ModPenetration + 1) <= cfg.MaxPenetration
-	case 3:
-		return (2 + s.playerOptions.ModSpreadDeltaDeg + 5) <= cfg.MaxSpreadDeg
-	case 4:
-		return (8.0 + s.playerOptions.ModBulletSpeed + 0.5) <= cfg.MaxBulletSpeed
-	case 5:
-		return true // 伤害没有上限
-	case 6:
-		return (0.0 + s.playerOptions.ModBurstChance + 0.05) <= cfg.MaxBurstChance
-	case 7:
-		return !s.playerOptions.ModEnableHoming
-	case 8:
-		return (0.01 + s.playerOptions.ModTurnRateRad + 0.02) <= cfg.MaxTurnRateRad
-	default:
-		return true
-	}
-}
@@ -0,0 +1,90 @@
+package scenes
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/upgrades"
+)
+
+// maxHistoryRows 功勋流水面板显示的记录数
+const maxHistoryRows = 15
+
+// openHistory 打开功勋流水面板
+func (s *UpgradeScene) openHistory() {
+	s.historyOpen = true
+	entries, _ := progress.MeritHistory(maxHistoryRows)
+	undone := make(map[int64]bool)
+	for _, e := range entries {
+		if e.Reverses > 0 {
+			undone[e.Reverses] = true
+		}
+	}
+	view := systems.MeritHistoryView{Merits: progress.GetMerits()}
+	for _, e := range entries {
+		view.Rows = append(view.Rows, systems.MeritHistoryRow{
+			Time:      e.CreatedAt,
+			SourceKey: "ledger.source." + string(e.Source),
+			Detail:    meritDetail(e),
+			Amount:    e.Amount,
+			Balance:   e.Balance,
+			Undone:    undone[e.ID],
+		})
+	}
+	_, err := progress.AuditMerits()
+	view.Mismatch = errors.Is(err, progress.ErrLedgerMismatch)
+	s.history = view
+}
+
+// updateHistory 功勋流水面板：ESC、回车或再次按 H 关闭
+func (s *UpgradeScene) updateHistory() {
+	if s.inputSystem.IsEscapePressed() || s.inputSystem.IsConfirmed() || s.inputSystem.IsHistoryPressed() {
+		s.historyOpen = false
+	}
+}
+
+// meritDetail 流水记录关联对象的显示文本：升级显示名称，其余原样显示
+func meritDetail(e progress.MeritEntry) string {
+	if _, key, ok := strings.Cut(e.Ref, "/"); ok {
+		if u, found := upgrades.ByKey(key); found {
+			return i18n.T(u.NameKey)
+		}
+	}
+	return e.Ref
+}
+
+// undoLastPurchase 撤销最近一次加点：流水中最近一笔仍是本战机的加点时追加反向记录并降一级；
+// 之后有出征、退还等其他记账时不能撤销
+func (s *UpgradeScene) undoLastPurchase(menuState *components.MenuStateData) {
+	e, ok, err := progress.LastMeritEntry()
+	if err != nil || !ok || e.Source != progress.SourceUpgradePurchase {
+		s.setMessage("ledger.nothing_to_undo", true)
+		return
+	}
+	key, found := strings.CutPrefix(e.Ref, s.playerOptions.ShipID+"/")
+	levels := s.playerOptions.UpgradeLevels
+	if _, canRefund := levels.Refund(key); !found || !canRefund {
+		s.setMessage("ledger.nothing_to_undo", true)
+		return
+	}
+	next := maps.Clone(levels)
+	next[key]--
+	if err := progress.UndoUpgradePurchase(e, s.playerOptions.ShipID, progress.UpgradeData(next)); err != nil {
+		if errors.Is(err, progress.ErrNothingToUndo) {
+			s.setMessage("ledger.nothing_to_undo", true)
+		} else {
+			s.setMessage("ledger.error_save", true)
+		}
+		return
+	}
+	levels[key]--
+	menuState.AvailableMerits = progress.GetMerits()
+	menuState.OptionCount = len(s.upgrades())
+	menuState.SelectedIndex = min(menuState.SelectedIndex, menuState.OptionCount-1)
+	s.setMessage("ledger.undone", false)
+}
@@ -0,0 +1,245 @@
+package scenes
+
+import (
+	"errors"
+
+	"spacebattle/internal/achievements"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/systems"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/upgrades"
+)
+
+// maxUpgradePresets 每个档案最多保存的加点方案数（对应数字键 1-9）
+const maxUpgradePresets = 9
+
+// openPresets 打开加点方案面板
+func (s *UpgradeScene) openPresets() {
+	s.presetsOpen = true
+	s.presetState.SelectedIndex = 0
+	s.presetView = systems.UpgradePresetsView{}
+	s.loadPresets()
+}
+
+// loadPresets 读取加点方案并计算切换到各方案的净花费
+func (s *UpgradeScene) loadPresets() {
+	s.presets, _ = progress.UpgradePresets()
+	current := s.playerOptions.UpgradeLevels
+	s.presetView.Rows = s.presetView.Rows[:0]
+	for _, p := range s.presets {
+		row := systems.UpgradePresetRow{Name: p.Name}
+		if change, err := upgrades.Respec(current, upgrades.Levels(p.Upgrades)); err == nil {
+			row.Net = change.Cost - change.Refund
+			row.Valid = true
+		}
+		s.presetView.Rows = append(s.presetView.Rows, row)
+	}
+	s.presetView.Code = current.Code()
+	s.presetState.OptionCount = len(s.presets) + 3 // 方案列表、保存、导入、返回
+	s.presetState.SelectedIndex = min(s.presetState.SelectedIndex, s.presetState.OptionCount-1)
+}
+
+// updatePresets 加点方案面板：回车套用选中方案，末尾三项为保存当前加点、导入加点码与返回
+func (s *UpgradeScene) updatePresets(menuState *components.MenuStateData) {
+	s.presetState.AvailableMerits = menuState.AvailableMerits
+	if s.presetView.Editing {
+		s.updatePresetEditing(menuState)
+		return
+	}
+
+	state := &s.presetState
+	if s.inputSystem.IsGMUpPressed() {
+		s.presetView.ConfirmDelete = false
+		state.SelectedIndex = (state.SelectedIndex + state.OptionCount - 1) % state.OptionCount
+	}
+	if s.inputSystem.IsGMDownPressed() {
+		s.presetView.ConfirmDelete = false
+		state.SelectedIndex = (state.SelectedIndex + 1) % state.OptionCount
+	}
+	if s.inputSystem.IsEscapePressed() {
+		s.presetsOpen = false
+		return
+	}
+	if n := s.inputSystem.PresetHotkey(); n > 0 && n <= len(s.presets) {
+		state.SelectedIndex = n - 1
+	}
+
+	activate := s.inputSystem.IsConfirmed()
+	remove := s.inputSystem.IsDeletePressed()
+	switch action, idx := s.inputSystem.MenuTap(state); action {
+	case systems.MenuTapItem:
+		if idx == state.SelectedIndex {
+			activate = true
+		} else {
+			s.presetView.ConfirmDelete = false
+			state.SelectedIndex = idx
+		}
+	}
+
+	idx := state.SelectedIndex
+	switch {
+	case activate && idx < len(s.presets):
+		key, ok := s.applyBuild(menuState, upgrades.Levels(s.presets[idx].Upgrades))
+		s.presetMessage(key, !ok)
+		if ok {
+			s.presetsOpen = false
+		}
+	case activate && idx == len(s.presets):
+		s.startPresetEditing(false)
+	case activate && idx == len(s.presets)+1:
+		s.startPresetEditing(true)
+	case activate:
+		s.presetsOpen = false
+	case remove && idx < len(s.presets):
+		// 删除需再次确认
+		if !s.presetView.ConfirmDelete {
+			s.presetView.ConfirmDelete = true
+			return
+		}
+		s.presetView.ConfirmDelete = false
+		if err := progress.DeleteUpgradePreset(s.presets[idx].Name); err != nil {
+			s.presetMessage("preset.error_save", true)
+		} else {
+			s.presetMessage("preset.deleted", false)
+		}
+		s.loadPresets()
+	}
+}
+
+// startPresetEditing 进入输入状态：方案名称或加点码
+func (s *UpgradeScene) startPresetEditing(importing bool) {
+	s.presetView.Editing = true
+	s.presetView.Importing = importing
+	s.presetView.EditText = ""
+	s.presetView.ConfirmDelete = false
+	s.presetMessage("", false)
+}
+
+// updatePresetEditing 处理输入：字符追加、退格删除、回车提交、ESC 取消
+func (s *UpgradeScene) updatePresetEditing(menuState *components.MenuStateData) {
+	s.presetView.EditText += string(s.inputSystem.TypedChars())
+	if s.inputSystem.IsBackspacePressed() && s.presetView.EditText != "" {
+		runes := []rune(s.presetView.EditText)
+		s.presetView.EditText = string(runes[:len(runes)-1])
+	}
+	if s.inputSystem.IsEscapePressed() {
+		s.presetView.Editing = false
+		return
+	}
+	if !s.inputSystem.IsConfirmed() {
+		return
+	}
+
+	if s.presetView.Importing {
+		target, err := upgrades.ParseCode(s.presetView.EditText)
+		if err != nil {
+			s.presetMessage("preset.error_code", true)
+			return
+		}
+		key, ok := s.applyBuild(menuState, target)
+		s.presetMessage(key, !ok)
+		if !ok {
+			return
+		}
+	} else {
+		if err := s.savePreset(s.presetView.EditText); err != nil {
+			s.presetMessage(presetErrorKey(err), true)
+			return
+		}
+		s.presetMessage("preset.saved", false)
+	}
+	s.presetView.Editing = false
+	s.loadPresets()
+}
+
+// errPresetsFull 方案数已达上限（仅在场景内使用）
+var errPresetsFull = errors.New("upgrade presets full")
+
+// savePreset 把当前加点保存为方案；同名方案被覆盖，不占用新的位置
+func (s *UpgradeScene) savePreset(name string) error {
+	if len(s.presets) >= maxUpgradePresets {
+		exists := false
+		for _, p := range s.presets {
+			exists = exists || p.Name == name
+		}
+		if !exists {
+			return errPresetsFull
+		}
+	}
+	return progress.SaveUpgradePreset(name, s.playerOptions.Upgrades())
+}
+
+// presetErrorKey 保存方案错误对应的本地化键
+func presetErrorKey(err error) string {
+	switch {
+	case errors.Is(err, progress.ErrPresetName):
+		return "preset.error_name"
+	case errors.Is(err, errPresetsFull):
+		return "preset.error_full"
+	default:
+		return "preset.error_save"
+	}
+}
+
+// applyPresetHotkey 升级界面按数字键直接套用对应方案
+func (s *UpgradeScene) applyPresetHotkey(menuState *components.MenuStateData, n int) {
+	presets, _ := progress.UpgradePresets()
+	if n > len(presets) {
+		s.setMessage("preset.error_empty", true)
+		return
+	}
+	key, ok := s.applyBuild(menuState, upgrades.Levels(presets[n-1].Upgrades))
+	s.setMessage(key, !ok)
+}
+
+// applyBuild 切换到目标加点：先退还降级的功勋，再花费升级的功勋；功勋不足或含无法购买的升级时不做任何改动
+func (s *UpgradeScene) applyBuild(menuState *components.MenuStateData, target upgrades.Levels) (string, bool) {
+	current := s.playerOptions.UpgradeLevels
+	change, err := upgrades.Respec(current, target)
+	if err != nil {
+		return "preset.error_unavailable", false
+	}
+	// 逐级退还与花费和新加点一同保存，功勋不足时整套失败
+	steps := make([]progress.UpgradeStep, 0, len(change.Steps))
+	for _, step := range change.Steps {
+		steps = append(steps, progress.UpgradeStep{Upgrade: step.Key, Amount: step.Amount})
+	}
+	err = progress.RespecUpgrades(s.playerOptions.ShipID, steps, progress.UpgradeData(change.Levels))
+	if errors.Is(err, progress.ErrInsufficientMerits) {
+		return "preset.error_merits", false
+	}
+	if err != nil {
+		return "ledger.error_save", false
+	}
+	s.playerOptions.UpgradeLevels = change.Levels
+	menuState.AvailableMerits = progress.GetMerits()
+	menuState.OptionCount = len(s.upgrades())
+	menuState.SelectedIndex = min(menuState.SelectedIndex, menuState.OptionCount-1)
+
+	// 新买到的等级与逐级购买一样计入成就
+	for _, u := range upgrades.Registry {
+		if change.Levels[u.Key] > current[u.Key] {
+			_, err := change.Levels.NextCost(u.Key)
+			achievements.Publish(achievements.Event{
+				Kind:    achievements.EventUpgradePurchased,
+				Ship:    s.playerOptions.ShipID,
+				Upgrade: u.Key,
+				Maxed:   err == upgrades.ErrMaxLevel,
+			})
+		}
+	}
+	return "preset.applied", true
+}
+
+// presetMessage 设置方案面板的提示
+func (s *UpgradeScene) presetMessage(key string, isError bool) {
+	s.presetView.MessageKey = key
+	s.presetView.MessageError = isError
+	s.setMessage(key, isError)
+}
+
+// setMessage 设置升级界面底部的提示
+func (s *UpgradeScene) setMessage(key string, isError bool) {
+	s.messageKey = key
+	s.messageError = isError
+}
@@ -0,0 +1,148 @@
+package ecs
+
+import (
+	"fmt"
+	"time"
+)
+
+// System 可调度的系统：每个模拟 tick 调用一次，dt 为步长（秒）
+type System interface {
+	Update(w *World, dt float64)
+}
+
+// SystemFunc 将普通函数适配为 System
+type SystemFunc func(w *World, dt float64)
+
+// Update 调用函数本身
+func (f SystemFunc) Update(w *World, dt float64) {
+	f(w, dt)
+}
+
+// Phase 调度阶段，阶段按 Phases 顺序执行，阶段内按注册顺序执行
+type Phase string
+
+const (
+	PhaseInput     Phase = "input"
+	PhaseAI        Phase = "ai"
+	PhaseMovement  Phase = "movement"
+	PhaseCollision Phase = "collision"
+	PhaseSpawn     Phase = "spawn"
+	PhaseCleanup   Phase = "cleanup"
+	PhaseEffects   Phase = "effects"
+)
+
+// Phases 阶段执行顺序
+var Phases = []Phase{PhaseInput, PhaseAI, PhaseMovement, PhaseCollision, PhaseSpawn, PhaseCleanup, PhaseEffects}
+
+// SystemStats 系统耗时统计
+type SystemStats struct {
+	Name    string
+	Phase   Phase
+	Enabled bool
+	Calls   int
+	Last    time.Duration
+	Max     time.Duration
+	Total   time.Duration
+}
+
+// Average 平均每次耗时
+func (s SystemStats) Average() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Calls)
+}
+
+type scheduledSystem struct {
+	system System
+	stats  SystemStats
+}
+
+// Scheduler 按阶段调度系统，支持单独启停与耗时统计；
+// 每个 tick 末尾派发事件总线，订阅者在此统一响应
+type Scheduler struct {
+	systems []*scheduledSystem
+}
+
+// NewScheduler 创建调度器
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add 在指定阶段末尾注册系统，名称需唯一
+func (s *Scheduler) Add(phase Phase, name string, system System) {
+	if s.find(name) != nil {
+		panic(fmt.Sprintf("ecs: system %q already scheduled", name))
+	}
+	s.systems = append(s.systems, &scheduledSystem{
+		system: system,
+		stats:  SystemStats{Name: name, Phase: phase, Enabled: true},
+	})
+}
+
+// SetEnabled 启用或停用系统，系统不存在时返回 false
+func (s *Scheduler) SetEnabled(name string, enabled bool) bool {
+	sys := s.find(name)
+	if sys == nil {
+		return false
+	}
+	sys.stats.Enabled = enabled
+	return true
+}
+
+// Enabled 系统是否启用
+func (s *Scheduler) Enabled(name string) bool {
+	sys := s.find(name)
+	return sys != nil && sys.stats.Enabled
+}
+
+// Update 执行一个 tick：按阶段运行已启用的系统，最后派发本 tick 的事件
+func (s *Scheduler) Update(w *World, dt float64) {
+	for _, phase := range Phases {
+		for _, sys := range s.systems {
+			if sys.stats.Phase != phase || !sys.stats.Enabled {
+				continue
+			}
+			start := time.Now()
+			sys.system.Update(w, dt)
+			elapsed := time.Since(start)
+			sys.stats.Calls++
+			sys.stats.Last = elapsed
+			sys.stats.Total += elapsed
+			sys.stats.Max = max(sys.stats.Max, elapsed)
+		}
+	}
+	w.Events.Dispatch()
+}
+
+// Stats 返回各系统的耗时统计（按执行顺序）
+func (s *Scheduler) Stats() []SystemStats {
+	stats := make([]SystemStats, 0, len(s.systems))
+	for _, phase := range Phases {
+		for _, sys := range s.systems {
+			if sys.stats.Phase == phase {
+				stats = append(stats, sys.stats)
+			}
+		}
+	}
+	return stats
+}
+
+// ResetStats 清空耗时统计
+func (s *Scheduler) ResetStats() {
+	for _, sys := range s.systems {
+		sys.stats.Calls = 0
+		sys.stats.Last = 0
+		sys.stats.Max = 0
+		sys.stats.Total = 0
+	}
+}
+
+func (s *Scheduler) find(name string) *scheduledSystem {
+	for _, sys := range s.systems {
+		if sys.stats.Name == name {
+			return sys
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"math"
+
+	"spacebattle/internal/config"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// AssistSystem 自适应难度系统：按玩家表现在限定范围内微调生成间隔、敌机生命与敌机子弹速度
+type AssistSystem struct {
+	cfg *config.Config
+}
+
+// NewAssistSystem 创建自适应难度系统
+func NewAssistSystem() *AssistSystem {
+	return &AssistSystem{
+		cfg: config.DefaultConfig(),
+	}
+}
+
+// Update 每个评估间隔根据受伤、击杀率与剩余生命调整一次
+func (s *AssistSystem) Update(w donburi.World) {
+	var gameState *components.GameStateData
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		gameState = components.GameState.Get(entry)
+	})
+	if gameState == nil || !gameState.Assist.Enabled {
+		return
+	}
+
+	assist := &gameState.Assist
+	now := gameState.Clock
+	if assist.LastEval.IsZero() {
+		assist.LastEval = now
+		assist.History = append(assist.History, components.AssistSample{At: 0, Level: assist.Level})
+		return
+	}
+	if now.Sub(assist.LastEval) < s.cfg.AssistInterval {
+		return
+	}
+
+	// 本次评估窗口内的表现
+	damaged := gameState.DamageTaken - assist.LastDamage
+	killed := gameState.KilledEnemyCount - assist.LastKilled
+	spawned := gameState.SpawnedCount - assist.LastSpawned
+	killRatio := 1.0
+	if spawned > 0 {
+		killRatio = float64(killed) / float64(spawned)
+	}
+	livesRatio := s.livesRatio(w)
+
+	// 受伤或生命偏低时减难；无伤、高击杀且满血时加难
+	pressure := 0.0
+	if damaged > 0 {
+		pressure++
+	}
+	if livesRatio < 0.5 {
+		pressure++
+	}
+	if killRatio < 0.5 {
+		pressure += 0.5
+	}
+	if damaged == 0 && killRatio >= 0.8 && livesRatio >= 1 {
+		pressure--
+	}
+
+	level := math.Max(s.cfg.AssistMinLevel, math.Min(s.cfg.AssistMaxLevel, assist.Level+pressure*s.cfg.AssistStep))
+	if level != assist.Level {
+		assist.Level = level
+		assist.History = append(assist.History, components.AssistSample{
+			At:    now.Sub(gameState.StartTime),
+			Level: level,
+		})
+	}
+	assist.SpawnIntervalMul = 1 + s.cfg.AssistSpawnK*level
+	assist.EnemyHPMul = 1 - s.cfg.AssistEnemyHpK*level
+	assist.EnemyBulletMul = 1 - s.cfg.AssistBulletSpdK*level
+
+	assist.LastEval = now
+	assist.LastDamage = gameState.DamageTaken
+	assist.LastKilled = gameState.KilledEnemyCount
+	assist.LastSpawned = gameState.SpawnedCount
+}
+
+// livesRatio 存活玩家当前生命占上限的比例
+func (s *AssistSystem) livesRatio(w donburi.World) float64 {
+	current, maximum := 0, 0
+	query.NewQuery(filter.Contains(tags.Player, components.Health)).Each(w, func(entry *donburi.Entry) {
+		health := components.Health.Get(entry)
+		current += max(health.Current, 0)
+		maximum += health.Max
+	})
+	if maximum == 0 {
+		return 0
+	}
+	return float64(current) / float64(maximum)
+}
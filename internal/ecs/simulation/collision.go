@@ -1,4 +1,4 @@
-package systems
+package simulation
 
 import (
 	"time"
@@ -6,28 +6,22 @@ import (
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
-	"spacebattle/internal/sound"
 
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/filter"
 	"github.com/yohamta/donburi/query"
 )
 
-// CollisionSystem 碰撞检测系统
+// CollisionSystem 碰撞检测系统：只结算伤害与计分，击毁、受伤等后果以事件发布，
+// 由被动技能、粒子、震屏、音效与成就等订阅者响应
 type CollisionSystem struct {
-	world             *ecs.World
-	shipAbilitySystem *ShipAbilitySystem
-	particleSystem    *ParticleSystem
-	shakeSystem       *ScreenShakeSystem
+	world *ecs.World
 }
 
 // NewCollisionSystem 创建碰撞检测系统
-func NewCollisionSystem(world *ecs.World, shipAbility *ShipAbilitySystem, particle *ParticleSystem, shake *ScreenShakeSystem) *CollisionSystem {
+func NewCollisionSystem(world *ecs.World) *CollisionSystem {
 	return &CollisionSystem{
-		world:             world,
-		shipAbilitySystem: shipAbility,
-		particleSystem:    particle,
-		shakeSystem:       shake,
+		world: world,
 	}
 }
 
@@ -62,12 +56,6 @@ func (s *CollisionSystem) CheckBulletEnemyCollisions(w donburi.World) {
 		gameState = components.GameState.Get(entry)
 	})
 
-	// 获取玩家实体用于被动触发
-	var playerEntry *donburi.Entry
-	query.NewQuery(filter.Contains(tags.Player)).Each(w, func(entry *donburi.Entry) {
-		playerEntry = entry
-	})
-
 	bulletQuery.Each(w, func(bullet *donburi.Entry) {
 		bulletPos := components.Position.Get(bullet)
 		bulletSize := components.Size.Get(bullet)
@@ -110,7 +98,7 @@ func (s *CollisionSystem) CheckBulletEnemyCollisions(w donburi.World) {
 						damage = 1 // 最小伤害为1
 					}
 					enemyHealth.Current -= damage
-					sound.PlayHit()
+					ecs.Publish(s.world.Events, ecs.EnemyHit{X: enemyPos.X + enemySize.Width/2, Y: enemyPos.Y + enemySize.Height/2})
 
 					if enemyHealth.Current <= 0 {
 						// 敌机被击毁
@@ -118,25 +106,15 @@ func (s *CollisionSystem) CheckBulletEnemyCollisions(w donburi.World) {
 						if gameState != nil {
 							gameState.Score += 10
 							gameState.KilledEnemyCount++
+							if bulletDamage.OwnerSlot < len(gameState.PlayerKills) {
+								gameState.PlayerKills[bulletDamage.OwnerSlot]++
+							}
 						}
-
-						// 触发被动技能（Alpha回血、Beta叠buff）
-						if s.shipAbilitySystem != nil && playerEntry != nil {
-							s.shipAbilitySystem.OnEnemyKilled(w, playerEntry)
-						}
-
-						// 创建粒子效果
-						if s.particleSystem != nil {
-							s.particleSystem.CreateExplosionParticles(
-								enemyPos.X+enemySize.Width/2,
-								enemyPos.Y+enemySize.Height/2,
-							)
-						}
-
-						// 触发屏幕震动
-						if s.shakeSystem != nil {
-							s.shakeSystem.TriggerShake(2.0, 0.15)
-						}
+						ecs.Publish(s.world.Events, ecs.EnemyKilled{
+							X:         enemyPos.X + enemySize.Width/2,
+							Y:         enemyPos.Y + enemySize.Height/2,
+							OwnerSlot: bulletDamage.OwnerSlot,
+						})
 
 						// 创建爆炸效果
 						s.world.CreateExplosion(
@@ -218,12 +196,11 @@ func (s *CollisionSystem) CheckBulletBossCollisions(w donburi.World) {
 					baseDmg = 1
 				}
 
-				// Boss 软收束：根据时间提高伤害倍率
+				// Boss 软收束：根据 Boss 出现后的时间提高伤害倍率
 				dmgMultiplier := 1
 				if gameState != nil {
-					elapsed := time.Since(gameState.StartTime)
-					bossElapsed := elapsed - gameState.SmallPhaseDuration
-					if bossElapsed > 0 {
+					if current := gameState.CurrentBoss(); current != nil {
+						bossElapsed := gameState.Clock.Sub(current.SpawnTime)
 						if bossElapsed >= 14*time.Second {
 							dmgMultiplier = 3
 						} else if bossElapsed >= 10*time.Second {
@@ -235,15 +212,33 @@ func (s *CollisionSystem) CheckBulletBossCollisions(w donburi.World) {
 				// 应用伤害
 				totalDmg := baseDmg * dmgMultiplier
 				bossHealth.Current -= totalDmg
-				sound.PlayHit()
+				ecs.Publish(s.world.Events, ecs.EnemyHit{X: bossPos.X + bossSize.Width/2, Y: bossPos.Y + bossSize.Height/2, Boss: true})
 
 				if bossHealth.Current <= 0 {
 					// Boss 被击毁
 					bossToRemove = append(bossToRemove, boss)
 					if gameState != nil {
-						gameState.Victory = true
-						gameState.BossKilled = true
+						if current := gameState.CurrentBoss(); current != nil {
+							ecs.Publish(s.world.Events, ecs.BossPhaseChanged{
+								NameKey: current.NameKey,
+								Phase:   ecs.BossPhaseDefeated,
+								X:       bossPos.X + bossSize.Width/2,
+								Y:       bossPos.Y + bossSize.Height/2,
+								NoHit:   gameState.BossNoHit(),
+							})
+						}
+						gameState.KillCurrentBoss(gameState.Clock)
 						gameState.Score += 200
+						switch {
+						case gameState.Mode == components.ModeEndless:
+							// 无尽模式：击败 Boss 不结束，重新计时下一只
+							gameState.NextBossTime = gameState.Clock.Add(gameState.BossInterval)
+						case gameState.Mode == components.ModeBossRush && gameState.BossKillCount() < len(gameState.BossQueue):
+							// Boss 连战：进入强化选择，之后出现下一只
+							gameState.RushPicking = true
+						default:
+							gameState.Victory = true
+						}
 					}
 					// 创建爆炸效果
 					s.world.CreateExplosion(
@@ -296,28 +291,20 @@ func (s *CollisionSystem) CheckPlayerEnemyCollisions(w donburi.World) {
 					enemyPos.X, enemyPos.Y, enemySize.Width, enemySize.Height,
 				) {
 					// 检查被动技能（无敌、护盾）
-					damage := 1
-					if s.shipAbilitySystem != nil {
-						damage = s.shipAbilitySystem.OnPlayerDamaged(w, player)
-					}
+					damage := AbsorbDamage(player, components.Now(w))
 
 					// 应用伤害
 					if damage > 0 {
 						playerHealth.Current -= damage
-						sound.PlayHit()
-
-						// 触发屏幕震动
-						if s.shakeSystem != nil {
-							s.shakeSystem.TriggerShake(4.0, 0.2)
-						}
+						addDamageTaken(w, damage)
+						s.publishPlayerDamaged(player, damage)
 					}
 
 					// 移除敌机
 					w.Remove(enemy.Entity())
 
 					// 重置玩家位置
-					playerPos.X = 400
-					playerPos.Y = 500
+					resetToSpawn(player)
 
 					// 创建爆炸效果
 					s.world.CreateExplosion(
@@ -360,24 +347,16 @@ func (s *CollisionSystem) CheckEnemyBulletPlayerCollisions(w donburi.World) {
 				bulletsToRemove = append(bulletsToRemove, bullet)
 
 				// 检查被动技能（无敌、护盾）
-				damage := 1
-				if s.shipAbilitySystem != nil {
-					damage = s.shipAbilitySystem.OnPlayerDamaged(w, player)
-				}
+				damage := AbsorbDamage(player, components.Now(w))
 
 				// 应用伤害
 				if damage > 0 {
 					playerHealth.Current -= damage
-					sound.PlayHit()
-
-					// 触发屏幕震动
-					if s.shakeSystem != nil {
-						s.shakeSystem.TriggerShake(4.0, 0.2)
-					}
+					addDamageTaken(w, damage)
+					s.publishPlayerDamaged(player, damage)
 
 					// 重置玩家位置
-					playerPos.X = 400
-					playerPos.Y = 500
+					resetToSpawn(player)
 
 					// 创建爆炸效果
 					s.world.CreateExplosion(
@@ -396,7 +375,48 @@ func (s *CollisionSystem) CheckEnemyBulletPlayerCollisions(w donburi.World) {
 	}
 }
 
+// publishPlayerDamaged 发布玩家受伤事件
+func (s *CollisionSystem) publishPlayerDamaged(player *donburi.Entry, damage int) {
+	pos := components.Position.Get(player)
+	size := components.Size.Get(player)
+	ecs.Publish(s.world.Events, ecs.PlayerDamaged{
+		Player: player,
+		X:      pos.X + size.Width/2,
+		Y:      pos.Y + size.Height/2,
+		Damage: damage,
+	})
+}
+
+// PlayersBySlot 按玩家编号收集玩家实体
+func PlayersBySlot(w donburi.World) map[int]*donburi.Entry {
+	players := make(map[int]*donburi.Entry)
+	query.NewQuery(filter.Contains(tags.Player, components.PlayerInput)).Each(w, func(entry *donburi.Entry) {
+		players[components.PlayerInput.Get(entry).Slot] = entry
+	})
+	return players
+}
+
+// resetToSpawn 将玩家重置到出生点
+func resetToSpawn(player *donburi.Entry) {
+	pos := components.Position.Get(player)
+	if player.HasComponent(components.PlayerInput) {
+		input := components.PlayerInput.Get(player)
+		pos.X = input.SpawnX
+		pos.Y = input.SpawnY
+		return
+	}
+	pos.X = 400
+	pos.Y = 500
+}
+
 // CheckAABB 检查 AABB 碰撞
 func (s *CollisionSystem) CheckAABB(x1, y1, w1, h1, x2, y2, w2, h2 float64) bool {
 	return x1 < x2+w2 && x1+w1 > x2 && y1 < y2+h2 && y1+h1 > y2
 }
+
+// addDamageTaken 累计玩家受到的伤害（供自适应难度评估）
+func addDamageTaken(w donburi.World, damage int) {
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		components.GameState.Get(entry).DamageTaken += damage
+	})
+}
@@ -1,8 +1,7 @@
-package systems
+package simulation
 
 import (
 	"math"
-	"time"
 
 	"spacebattle/internal/config"
 	"spacebattle/internal/ecs"
@@ -30,26 +29,34 @@ func NewEnemyAISystem(world *ecs.World) *EnemyAISystem {
 
 // Update 更新敌机AI行为
 func (s *EnemyAISystem) Update(w donburi.World, dt float64) {
-	// 获取玩家位置（用于射击型敌机瞄准）
-	var playerPos *components.PositionData
+	// 获取所有玩家位置（射击型敌机瞄准最近的玩家）
+	var playerPositions []*components.PositionData
 	query.NewQuery(filter.Contains(tags.Player, components.Position)).Each(w, func(entry *donburi.Entry) {
-		playerPos = components.Position.Get(entry)
+		playerPositions = append(playerPositions, components.Position.Get(entry))
+	})
+
+	// 自适应难度：敌机子弹速度倍率
+	bulletMul := 1.0
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		if assist := components.GameState.Get(entry).Assist; assist.Enabled {
+			bulletMul = assist.EnemyBulletMul
+		}
 	})
 
 	// 处理射击型敌机
-	s.processShooterEnemies(w, playerPos)
+	s.processShooterEnemies(w, playerPositions, bulletMul)
 
 	// 处理之字型敌机
 	s.processZigzagEnemies(w, dt)
 }
 
 // processShooterEnemies 处理射击型敌机
-func (s *EnemyAISystem) processShooterEnemies(w donburi.World, playerPos *components.PositionData) {
+func (s *EnemyAISystem) processShooterEnemies(w donburi.World, playerPositions []*components.PositionData, bulletMul float64) {
 	shooterQuery := query.NewQuery(
 		filter.Contains(tags.EnemyShooter, components.Position, components.Size, components.EnemyAI),
 	)
 
-	now := time.Now()
+	now := components.Now(w)
 	shooterQuery.Each(w, func(entry *donburi.Entry) {
 		ai := components.EnemyAI.Get(entry)
 		pos := components.Position.Get(entry)
@@ -62,15 +69,15 @@ func (s *EnemyAISystem) processShooterEnemies(w donburi.World, playerPos *compon
 
 		// 检查是否到达射击时间
 		if now.Sub(ai.LastShotTime) >= ai.ShootInterval {
-			if playerPos != nil {
+			if playerPos := nearestPosition(playerPositions, pos.X, pos.Y); playerPos != nil {
 				// 向玩家位置射击
 				dx := playerPos.X - pos.X
 				dy := playerPos.Y - pos.Y
 				dist := math.Sqrt(dx*dx + dy*dy)
 				if dist > 0 {
 					// 创建敌机子弹
-					vx := (dx / dist) * 4.0
-					vy := (dy / dist) * 4.0
+					vx := (dx / dist) * 4.0 * bulletMul
+					vy := (dy / dist) * 4.0 * bulletMul
 					s.world.CreateEnemyBullet(pos.X, pos.Y, vx, vy)
 				}
 			}
@@ -79,6 +86,21 @@ func (s *EnemyAISystem) processShooterEnemies(w donburi.World, playerPos *compon
 	})
 }
 
+// nearestPosition 找到距离 (x, y) 最近的位置
+func nearestPosition(positions []*components.PositionData, x, y float64) *components.PositionData {
+	var nearest *components.PositionData
+	bestDist := math.Inf(1)
+	for _, p := range positions {
+		dx := p.X - x
+		dy := p.Y - y
+		if d := dx*dx + dy*dy; d < bestDist {
+			bestDist = d
+			nearest = p
+		}
+	}
+	return nearest
+}
+
 // isInScreen 检查实体是否在屏幕内
 func (s *EnemyAISystem) isInScreen(x, y, width, height float64) bool {
 	screenWidth := float64(s.cfg.WindowWidth)
@@ -105,6 +127,6 @@ func (s *EnemyAISystem) processZigzagEnemies(w donburi.World, dt float64) {
 		ai.ZigzagPhase += ai.ZigzagSpeed * dt
 
 		// 根据正弦函数计算横向速度
-		vel.VX = math.Sin(ai.ZigzagPhase) * 2.0
+		vel.VX = ecs.PerSecond(math.Sin(ai.ZigzagPhase) * 2.0)
 	})
 }
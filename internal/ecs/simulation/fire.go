@@ -1,14 +1,12 @@
-package systems
+package simulation
 
 import (
 	"math"
-	"math/rand"
 	"time"
 
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
-	"spacebattle/internal/sound"
 
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/filter"
@@ -27,49 +25,49 @@ func NewFireSystem(world *ecs.World) *FireSystem {
 	}
 }
 
-// Update 更新射击系统
-func (s *FireSystem) Update(w donburi.World, firePressed bool) {
-	// 处理计划中的连射
-	s.ProcessScheduledShots(w)
+// Update 更新射击系统（射击意图由输入系统写入各玩家的 PlayerInput.Fire）
+func (s *FireSystem) Update(w donburi.World) {
+	now := components.Now(w)
 
-	// 如果没有按下射击键，直接返回
-	if !firePressed {
-		return
-	}
+	// 处理计划中的连射
+	s.ProcessScheduledShots(w, now)
 
 	// 查找玩家实体
 	playerQuery := query.NewQuery(
-		filter.Contains(tags.Player, components.Position, components.Size, components.FireSkill),
+		filter.Contains(tags.Player, components.Position, components.Size, components.FireSkill, components.PlayerInput),
 	)
 
 	playerQuery.Each(w, func(entry *donburi.Entry) {
+		input := components.PlayerInput.Get(entry)
+		// 如果没有按下射击键，跳过
+		if !input.Fire {
+			return
+		}
+
 		pos := components.Position.Get(entry)
 		size := components.Size.Get(entry)
 		fireSkill := components.FireSkill.Get(entry)
 
-		now := time.Now()
-
 		// 检查射击冷却
 		if now.Sub(fireSkill.LastShot) < fireSkill.ShotDelay {
 			return
 		}
 
 		// 执行射击
-		s.Fire(w, pos.X+size.Width/2-2, pos.Y, fireSkill)
-		sound.PlayShoot()
+		s.Fire(w, pos.X+size.Width/2-2, pos.Y, fireSkill, input.Slot)
 
 		fireSkill.LastShot = now
 
 		// 处理连发
-		if fireSkill.BurstChance > 0 && rand.Float64() < fireSkill.BurstChance {
+		if fireSkill.BurstChance > 0 && s.world.Rand.Float64() < fireSkill.BurstChance {
 			burstTime := now.Add(fireSkill.BurstInterval)
 			fireSkill.ScheduledShots = append(fireSkill.ScheduledShots, burstTime)
 		}
 	})
 }
 
-// Fire 执行射击
-func (s *FireSystem) Fire(w donburi.World, x, y float64, fireSkill *components.FireSkillData) {
+// Fire 执行射击，slot 为发射者的玩家编号
+func (s *FireSystem) Fire(w donburi.World, x, y float64, fireSkill *components.FireSkillData, slot int) {
 	numBullets := max(fireSkill.BulletsPerShot, 1)
 	baseAngle := -math.Pi / 2
 	spreadRad := fireSkill.SpreadDeg * math.Pi / 180.0
@@ -86,7 +84,7 @@ func (s *FireSystem) Fire(w donburi.World, x, y float64, fireSkill *components.F
 		vx := math.Cos(angle) * fireSkill.BulletSpeed
 		vy := math.Sin(angle) * fireSkill.BulletSpeed
 
-		s.world.CreateBullet(
+		bullet := s.world.CreateBullet(
 			x, y, vx, vy,
 			fireSkill.BulletSpeed,
 			fireSkill.BulletDamage,
@@ -94,11 +92,13 @@ func (s *FireSystem) Fire(w donburi.World, x, y float64, fireSkill *components.F
 			fireSkill.EnableHoming,
 			fireSkill.HomingTurnRateRad,
 		)
+		components.Damage.Get(bullet).OwnerSlot = slot
 	}
+	ecs.Publish(s.world.Events, ecs.BulletFired{OwnerSlot: slot, Bullets: numBullets})
 }
 
-// ProcessScheduledShots 处理计划中的连射
-func (s *FireSystem) ProcessScheduledShots(w donburi.World) {
+// ProcessScheduledShots 处理计划中的连射（到达 now 的连射依次发射）
+func (s *FireSystem) ProcessScheduledShots(w donburi.World, now time.Time) {
 	playerQuery := query.NewQuery(
 		filter.Contains(tags.Player, components.Position, components.Size, components.FireSkill),
 	)
@@ -112,12 +112,15 @@ func (s *FireSystem) ProcessScheduledShots(w donburi.World) {
 			return
 		}
 
-		now := time.Now()
+		slot := 0
+		if entry.HasComponent(components.PlayerInput) {
+			slot = components.PlayerInput.Get(entry).Slot
+		}
+
 		idx := 0
 
 		for idx < len(fireSkill.ScheduledShots) && fireSkill.ScheduledShots[idx].Before(now) {
-			s.Fire(w, pos.X+size.Width/2-2, pos.Y, fireSkill)
-			sound.PlayShoot()
+			s.Fire(w, pos.X+size.Width/2-2, pos.Y, fireSkill, slot)
 			idx++
 		}
 
@@ -130,7 +133,6 @@ func (s *FireSystem) ProcessScheduledShots(w donburi.World) {
 // InitializeFireSkill 初始化射击技能
 func InitializeFireSkill(fireSkill *components.FireSkillData) {
 	fireSkill.ShotDelay = ecs.ComputeShotDelay(fireSkill.FireRateHz)
-	fireSkill.LastShot = time.Now().Add(-fireSkill.ShotDelay) // 允许立即射击
+	fireSkill.LastShot = time.Time{} // 零值表示从未射击，开局即可射击
 	fireSkill.ScheduledShots = make([]time.Time, 0)
 }
-
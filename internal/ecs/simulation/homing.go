@@ -1,8 +1,7 @@
-package systems
+package simulation
 
 import (
 	"math"
-	"time"
 
 	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
@@ -21,9 +20,9 @@ func NewHomingSystem() *HomingSystem {
 	return &HomingSystem{}
 }
 
-// Update 更新追踪子弹
-func (s *HomingSystem) Update(w donburi.World) {
-	now := time.Now()
+// Update 更新追踪子弹，转向速率（弧度/秒）按 dt 折算
+func (s *HomingSystem) Update(w donburi.World, dt float64) {
+	now := components.Now(w)
 	
 	// 先统计每个敌人被锁定的子弹数量
 	targetCounts := make(map[donburi.Entity]int)
@@ -109,7 +108,8 @@ func (s *HomingSystem) Update(w donburi.World) {
 		delta := ecs.WrapAngle(desiredAngle - currentAngle)
 
 		// 限制转向速率
-		turn := ecs.ClampFloat64(delta, -homing.TurnRate, homing.TurnRate)
+		maxTurn := homing.TurnRate * dt
+		turn := ecs.ClampFloat64(delta, -maxTurn, maxTurn)
 
 		// 应用转向
 		newAngle := currentAngle + turn
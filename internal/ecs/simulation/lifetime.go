@@ -1,6 +1,7 @@
-package systems
+package simulation
 
 import (
+	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
 
@@ -18,13 +19,13 @@ func NewLifetimeSystem() *LifetimeSystem {
 }
 
 // Update 更新生命周期
-func (s *LifetimeSystem) Update(w donburi.World) {
+func (s *LifetimeSystem) Update(w donburi.World, dt float64) {
 	// 更新爆炸效果
-	s.UpdateExplosions(w)
+	s.UpdateExplosions(w, dt)
 }
 
 // UpdateExplosions 更新爆炸效果
-func (s *LifetimeSystem) UpdateExplosions(w donburi.World) {
+func (s *LifetimeSystem) UpdateExplosions(w donburi.World, dt float64) {
 	explosionQuery := query.NewQuery(
 		filter.Contains(tags.Explosion, components.Lifetime),
 	)
@@ -34,8 +35,8 @@ func (s *LifetimeSystem) UpdateExplosions(w donburi.World) {
 	explosionQuery.Each(w, func(entry *donburi.Entry) {
 		lifetime := components.Lifetime.Get(entry)
 
-		// 更新计时器
-		lifetime.Timer += 0.1
+		// 更新计时器（约 10 个 tick 播放完毕）
+		lifetime.Timer += ecs.PerSecond(0.1) * dt
 		// 更新半径（从 0 渐变到最大，然后渐变回 0）
 		lifetime.Radius = lifetime.MaxRadius * (1 - lifetime.Timer)
 
@@ -1,4 +1,4 @@
-package systems
+package simulation
 
 import (
 	"math/rand"
@@ -19,8 +19,8 @@ func NewMovementSystem() *MovementSystem {
 	return &MovementSystem{}
 }
 
-// Update 更新所有有速度的实体位置
-func (s *MovementSystem) Update(w donburi.World) {
+// Update 按 dt（秒）积分所有有速度的实体位置，速度单位为像素/秒
+func (s *MovementSystem) Update(w donburi.World, dt float64) {
 	// 更新所有有位置和速度的实体
 	movableQuery := query.NewQuery(
 		filter.Contains(components.Position, components.Velocity),
@@ -30,15 +30,15 @@ func (s *MovementSystem) Update(w donburi.World) {
 		pos := components.Position.Get(entry)
 		vel := components.Velocity.Get(entry)
 
-		pos.X += vel.VX
-		pos.Y += vel.VY
+		pos.X += vel.VX * dt
+		pos.Y += vel.VY * dt
 	})
 
 	// Boss 特殊移动逻辑（边界反弹）
 	s.UpdateBoss(w)
 
 	// 星星特殊移动逻辑（循环滚动）
-	s.UpdateStars(w)
+	s.UpdateStars(w, dt)
 }
 
 // UpdateBoss 更新 Boss 移动（边界反弹）
@@ -65,7 +65,7 @@ func (s *MovementSystem) UpdateBoss(w donburi.World) {
 }
 
 // UpdateStars 更新星星背景（循环滚动）
-func (s *MovementSystem) UpdateStars(w donburi.World) {
+func (s *MovementSystem) UpdateStars(w donburi.World, dt float64) {
 	starQuery := query.NewQuery(
 		filter.Contains(tags.Star, components.Position, components.Star),
 	)
@@ -75,7 +75,7 @@ func (s *MovementSystem) UpdateStars(w donburi.World) {
 		star := components.Star.Get(entry)
 
 		// 向下移动
-		pos.Y += star.Speed
+		pos.Y += star.Speed * dt
 
 		// 超出底部则回到顶部
 		if pos.Y > 600 {
@@ -1,7 +1,6 @@
-package systems
+package simulation
 
 import (
-	"image/color"
 	"math"
 	"math/rand"
 
@@ -10,8 +9,6 @@ import (
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/filter"
 	"github.com/yohamta/donburi/query"
@@ -23,12 +20,16 @@ type ParticleSystem struct {
 	cfg   *config.Config
 }
 
-// NewParticleSystem 创建粒子系统
+// NewParticleSystem 创建粒子系统，并订阅击毁事件生成爆炸粒子
 func NewParticleSystem(world *ecs.World) *ParticleSystem {
-	return &ParticleSystem{
+	s := &ParticleSystem{
 		world: world,
 		cfg:   config.DefaultConfig(),
 	}
+	ecs.Subscribe(world.Events, func(e ecs.EnemyKilled) {
+		s.CreateExplosionParticles(e.X, e.Y)
+	})
+	return s
 }
 
 // Update 更新粒子
@@ -44,8 +45,8 @@ func (s *ParticleSystem) Update(w donburi.World, dt float64) {
 		particle := components.Particle.Get(entry)
 
 		// 更新位置
-		pos.X += particle.VX * dt * 60 // 60 FPS 标准化
-		pos.Y += particle.VY * dt * 60
+		pos.X += particle.VX * dt
+		pos.Y += particle.VY * dt
 
 		// 更新生命周期
 		particle.Life -= dt
@@ -65,36 +66,6 @@ func (s *ParticleSystem) Update(w donburi.World, dt float64) {
 	}
 }
 
-// Draw 绘制粒子
-func (s *ParticleSystem) Draw(w donburi.World, screen *ebiten.Image) {
-	particleQuery := query.NewQuery(
-		filter.Contains(tags.Particle, components.Position, components.Particle),
-	)
-
-	particleQuery.Each(w, func(entry *donburi.Entry) {
-		pos := components.Position.Get(entry)
-		particle := components.Particle.Get(entry)
-
-		// 绘制粒子（小方块）
-		col := color.RGBA{
-			R: particle.ColorR,
-			G: particle.ColorG,
-			B: particle.ColorB,
-			A: particle.Alpha,
-		}
-
-		vector.DrawFilledRect(
-			screen,
-			float32(pos.X),
-			float32(pos.Y),
-			float32(particle.Size),
-			float32(particle.Size),
-			col,
-			false,
-		)
-	})
-}
-
 // CreateExplosionParticles 创建爆炸粒子效果
 func (s *ParticleSystem) CreateExplosionParticles(x, y float64) {
 	// 检查粒子数量限制
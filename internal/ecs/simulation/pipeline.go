@@ -0,0 +1,109 @@
+// Package simulation 战斗模拟的系统与管线：不依赖渲染、音频与键盘，
+// 战斗场景与无界面的平衡模拟共用同一份实现
+package simulation
+
+import (
+	"time"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+)
+
+// BattlePipeline 战斗模拟的系统与执行顺序，战斗场景与无界面模拟共用同一份声明
+type BattlePipeline struct {
+	Scheduler   *ecs.Scheduler
+	ShipAbility *ShipAbilitySystem
+	EnemyAI     *EnemyAISystem
+	Movement    *MovementSystem
+	Fire        *FireSystem
+	Homing      *HomingSystem
+	Collision   *CollisionSystem
+	PlayerLife  *PlayerLifeSystem
+	Assist      *AssistSystem
+	Spawn       *SpawnSystem
+	Lifetime    *LifetimeSystem
+	Particle    *ParticleSystem
+	Shake       *ScreenShakeSystem
+}
+
+// NewBattlePipeline 创建战斗管线；input 为玩家输入系统，为空时不注册，由调用方自行写入 PlayerInput
+func NewBattlePipeline(world *ecs.World, input ecs.System) *BattlePipeline {
+	p := &BattlePipeline{
+		Scheduler:   ecs.NewScheduler(),
+		ShipAbility: NewShipAbilitySystem(world),
+		EnemyAI:     NewEnemyAISystem(world),
+		Movement:    NewMovementSystem(),
+		Fire:        NewFireSystem(world),
+		Homing:      NewHomingSystem(),
+		Collision:   NewCollisionSystem(world),
+		PlayerLife:  NewPlayerLifeSystem(),
+		Assist:      NewAssistSystem(),
+		Spawn:       NewSpawnSystem(world),
+		Lifetime:    NewLifetimeSystem(),
+		Particle:    NewParticleSystem(world),
+		Shake:       NewScreenShakeSystem(world),
+	}
+
+	sched := p.Scheduler
+	if input != nil {
+		sched.Add(ecs.PhaseInput, "player_input", input)
+	}
+
+	// 被动技能状态与敌机行为
+	sched.Add(ecs.PhaseAI, "ship_ability", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.ShipAbility.Update(w.ECS.World, dt)
+	}))
+	sched.Add(ecs.PhaseAI, "enemy_ai", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.EnemyAI.Update(w.ECS.World, dt)
+	}))
+
+	// 位移、射击与追踪（新生成的子弹下一 tick 才移动）
+	sched.Add(ecs.PhaseMovement, "movement", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Movement.Update(w.ECS.World, dt)
+	}))
+	sched.Add(ecs.PhaseMovement, "fire", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Fire.Update(w.ECS.World)
+	}))
+	sched.Add(ecs.PhaseMovement, "homing", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Homing.Update(w.ECS.World, dt)
+	}))
+
+	// 碰撞结算，随后同步玩家生命并评估自适应难度
+	sched.Add(ecs.PhaseCollision, "collision", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Collision.Update(w.ECS.World)
+	}))
+	sched.Add(ecs.PhaseCollision, "player_life", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.PlayerLife.Update(w.ECS.World)
+	}))
+	sched.Add(ecs.PhaseCollision, "assist", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Assist.Update(w.ECS.World)
+	}))
+
+	sched.Add(ecs.PhaseSpawn, "spawn", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Spawn.Update(w.ECS.World)
+	}))
+
+	sched.Add(ecs.PhaseCleanup, "out_of_bounds", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Movement.CleanOutOfBounds(w.ECS.World)
+	}))
+	sched.Add(ecs.PhaseCleanup, "lifetime", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Lifetime.Update(w.ECS.World, dt)
+	}))
+
+	sched.Add(ecs.PhaseEffects, "particles", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Particle.Update(w.ECS.World, dt)
+	}))
+	sched.Add(ecs.PhaseEffects, "screen_shake", ecs.SystemFunc(func(w *ecs.World, dt float64) {
+		p.Shake.Update(w.ECS.World, dt)
+	}))
+
+	return p
+}
+
+// Update 执行一个模拟 tick：先推进模拟时钟，各系统的冷却、生成与 Boss 计时均读取该时钟
+func (p *BattlePipeline) Update(w *ecs.World, dt float64) {
+	if entry, ok := components.GameState.First(w.ECS.World); ok {
+		components.GameState.Get(entry).Advance(time.Duration(dt * float64(time.Second)))
+	}
+	p.Scheduler.Update(w, dt)
+}
@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// PlayerLifeSystem 玩家生命系统：同步生命池、移除阵亡玩家并判定失败
+type PlayerLifeSystem struct{}
+
+// NewPlayerLifeSystem 创建玩家生命系统
+func NewPlayerLifeSystem() *PlayerLifeSystem {
+	return &PlayerLifeSystem{}
+}
+
+// Update 更新玩家生命状态（在碰撞检测之后调用）
+func (s *PlayerLifeSystem) Update(w donburi.World) {
+	var gameState *components.GameStateData
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		gameState = components.GameState.Get(entry)
+	})
+	if gameState == nil {
+		return
+	}
+
+	var players []*donburi.Entry
+	query.NewQuery(filter.Contains(tags.Player, components.Health)).Each(w, func(entry *donburi.Entry) {
+		players = append(players, entry)
+	})
+
+	// 练习模式无限生命：受伤后立即回满
+	if gameState.InfiniteLives {
+		for _, p := range players {
+			health := components.Health.Get(p)
+			health.Current = max(health.Current, health.Max)
+		}
+	}
+
+	if gameState.SharedLives && len(players) > 1 {
+		s.syncSharedPool(gameState, players)
+	} else {
+		// 独立生命：HUD 显示所有存活玩家的生命总和
+		total := 0
+		for _, p := range players {
+			total += max(components.Health.Get(p).Current, 0)
+		}
+		gameState.Lives = total
+	}
+
+	// 移除阵亡玩家
+	alive := 0
+	for _, p := range players {
+		if components.Health.Get(p).Current <= 0 {
+			w.Remove(p.Entity())
+			continue
+		}
+		alive++
+	}
+
+	if alive == 0 && !gameState.Victory {
+		gameState.GameOver = true
+	}
+}
+
+// syncSharedPool 共享生命池：把各玩家本帧的生命变化（受伤/回血）汇总到生命池，再同步回每名玩家
+func (s *PlayerLifeSystem) syncSharedPool(gameState *components.GameStateData, players []*donburi.Entry) {
+	pool := gameState.Lives
+	maxPool := 0
+	for _, p := range players {
+		health := components.Health.Get(p)
+		pool += health.Current - gameState.Lives
+		maxPool = max(maxPool, health.Max)
+	}
+	pool = min(pool, maxPool)
+
+	gameState.Lives = pool
+	for _, p := range players {
+		components.Health.Get(p).Current = pool
+	}
+}
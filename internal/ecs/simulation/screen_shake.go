@@ -1,4 +1,4 @@
-package systems
+package simulation
 
 import (
 	"math/rand"
@@ -16,11 +16,18 @@ type ScreenShakeSystem struct {
 	world *ecs.World
 }
 
-// NewScreenShakeSystem 创建屏幕震动系统
+// NewScreenShakeSystem 创建屏幕震动系统，并订阅击毁与受伤事件
 func NewScreenShakeSystem(world *ecs.World) *ScreenShakeSystem {
-	return &ScreenShakeSystem{
+	s := &ScreenShakeSystem{
 		world: world,
 	}
+	ecs.Subscribe(world.Events, func(ecs.EnemyKilled) {
+		s.TriggerShake(2.0, 0.15)
+	})
+	ecs.Subscribe(world.Events, func(ecs.PlayerDamaged) {
+		s.TriggerShake(4.0, 0.2)
+	})
+	return s
 }
 
 // Update 更新震动状态
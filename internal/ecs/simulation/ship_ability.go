@@ -1,9 +1,10 @@
-package systems
+package simulation
 
 import (
 	"time"
 
 	"spacebattle/internal/config"
+	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
 
@@ -17,15 +18,23 @@ type ShipAbilitySystem struct {
 	cfg *config.Config
 }
 
-// NewShipAbilitySystem 创建战机被动系统
-func NewShipAbilitySystem() *ShipAbilitySystem {
-	return &ShipAbilitySystem{
+// NewShipAbilitySystem 创建战机被动系统，并订阅击杀事件（击杀归属于子弹发射者）
+func NewShipAbilitySystem(world *ecs.World) *ShipAbilitySystem {
+	s := &ShipAbilitySystem{
 		cfg: config.DefaultConfig(),
 	}
+	ecs.Subscribe(world.Events, func(e ecs.EnemyKilled) {
+		if player := PlayersBySlot(world.ECS.World)[e.OwnerSlot]; player != nil {
+			s.OnEnemyKilled(world.ECS.World, player)
+		}
+	})
+	return s
 }
 
 // Update 更新被动技能状态
 func (s *ShipAbilitySystem) Update(w donburi.World, dt float64) {
+	now := components.Now(w)
+
 	// 查找玩家实体
 	playerQuery := query.NewQuery(
 		filter.Contains(tags.Player, components.ShipAbility, components.Health, components.FireSkill),
@@ -38,7 +47,7 @@ func (s *ShipAbilitySystem) Update(w donburi.World, dt float64) {
 		case "speed_frenzy":
 			// Beta - 速度狂热：检查buff是否过期
 			if ability.FrenzyStacks > 0 {
-				elapsed := time.Since(ability.LastKillTime).Seconds()
+				elapsed := now.Sub(ability.LastKillTime).Seconds()
 				if elapsed > s.cfg.FrenzyStackDuration {
 					// buff过期，清空所有层数
 					ability.FrenzyStacks = 0
@@ -64,12 +73,13 @@ func (s *ShipAbilitySystem) Update(w donburi.World, dt float64) {
 		case "energy_shield":
 			// Delta - 能量护盾：5秒不受伤害后回复护盾
 			if ability.ShieldCurrent < ability.ShieldMax {
-				elapsed := time.Since(ability.LastDamageTime).Seconds()
+				elapsed := now.Sub(ability.LastDamageTime).Seconds()
 				if elapsed >= s.cfg.ShieldRegenDelay {
-					// 每秒回复1点护盾
-					ability.ShieldCurrent += int(dt)
-					if ability.ShieldCurrent > ability.ShieldMax {
-						ability.ShieldCurrent = ability.ShieldMax
+					// 每秒回复1点护盾（按帧累计，避免 dt 取整为 0）
+					ability.ShieldRegenAcc += dt
+					for ability.ShieldRegenAcc >= 1 && ability.ShieldCurrent < ability.ShieldMax {
+						ability.ShieldRegenAcc--
+						ability.ShieldCurrent++
 					}
 				}
 			}
@@ -99,15 +109,17 @@ func (s *ShipAbilitySystem) OnEnemyKilled(w donburi.World, playerEntry *donburi.
 
 	case "speed_frenzy":
 		// Beta - 速度狂热：叠加射速buff
-		ability.LastKillTime = time.Now()
+		ability.LastKillTime = components.Now(w)
 		if ability.FrenzyStacks < s.cfg.FrenzyMaxStacks {
 			ability.FrenzyStacks++
 		}
 	}
 }
 
-// OnPlayerDamaged 玩家受到伤害时触发
-func (s *ShipAbilitySystem) OnPlayerDamaged(w donburi.World, playerEntry *donburi.Entry) int {
+// AbsorbDamage 玩家受到伤害时结算被动技能（无敌、护盾），返回实际伤害。
+// 伤害需在碰撞当帧确定，因此由碰撞系统直接调用而不经事件总线；now 为当前模拟时间
+func AbsorbDamage(playerEntry *donburi.Entry, now time.Time) int {
+	cfg := config.DefaultConfig()
 	if !playerEntry.HasComponent(components.ShipAbility) {
 		return 1 // 默认伤害
 	}
@@ -123,15 +135,15 @@ func (s *ShipAbilitySystem) OnPlayerDamaged(w donburi.World, playerEntry *donbur
 		// 触发无敌效果（如果冷却完成）
 		if ability.InvulnCooldown <= 0 {
 			ability.IsInvulnerable = true
-			ability.InvulnTime = s.cfg.DodgeInvulnDuration
-			ability.InvulnCooldown = s.cfg.DodgeInvulnCooldown
-			ability.LastDamageTaken = time.Now()
+			ability.InvulnTime = cfg.DodgeInvulnDuration
+			ability.InvulnCooldown = cfg.DodgeInvulnCooldown
+			ability.LastDamageTaken = now
 		}
 		return 1
 
 	case "energy_shield":
 		// Delta - 能量护盾：先扣除护盾
-		ability.LastDamageTime = time.Now()
+		ability.LastDamageTime = now
 		if ability.ShieldCurrent > 0 {
 			ability.ShieldCurrent--
 			return 0 // 护盾吸收了伤害
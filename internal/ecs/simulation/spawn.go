@@ -1,4 +1,4 @@
-package systems
+package simulation
 
 import (
 	"math"
@@ -47,17 +47,28 @@ func (s *SpawnSystem) Update(w donburi.World) {
 		bossExists = true
 	})
 
+	// 无尽模式：波次循环、难度递增、周期 Boss
+	if gameState.Mode == components.ModeEndless {
+		s.updateEndless(w, gameState, bossExists)
+		return
+	}
+
+	// Boss 连战：跳过小怪阶段，依次生成 Boss
+	if gameState.Mode == components.ModeBossRush {
+		s.updateBossRush(w, gameState, bossExists)
+		return
+	}
+
 	// Boss 阶段
 	if bossExists {
 		return
 	}
 
 	// 检查是否到达 Boss 生成时间
-	elapsed := time.Since(gameState.StartTime)
+	elapsed := gameState.Elapsed()
 	if elapsed >= gameState.SmallPhaseDuration {
-		if !gameState.BossSpawned {
-			s.SpawnBoss(w, gameState)
-			gameState.BossSpawned = true
+		if !gameState.BossSpawned() {
+			s.SpawnBoss(w, gameState, s.stageBoss(gameState))
 		}
 		return
 	}
@@ -66,6 +77,43 @@ func (s *SpawnSystem) Update(w donburi.World) {
 	s.SpawnEnemies(w, gameState, elapsed)
 }
 
+// updateEndless 无尽模式生成逻辑
+func (s *SpawnSystem) updateEndless(w donburi.World, gameState *components.GameStateData, bossExists bool) {
+	elapsed := gameState.Elapsed()
+
+	// 难度随时间线性递增
+	base := gameState.BaseDifficulty
+	if base <= 0 {
+		base = 1
+	}
+	gameState.DifficultyMul = base * (1 + s.cfg.EndlessDifficultyRamp*elapsed.Minutes())
+
+	// Boss 战期间暂停小怪生成
+	if bossExists {
+		return
+	}
+
+	// 周期 Boss（击败后重新计时）
+	if !gameState.Clock.Before(gameState.NextBossTime) {
+		s.SpawnBoss(w, gameState, s.DefaultBoss())
+		return
+	}
+
+	s.SpawnEnemies(w, gameState, elapsed)
+}
+
+// updateBossRush Boss 连战生成逻辑：上一只被击败且强化选择完成后生成下一只
+func (s *SpawnSystem) updateBossRush(w donburi.World, gameState *components.GameStateData, bossExists bool) {
+	if bossExists || gameState.RushPicking {
+		return
+	}
+	next := gameState.BossKillCount()
+	if next >= len(gameState.BossQueue) || gameState.Clock.Before(gameState.NextBossTime) {
+		return
+	}
+	s.SpawnBoss(w, gameState, gameState.BossQueue[next])
+}
+
 // SpawnEnemies 生成敌机
 func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameStateData, elapsed time.Duration) {
 	// 计算当前波次
@@ -73,7 +121,11 @@ func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameSt
 	if waveIndex < 0 {
 		waveIndex = 0
 	}
-	if waveIndex >= gameState.WaveCount {
+	gameState.WaveReached = max(gameState.WaveReached, waveIndex+1)
+	if gameState.Mode == components.ModeEndless {
+		// 无尽模式波次循环
+		waveIndex %= gameState.WaveCount
+	} else if waveIndex >= gameState.WaveCount {
 		waveIndex = gameState.WaveCount - 1
 	}
 	gameState.WaveIndex = waveIndex
@@ -100,13 +152,18 @@ func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameSt
 	}
 	enemyDelay = time.Duration(float64(enemyDelay) * delayFactor)
 
+	// 自适应难度：调整生成间隔
+	if gameState.Assist.Enabled {
+		enemyDelay = time.Duration(float64(enemyDelay) * gameState.Assist.SpawnIntervalMul)
+	}
+
 	// 应用最小间隔
 	if s.cfg.EnemyDelayFloor > 0 && enemyDelay < s.cfg.EnemyDelayFloor {
 		enemyDelay = s.cfg.EnemyDelayFloor
 	}
 
 	// 检查是否到达生成时间
-	if time.Since(gameState.LastEnemyTime) < enemyDelay {
+	if gameState.Clock.Sub(gameState.LastEnemyTime) < enemyDelay {
 		return
 	}
 
@@ -156,6 +213,9 @@ func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameSt
 	} else {
 		hpScale = math.Max(0.5, diff)
 	}
+	if gameState.Assist.Enabled {
+		hpScale *= gameState.Assist.EnemyHPMul
+	}
 	enemyHP = max(int(math.Ceil(float64(enemyHP)*hpScale)), 1)
 
 	// 计算速度缩放
@@ -169,13 +229,19 @@ func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameSt
 
 	// 生成敌机（根据波次权重随机选择类型）
 	for range batch {
-		x := float64(rand.Intn(760))
+		rng := s.world.Rand
+		x := float64(rng.Intn(760))
 		y := -30.0
-		vx := (float64(rand.Intn(3) - 1)) * speedScale
-		vy := (2 + float64(rand.Intn(2))) * speedScale
-
-		// 根据波次确定敌机类型
-		enemyType := s.selectEnemyType(waveIndex)
+		vx := (float64(rng.Intn(3) - 1)) * speedScale
+		vy := (2 + float64(rng.Intn(2))) * speedScale
+
+		// 根据波次确定敌机类型（战役关卡使用脚本权重）
+		var enemyType string
+		if gameState.Stage != nil && waveIndex < len(gameState.Stage.EnemyWeights) {
+			enemyType = selectWeightedType(rng, gameState.Stage.EnemyWeights[waveIndex])
+		} else {
+			enemyType = s.selectEnemyType(waveIndex)
+		}
 
 		switch enemyType {
 		case "shooter":
@@ -202,12 +268,12 @@ func (s *SpawnSystem) SpawnEnemies(w donburi.World, gameState *components.GameSt
 		gameState.SpawnedCount++
 	}
 
-	gameState.LastEnemyTime = time.Now()
+	gameState.LastEnemyTime = gameState.Clock
 }
 
 // selectEnemyType 根据波次选择敌机类型
 func (s *SpawnSystem) selectEnemyType(waveIndex int) string {
-	roll := rand.Float64() * 100
+	roll := s.world.Rand.Float64() * 100
 
 	// 调整为更早出现不同类型（方便测试）
 	switch waveIndex {
@@ -248,17 +314,68 @@ func (s *SpawnSystem) selectEnemyType(waveIndex int) string {
 	}
 }
 
-// SpawnBoss 生成 Boss
-func (s *SpawnSystem) SpawnBoss(w donburi.World, gameState *components.GameStateData) {
+// enemyTypeOrder 权重抽取时的类型顺序（保证结果与 map 遍历顺序无关）
+var enemyTypeOrder = []string{"basic", "zigzag", "shooter", "tank"}
+
+// selectWeightedType 按权重随机选择敌机类型
+func selectWeightedType(rng *rand.Rand, weights map[string]float64) string {
+	total := 0.0
+	for _, t := range enemyTypeOrder {
+		total += weights[t]
+	}
+	if total <= 0 {
+		return "basic"
+	}
+
+	roll := rng.Float64() * total
+	for _, t := range enemyTypeOrder {
+		roll -= weights[t]
+		if roll < 0 {
+			return t
+		}
+	}
+	return "basic"
+}
+
+// DefaultBoss 标准模式的 Boss 参数
+func (s *SpawnSystem) DefaultBoss() components.BossSpec {
+	return components.BossSpec{
+		NameKey: "boss.warden",
+		HP:      60,
+		Width:   100,
+		Height:  60,
+		Speed:   1.2,
+		Color:   s.cfg.BossColor,
+	}
+}
+
+// stageBoss 返回当前关卡的 Boss（非战役模式为默认 Boss）
+func (s *SpawnSystem) stageBoss(gameState *components.GameStateData) components.BossSpec {
+	if gameState.Stage != nil {
+		return gameState.Stage.Boss
+	}
+	return s.DefaultBoss()
+}
+
+// SpawnBoss 生成 Boss 并记录出现时间
+func (s *SpawnSystem) SpawnBoss(w donburi.World, gameState *components.GameStateData, spec components.BossSpec) {
 	diff := gameState.DifficultyMul
 	if diff <= 0 {
 		diff = 1
 	}
 
 	// Boss 血量随难度缩放
-	bossHP := int(math.Ceil(60 * math.Max(1.0, 1.0+s.cfg.DiffHpLogK*math.Log10(math.Max(1.0, diff)))))
-
-	s.world.CreateBoss(350, 60, 1.2, 0.8, 100, 60, bossHP)
+	bossHP := int(math.Ceil(float64(spec.HP) * math.Max(1.0, 1.0+s.cfg.DiffHpLogK*math.Log10(math.Max(1.0, diff)))))
+
+	boss := s.world.CreateBoss(400-spec.Width/2, 60, spec.Speed, 0.8, spec.Width, spec.Height, bossHP)
+	components.Sprite.Get(boss).Color = spec.Color
+	gameState.StartBoss(spec.NameKey, gameState.Clock)
+	ecs.Publish(s.world.Events, ecs.BossPhaseChanged{
+		NameKey: spec.NameKey,
+		Phase:   ecs.BossPhaseSpawned,
+		X:       400,
+		Y:       60 + spec.Height/2,
+	})
 }
 
 // CountActiveEnemies 计算当前活跃敌机数量（所有类型）
@@ -0,0 +1,282 @@
+package ecs
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"slices"
+
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/component"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// snapshotVersion 快照格式版本，组件结构不兼容地变化时递增
+// （2：计时改为以游戏状态中的模拟时钟为准，不再按拍摄时间平移）
+const snapshotVersion = 2
+
+// Snapshot 战斗世界快照：全部实体及组件、随机源状态，可序列化为 JSON。
+// 所有计时都相对于游戏状态中的模拟时钟，时钟随快照一并恢复
+type Snapshot struct {
+	Version  int
+	Rand     RandState
+	Entities []EntitySnapshot
+}
+
+// RandState 随机源状态（种子与已抽取次数）
+type RandState struct {
+	Seed  int64
+	Draws uint64
+}
+
+// EntitySnapshot 单个实体：原实体 ID（用于还原实体引用）、标签与组件数据
+type EntitySnapshot struct {
+	ID         donburi.Entity
+	Tags       []string                   `json:",omitempty"`
+	Components map[string]json.RawMessage `json:",omitempty"`
+}
+
+// restoreContext 恢复时的实体映射
+type restoreContext struct {
+	entities map[donburi.Entity]donburi.Entity
+}
+
+// entity 将快照中的实体 ID 映射为恢复后的实体，引用已不存在的实体时返回 0
+func (c *restoreContext) entity(e donburi.Entity) donburi.Entity {
+	return c.entities[e]
+}
+
+// componentCodec 单个组件类型的序列化方式
+type componentCodec struct {
+	name   string
+	typ    donburi.IComponentType
+	encode func(entry *donburi.Entry) (json.RawMessage, error)
+	decode func(entry *donburi.Entry, raw json.RawMessage, ctx *restoreContext) error
+}
+
+// codecFor 按组件数据结构直接序列化；fixup 用于还原实体引用
+func codecFor[T any](name string, c *donburi.ComponentType[T], fixup func(*T, *restoreContext)) componentCodec {
+	return componentCodec{
+		name: name,
+		typ:  c,
+		encode: func(entry *donburi.Entry) (json.RawMessage, error) {
+			return json.Marshal(c.Get(entry))
+		},
+		decode: func(entry *donburi.Entry, raw json.RawMessage, ctx *restoreContext) error {
+			var v T
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return err
+			}
+			if fixup != nil {
+				fixup(&v, ctx)
+			}
+			c.SetValue(entry, v)
+			return nil
+		},
+	}
+}
+
+// spriteSnapshot 精灵数据的可序列化形式（颜色统一为 RGBA）
+type spriteSnapshot struct {
+	Color color.RGBA
+	Shape string
+}
+
+// spriteCodec 精灵组件的颜色是接口类型，需转换后序列化
+func spriteCodec() componentCodec {
+	return componentCodec{
+		name: "sprite",
+		typ:  components.Sprite,
+		encode: func(entry *donburi.Entry) (json.RawMessage, error) {
+			sprite := components.Sprite.Get(entry)
+			var c color.RGBA
+			if sprite.Color != nil {
+				c = color.RGBAModel.Convert(sprite.Color).(color.RGBA)
+			}
+			return json.Marshal(spriteSnapshot{Color: c, Shape: sprite.Shape})
+		},
+		decode: func(entry *donburi.Entry, raw json.RawMessage, ctx *restoreContext) error {
+			var v spriteSnapshot
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return err
+			}
+			components.Sprite.SetValue(entry, components.SpriteData{Color: v.Color, Shape: v.Shape})
+			return nil
+		},
+	}
+}
+
+// componentCodecs 战斗世界中可快照的组件；新增战斗组件时需在此登记，否则拍摄快照会报错
+var componentCodecs = []componentCodec{
+	codecFor("position", components.Position, nil),
+	codecFor("velocity", components.Velocity, nil),
+	codecFor("size", components.Size, nil),
+	codecFor("health", components.Health, nil),
+	spriteCodec(),
+	codecFor("player_input", components.PlayerInput, nil),
+	codecFor("fire_skill", components.FireSkill, nil),
+	codecFor("ship_ability", components.ShipAbility, nil),
+	codecFor("enemy_ai", components.EnemyAI, nil),
+	codecFor("homing", components.Homing, func(v *components.HomingData, ctx *restoreContext) {
+		v.TargetEntity = ctx.entity(v.TargetEntity)
+	}),
+	codecFor("penetration", components.Penetration, nil),
+	codecFor("damage", components.Damage, nil),
+	codecFor("lifetime", components.Lifetime, nil),
+	codecFor("particle", components.Particle, nil),
+	codecFor("star", components.Star, nil),
+	codecFor("screen_shake", components.ScreenShake, nil),
+	codecFor("game_state", components.GameState, nil),
+}
+
+// snapshotTags 可快照的标签
+var snapshotTags = map[string]donburi.IComponentType{
+	"player":        tags.Player,
+	"enemy":         tags.Enemy,
+	"boss":          tags.Boss,
+	"bullet":        tags.Bullet,
+	"explosion":     tags.Explosion,
+	"star":          tags.Star,
+	"enemy_shooter": tags.EnemyShooter,
+	"enemy_zigzag":  tags.EnemyZigzag,
+	"enemy_tank":    tags.EnemyTank,
+	"enemy_bullet":  tags.EnemyBullet,
+	"particle":      tags.Particle,
+}
+
+// Snapshot 拍摄当前世界的快照；遇到未登记的组件类型时返回错误
+func (w *World) Snapshot() (*Snapshot, error) {
+	codecs := make(map[component.ComponentTypeId]componentCodec, len(componentCodecs))
+	for _, c := range componentCodecs {
+		codecs[c.typ.Id()] = c
+	}
+	tagNames := make(map[component.ComponentTypeId]string, len(snapshotTags))
+	for name, t := range snapshotTags {
+		tagNames[t.Id()] = name
+	}
+
+	snap := &Snapshot{
+		Version: snapshotVersion,
+		Rand:    RandState{Seed: w.rng.seed, Draws: w.rng.draws},
+	}
+	var err error
+	query.NewQuery(filter.Contains()).Each(w.ECS.World, func(entry *donburi.Entry) {
+		if err != nil {
+			return
+		}
+		es := EntitySnapshot{ID: entry.Entity(), Components: make(map[string]json.RawMessage)}
+		for _, t := range entry.Archetype().ComponentTypes() {
+			if name, ok := tagNames[t.Id()]; ok {
+				es.Tags = append(es.Tags, name)
+				continue
+			}
+			c, ok := codecs[t.Id()]
+			if !ok {
+				err = fmt.Errorf("snapshot: unregistered component %s", t.Name())
+				return
+			}
+			raw, encErr := c.encode(entry)
+			if encErr != nil {
+				err = fmt.Errorf("snapshot: encode %s: %w", c.name, encErr)
+				return
+			}
+			es.Components[c.name] = raw
+		}
+		slices.Sort(es.Tags)
+		snap.Entities = append(snap.Entities, es)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(snap.Entities, func(a, b EntitySnapshot) int {
+		return cmp.Compare(a.ID.Id(), b.ID.Id())
+	})
+	return snap, nil
+}
+
+// Restore 清空当前世界并按快照重建全部实体；实体引用映射到新实体。
+// 模拟时钟随游戏状态恢复，挂起期间不计入对局时间
+func (w *World) Restore(snap *Snapshot) error {
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("snapshot: unsupported version %d", snap.Version)
+	}
+	codecs := make(map[string]componentCodec, len(componentCodecs))
+	for _, c := range componentCodecs {
+		codecs[c.name] = c
+	}
+
+	// 先按快照建好全部实体的组件布局，再解码数据，以便还原实体间引用
+	types := make([][]donburi.IComponentType, len(snap.Entities))
+	for i, es := range snap.Entities {
+		for _, name := range es.Tags {
+			t, ok := snapshotTags[name]
+			if !ok {
+				return fmt.Errorf("snapshot: unknown tag %q", name)
+			}
+			types[i] = append(types[i], t)
+		}
+		for name := range es.Components {
+			c, ok := codecs[name]
+			if !ok {
+				return fmt.Errorf("snapshot: unknown component %q", name)
+			}
+			types[i] = append(types[i], c.typ)
+		}
+	}
+
+	w.clear()
+	ctx := &restoreContext{
+		entities: make(map[donburi.Entity]donburi.Entity, len(snap.Entities)),
+	}
+	entries := make([]*donburi.Entry, len(snap.Entities))
+	for i, es := range snap.Entities {
+		entity := w.ECS.World.Create(types[i]...)
+		ctx.entities[es.ID] = entity
+		entries[i] = w.ECS.World.Entry(entity)
+	}
+	for i, es := range snap.Entities {
+		for name, raw := range es.Components {
+			if err := codecs[name].decode(entries[i], raw, ctx); err != nil {
+				return fmt.Errorf("snapshot: decode %s: %w", name, err)
+			}
+		}
+	}
+
+	w.Seed(snap.Rand.Seed)
+	w.rng.skip(snap.Rand.Draws)
+	return nil
+}
+
+// clear 移除全部实体并丢弃未派发的事件（订阅保持不变）
+func (w *World) clear() {
+	var all []donburi.Entity
+	query.NewQuery(filter.Contains()).Each(w.ECS.World, func(entry *donburi.Entry) {
+		all = append(all, entry.Entity())
+	})
+	for _, e := range all {
+		w.ECS.World.Remove(e)
+	}
+	w.Events.queue = nil
+}
+
+// Encode 将快照序列化为 JSON
+func (s *Snapshot) Encode() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// DecodeSnapshot 从 JSON 解析快照
+func DecodeSnapshot(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("snapshot: unsupported version %d", s.Version)
+	}
+	return &s, nil
+}
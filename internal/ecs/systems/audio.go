@@ -0,0 +1,23 @@
+package systems
+
+import (
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/sound"
+)
+
+// AudioSystem 音效系统：订阅战斗事件播放音效
+type AudioSystem struct{}
+
+// NewAudioSystem 创建音效系统并订阅命中、受伤与射击事件
+func NewAudioSystem(world *ecs.World) *AudioSystem {
+	ecs.Subscribe(world.Events, func(ecs.EnemyHit) {
+		sound.PlayHit()
+	})
+	ecs.Subscribe(world.Events, func(ecs.PlayerDamaged) {
+		sound.PlayHit()
+	})
+	ecs.Subscribe(world.Events, func(ecs.BulletFired) {
+		sound.PlayShoot()
+	})
+	return &AudioSystem{}
+}
@@ -0,0 +1,126 @@
+package systems
+
+import (
+	"image"
+
+	"spacebattle/internal/config"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// RushUpgrade Boss 连战两场之间可选的强化
+type RushUpgrade struct {
+	Key   string
+	Apply func(fire *components.FireSkillData, health *components.HealthData)
+}
+
+// rushUpgrades 强化池（每次随机提供 rushChoiceCount 项）
+var rushUpgrades = []RushUpgrade{
+	{Key: "rush.damage", Apply: func(fire *components.FireSkillData, health *components.HealthData) {
+		fire.BulletDamage++
+	}},
+	{Key: "rush.fire_rate", Apply: func(fire *components.FireSkillData, health *components.HealthData) {
+		fire.FireRateHz += 1.0
+		fire.ShotDelay = ecs.ComputeShotDelay(fire.FireRateHz)
+	}},
+	{Key: "rush.bullets", Apply: func(fire *components.FireSkillData, health *components.HealthData) {
+		fire.BulletsPerShot++
+		fire.SpreadDeg += 2
+	}},
+	{Key: "rush.bullet_speed", Apply: func(fire *components.FireSkillData, health *components.HealthData) {
+		fire.BulletSpeed += 2
+	}},
+	{Key: "rush.repair", Apply: func(fire *components.FireSkillData, health *components.HealthData) {
+		health.Max++
+		health.Current++
+	}},
+}
+
+// 强化选择的数量与行布局
+const (
+	rushChoiceCount = 3
+	rushChoiceTop   = 300
+	rushChoiceStep  = 36
+)
+
+// BossRushSystem Boss 连战系统：处理两场之间的强化选择
+type BossRushSystem struct {
+	world *ecs.World
+	cfg   *config.Config
+}
+
+// NewBossRushSystem 创建 Boss 连战系统
+func NewBossRushSystem(world *ecs.World) *BossRushSystem {
+	return &BossRushSystem{
+		world: world,
+		cfg:   config.DefaultConfig(),
+	}
+}
+
+// Update 强化选择期间处理输入（战斗暂停），选定后安排下一只 Boss
+func (s *BossRushSystem) Update(w donburi.World, input *InputSystem) {
+	var gameState *components.GameStateData
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		gameState = components.GameState.Get(entry)
+	})
+	if gameState == nil || !gameState.RushPicking {
+		return
+	}
+
+	if len(gameState.RushChoices) == 0 {
+		s.offer(gameState)
+	}
+
+	// 点击选项直接选定（行布局与 RenderSystem.DrawRushPick 一致）
+	if x, y, ok := input.inputManager.JustTapped(); ok {
+		for i, key := range gameState.RushChoices {
+			if image.Pt(x, y).In(rowRect(rushChoiceTop+i*rushChoiceStep, rushChoiceStep)) {
+				s.choose(w, gameState, key)
+				return
+			}
+		}
+	}
+
+	// 仅回车确认，避免连按空格射击时误选
+	count := len(gameState.RushChoices)
+	switch {
+	case input.IsGMUpPressed():
+		gameState.RushSelection = (gameState.RushSelection + count - 1) % count
+	case input.IsGMDownPressed():
+		gameState.RushSelection = (gameState.RushSelection + 1) % count
+	case input.inputManager.IsKeyJustPressed(ebiten.KeyEnter):
+		s.choose(w, gameState, gameState.RushChoices[gameState.RushSelection])
+	}
+}
+
+// offer 从强化池中随机抽取不重复的选项
+func (s *BossRushSystem) offer(gameState *components.GameStateData) {
+	order := s.world.Rand.Perm(len(rushUpgrades))
+	gameState.RushChoices = gameState.RushChoices[:0]
+	for _, idx := range order[:min(rushChoiceCount, len(order))] {
+		gameState.RushChoices = append(gameState.RushChoices, rushUpgrades[idx].Key)
+	}
+	gameState.RushSelection = 0
+}
+
+// choose 对所有存活玩家应用强化并结束选择
+func (s *BossRushSystem) choose(w donburi.World, gameState *components.GameStateData, key string) {
+	for _, upgrade := range rushUpgrades {
+		if upgrade.Key != key {
+			continue
+		}
+		query.NewQuery(filter.Contains(tags.Player, components.FireSkill, components.Health)).Each(w, func(entry *donburi.Entry) {
+			upgrade.Apply(components.FireSkill.Get(entry), components.Health.Get(entry))
+		})
+	}
+
+	gameState.RushPicking = false
+	gameState.RushChoices = nil
+	gameState.NextBossTime = gameState.Clock.Add(s.cfg.BossRushBreak)
+}
@@ -1,9 +1,14 @@
 package systems
 
 import (
+	"image"
+	"math"
+
+	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/ecs/tags"
 	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
 	"spacebattle/internal/utils"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -15,13 +20,105 @@ import (
 // InputSystem 输入处理系统
 type InputSystem struct {
 	inputManager *utils.InputManager
+	controlMode  string
+	playerCount  int
+
+	// 触摸拖拽状态（指针模式）
+	dragging   bool
+	dragTouch  ebiten.TouchID
+	dragStartX float64
+	dragStartY float64
+	shipStartX float64
+	shipStartY float64
+}
+
+// keyBinding 战斗按键绑定
+type keyBinding struct {
+	up, down, left, right, fire []ebiten.Key
+}
+
+var (
+	// soloBinding 单人：方向键与 WASD 均可移动，空格射击
+	soloBinding = keyBinding{
+		up:    []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyW},
+		down:  []ebiten.Key{ebiten.KeyArrowDown, ebiten.KeyS},
+		left:  []ebiten.Key{ebiten.KeyArrowLeft, ebiten.KeyA},
+		right: []ebiten.Key{ebiten.KeyArrowRight, ebiten.KeyD},
+		fire:  []ebiten.Key{ebiten.KeySpace},
+	}
+	// coopBindings 合作：1P 使用 WASD + 空格，2P 使用方向键 + 回车/右 Ctrl
+	coopBindings = [2]keyBinding{
+		{
+			up:    []ebiten.Key{ebiten.KeyW},
+			down:  []ebiten.Key{ebiten.KeyS},
+			left:  []ebiten.Key{ebiten.KeyA},
+			right: []ebiten.Key{ebiten.KeyD},
+			fire:  []ebiten.Key{ebiten.KeySpace},
+		},
+		{
+			up:    []ebiten.Key{ebiten.KeyArrowUp},
+			down:  []ebiten.Key{ebiten.KeyArrowDown},
+			left:  []ebiten.Key{ebiten.KeyArrowLeft},
+			right: []ebiten.Key{ebiten.KeyArrowRight},
+			fire:  []ebiten.Key{ebiten.KeyEnter, ebiten.KeyControlRight},
+		},
+	}
+)
+
+// anyPressed 检查任意按键是否按下
+func anyPressed(keys []ebiten.Key) bool {
+	for _, k := range keys {
+		if ebiten.IsKeyPressed(k) {
+			return true
+		}
+	}
+	return false
 }
 
+// MenuTapAction 菜单点击动作
+type MenuTapAction int
+
+const (
+	MenuTapNone    MenuTapAction = iota
+	MenuTapItem                  // 点击了某个选项
+	MenuTapPrev                  // 点击了向前切换区域
+	MenuTapNext                  // 点击了向后切换区域
+	MenuTapConfirm               // 点击了确认区域
+)
+
 // NewInputSystem 创建输入系统
 func NewInputSystem() *InputSystem {
 	return &InputSystem{
 		inputManager: utils.NewInputManager(),
+		controlMode:  progress.ControlModeKeyboard,
+		playerCount:  1,
+	}
+}
+
+// SetPlayerCount 设置战斗玩家数量（2 人时使用合作按键绑定）
+func (s *InputSystem) SetPlayerCount(n int) {
+	s.playerCount = n
+}
+
+// bindingFor 获取指定玩家的按键绑定
+func (s *InputSystem) bindingFor(slot int) keyBinding {
+	if s.playerCount < 2 {
+		return soloBinding
+	}
+	if slot < 0 || slot >= len(coopBindings) {
+		slot = 0
 	}
+	return coopBindings[slot]
+}
+
+// SetControlMode 设置战斗控制方式
+func (s *InputSystem) SetControlMode(mode string) {
+	s.controlMode = mode
+}
+
+// ControlMode 获取当前控制方式
+func (s *InputSystem) ControlMode() string {
+	return s.controlMode
 }
 
 // Update 更新输入
@@ -30,7 +127,8 @@ func (s *InputSystem) Update(w donburi.World) {
 }
 
 // ProcessPlayerInput 处理玩家输入（战斗场景）
-func (s *InputSystem) ProcessPlayerInput(w donburi.World) {
+// dt 为本次更新的时间步长（秒），指针模式下用于限制每次更新的最大位移
+func (s *InputSystem) ProcessPlayerInput(w donburi.World, dt float64) {
 	// 查找玩家实体
 	playerQuery := query.NewQuery(
 		filter.Contains(tags.Player, components.Position, components.Velocity, components.PlayerInput),
@@ -42,43 +140,98 @@ func (s *InputSystem) ProcessPlayerInput(w donburi.World) {
 		input := components.PlayerInput.Get(entry)
 		size := components.Size.Get(entry)
 
-		// 重置速度
-		vel.VX = 0
-		vel.VY = 0
+		binding := s.bindingFor(input.Slot)
+		input.Fire = anyPressed(binding.fire)
 
-		// 处理移动输入
-		if ebiten.IsKeyPressed(ebiten.KeyArrowUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
-			vel.VY = -input.Speed
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
-			vel.VY = input.Speed
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-			vel.VX = -input.Speed
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyArrowRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-			vel.VX = input.Speed
+		// 指针模式只作用于 1P
+		if s.controlMode == progress.ControlModePointer && input.Slot == 0 {
+			input.Up, input.Down, input.Left, input.Right = false, false, false, false
+			vel.VX = 0
+			vel.VY = 0
+			s.processPointerMove(pos, vel, size, input.Speed, dt)
+			if s.inputManager.IsPointerPressed() {
+				input.Fire = true
+			}
+		} else {
+			// 按键写入移动意图，与机器人共用同一转换
+			input.Up = anyPressed(binding.up)
+			input.Down = anyPressed(binding.down)
+			input.Left = anyPressed(binding.left)
+			input.Right = anyPressed(binding.right)
+			ecs.SteerPlayer(vel, input)
 		}
 
 		// 边界限制
-		if pos.X < 0 {
-			pos.X = 0
-		}
-		if pos.X+size.Width > 800 {
-			pos.X = 800 - size.Width
-		}
-		if pos.Y < 0 {
-			pos.Y = 0
+		ecs.ClampToScreen(pos, size)
+	})
+}
+
+// processPointerMove 指针模式移动：鼠标按住时战机中心跟随指针，触摸时跟随拖拽位移
+// speed 为每 tick 的最大位移，按 dt 换算为本次更新的步长，速度写回为每秒
+func (s *InputSystem) processPointerMove(pos *components.PositionData, vel *components.VelocityData, size *components.SizeData, speed, dt float64) {
+	targetX, targetY, ok := s.pointerTarget(pos, size)
+	if !ok {
+		return
+	}
+
+	dx := targetX - (pos.X + size.Width/2)
+	dy := targetY - (pos.Y + size.Height/2)
+	dist := math.Hypot(dx, dy)
+	if dist < 0.5 {
+		return
+	}
+
+	maxStep := ecs.PerSecond(speed) * dt
+	if dist > maxStep {
+		dx = dx / dist * maxStep
+		dy = dy / dist * maxStep
+	}
+	vel.VX = dx / dt
+	vel.VY = dy / dt
+}
+
+// pointerTarget 计算战机中心的目标位置
+func (s *InputSystem) pointerTarget(pos *components.PositionData, size *components.SizeData) (float64, float64, bool) {
+	touches := s.inputManager.TouchIDs()
+	if len(touches) > 0 {
+		// 触摸：记录按下时的触点与战机位置，之后按拖拽位移移动
+		if !s.dragging || !containsTouch(touches, s.dragTouch) {
+			s.dragging = true
+			s.dragTouch = touches[0]
+			tx, ty := s.inputManager.TouchPosition(s.dragTouch)
+			s.dragStartX, s.dragStartY = float64(tx), float64(ty)
+			s.shipStartX = pos.X + size.Width/2
+			s.shipStartY = pos.Y + size.Height/2
 		}
-		if pos.Y+size.Height > 600 {
-			pos.Y = 600 - size.Height
+		tx, ty := s.inputManager.TouchPosition(s.dragTouch)
+		return s.shipStartX + float64(tx) - s.dragStartX, s.shipStartY + float64(ty) - s.dragStartY, true
+	}
+	s.dragging = false
+
+	// 鼠标：按住左键时直接跟随指针
+	if s.inputManager.IsMousePressed() {
+		mx, my := s.inputManager.CursorPosition()
+		return float64(mx), float64(my), true
+	}
+	return 0, 0, false
+}
+
+// containsTouch 检查触点是否仍然存在
+func containsTouch(ids []ebiten.TouchID, id ebiten.TouchID) bool {
+	for _, t := range ids {
+		if t == id {
+			return true
 		}
-	})
+	}
+	return false
 }
 
-// IsFirePressed 检查是否按下射击键
+// IsFirePressed 检查 1P 是否按下射击键（指针模式下按住即自动射击）
 func (s *InputSystem) IsFirePressed() bool {
-	return ebiten.IsKeyPressed(ebiten.KeySpace)
+	if s.controlMode == progress.ControlModePointer && s.inputManager.IsPointerPressed() {
+		return true
+	}
+	return anyPressed(s.bindingFor(0).fire)
 }
 
 // IsGMTogglePressed 检查是否按下 GM 面板切换键
@@ -116,11 +269,76 @@ func (s *InputSystem) IsRestartPressed() bool {
 	return s.inputManager.IsKeyJustPressed(ebiten.KeyR)
 }
 
+// IsSlowMotionPressed 检查是否按下慢动作切换键（练习模式）
+func (s *InputSystem) IsSlowMotionPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyT)
+}
+
+// IsSystemStatsPressed 检查是否按下系统耗时面板切换键
+func (s *InputSystem) IsSystemStatsPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyF3)
+}
+
+// IsRewindPressed 检查是否按下回溯键（练习模式）
+func (s *InputSystem) IsRewindPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyF5)
+}
+
+// IsResumePressed 检查是否按下继续挂起出击键（主菜单）
+func (s *InputSystem) IsResumePressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyC)
+}
+
+// IsPresetsPressed 检查是否按下加点方案面板键（升级界面）
+func (s *InputSystem) IsPresetsPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyP)
+}
+
+// IsUndoPressed 检查是否按下撤销键（升级界面撤销最近一次加点）
+func (s *InputSystem) IsUndoPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyU)
+}
+
+// IsHistoryPressed 检查是否按下功勋流水键（升级界面）
+func (s *InputSystem) IsHistoryPressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyH)
+}
+
+// PresetHotkey 返回本帧按下的方案数字键（1-9），没有时返回 0
+func (s *InputSystem) PresetHotkey() int {
+	for i := 1; i <= 9; i++ {
+		if s.inputManager.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i-1)) {
+			return i
+		}
+	}
+	return 0
+}
+
 // IsEscapePressed 检查是否按下 ESC 键
 func (s *InputSystem) IsEscapePressed() bool {
 	return s.inputManager.IsKeyJustPressed(ebiten.KeyEscape)
 }
 
+// IsRenamePressed 检查是否按下重命名键（档案管理）
+func (s *InputSystem) IsRenamePressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyF2)
+}
+
+// IsDeletePressed 检查是否按下删除键（档案管理）
+func (s *InputSystem) IsDeletePressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyDelete)
+}
+
+// IsBackspacePressed 检查是否按下退格键（文本输入）
+func (s *InputSystem) IsBackspacePressed() bool {
+	return s.inputManager.IsKeyJustPressed(ebiten.KeyBackspace)
+}
+
+// TypedChars 返回本帧输入的字符（文本输入）
+func (s *InputSystem) TypedChars() []rune {
+	return ebiten.AppendInputChars(nil)
+}
+
 // ProcessMenuInput 处理菜单输入
 func (s *InputSystem) ProcessMenuInput(w donburi.World) {
 	// 查找菜单状态实体
@@ -161,6 +379,25 @@ func (s *InputSystem) ProcessMenuInput(w donburi.World) {
 			state.Confirmed = true
 		}
 
+		// 处理点击/触摸
+		switch action, idx := s.MenuTap(state); action {
+		case MenuTapItem:
+			state.SelectedIndex = idx
+			state.Confirmed = true
+		case MenuTapPrev:
+			state.SelectedIndex--
+			if state.SelectedIndex < 0 {
+				state.SelectedIndex = state.OptionCount - 1
+			}
+		case MenuTapNext:
+			state.SelectedIndex++
+			if state.SelectedIndex >= state.OptionCount {
+				state.SelectedIndex = 0
+			}
+		case MenuTapConfirm:
+			state.Confirmed = true
+		}
+
 		// 处理语言切换
 		if s.inputManager.IsKeyJustPressed(ebiten.KeyL) {
 			currentLang := i18n.GetCurrentLanguage()
@@ -181,3 +418,26 @@ func (s *InputSystem) IsConfirmed() bool {
 	return s.inputManager.IsKeyJustPressed(ebiten.KeyEnter) || s.inputManager.IsKeyJustPressed(ebiten.KeySpace)
 }
 
+// MenuTap 检测本帧对菜单的点击（鼠标左键或触摸），点击区域由 MenuSystem 绘制时记录
+func (s *InputSystem) MenuTap(state *components.MenuStateData) (MenuTapAction, int) {
+	x, y, ok := s.inputManager.JustTapped()
+	if !ok {
+		return MenuTapNone, 0
+	}
+	pt := image.Pt(x, y)
+
+	switch {
+	case pt.In(state.PrevRect):
+		return MenuTapPrev, 0
+	case pt.In(state.NextRect):
+		return MenuTapNext, 0
+	case pt.In(state.ConfirmRect):
+		return MenuTapConfirm, 0
+	}
+	for i, r := range state.ItemRects {
+		if pt.In(r) {
+			return MenuTapItem, i
+		}
+	}
+	return MenuTapNone, 0
+}
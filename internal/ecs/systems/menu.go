@@ -2,14 +2,18 @@ package systems
 
 import (
 	"fmt"
+	"image"
 	"image/color"
+	"time"
 
 	"spacebattle/internal/config"
 	"spacebattle/internal/ecs/components"
 	"spacebattle/internal/fonts"
 	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/filter"
 	"github.com/yohamta/donburi/query"
@@ -23,6 +27,11 @@ func NewMenuSystem() *MenuSystem {
 	return &MenuSystem{}
 }
 
+// DrawResumeHint 主菜单提示可继续挂起的出击
+func (s *MenuSystem) DrawResumeHint(screen *ebiten.Image) {
+	fonts.DrawTextCentered(screen, i18n.T("menu.resume_hint"), 0, 228, 800, config.DefaultConfig().UIHighlightColor)
+}
+
 // DrawMainMenu 绘制主菜单
 func (s *MenuSystem) DrawMainMenu(w donburi.World, screen *ebiten.Image) {
 	cfg := config.DefaultConfig()
@@ -42,11 +51,16 @@ func (s *MenuSystem) DrawMainMenu(w donburi.World, screen *ebiten.Image) {
 	// 绘制标题
 	fonts.DrawTextCenteredLarge(screen, i18n.T("menu.title"), 0, 150, 800, color.White)
 	fonts.DrawTextCentered(screen, "===================", 0, 180, 800, cfg.UIHintColor)
+	profileText := fmt.Sprintf("%s: %s", i18n.T("profile.label"), progress.CurrentProfile().Name)
+	fonts.DrawTextCentered(screen, profileText, 0, 210, 800, cfg.UIMeritColor)
 
 	// 绘制菜单选项
-	options := []string{i18n.T("menu.start"), i18n.T("menu.exit")}
+	options := []string{i18n.T("menu.start"), i18n.T("menu.daily"), i18n.T("menu.talents"), i18n.T("menu.stats"),
+		i18n.T("menu.profiles"), i18n.T("menu.settings"), i18n.T("menu.exit")}
+	menuState.ItemRects = menuState.ItemRects[:0]
 	for i, option := range options {
-		y := 250 + i*50
+		y := 250 + i*36
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 36))
 		if i == menuState.SelectedIndex {
 			fonts.DrawText(screen, "> "+option, 300, y, cfg.UIHighlightColor)
 		} else {
@@ -99,9 +113,35 @@ func (s *MenuSystem) DrawShipSelect(w donburi.World, screen *ebiten.Image) {
 	fonts.DrawTextCentered(screen, fmt.Sprintf("%s: %d", i18n.T("select.lives"), template.Lives), 0, y, 800, color.White)
 	y += 24
 	fonts.DrawTextCentered(screen, fmt.Sprintf("%s: %s", i18n.T("select.passive"), i18n.T(template.PassiveKey)), 0, y, 800, color.White)
+	y += 24
+	fonts.DrawTextCentered(screen, fmt.Sprintf("%s: %s", i18n.T("select.ability"), i18n.T("ability."+template.AbilityType)), 0, y, 800, color.White)
+
+	// 解锁状态：功勋购买或成就解锁
+	y += 34
+	meritText := fmt.Sprintf("%s: %d", i18n.T("common.merits"), menuState.AvailableMerits)
+	switch {
+	case !template.Locked:
+		fonts.DrawTextCentered(screen, meritText, 0, y, 800, cfg.UIMeritColor)
+	case template.UnlockAchievement != "":
+		lockText := fmt.Sprintf("[%s] %s", i18n.T("select.locked"), i18n.T("achievement."+template.UnlockAchievement))
+		fonts.DrawTextCentered(screen, lockText, 0, y, 800, cfg.UIGreyTextColor)
+	default:
+		lockText := fmt.Sprintf("[%s] %s: %d  (%s)", i18n.T("select.locked"), i18n.T("select.buy"), template.UnlockMerits, meritText)
+		lockColor := cfg.UIGreyTextColor
+		if menuState.AvailableMerits >= template.UnlockMerits {
+			lockColor = cfg.UIMeritColor
+		}
+		fonts.DrawTextCentered(screen, lockText, 0, y, 800, lockColor)
+	}
 
 	// 左右指示
-	fonts.DrawTextCentered(screen, "<   >", 0, 320, 800, cfg.UIHighlightColor)
+	fonts.DrawTextCentered(screen, "<   >", 0, 400, 800, cfg.UIHighlightColor)
+
+	// 点击区域：左右两侧切换，中间确认（未解锁时为购买）
+	menuState.PrevRect = image.Rect(0, 180, 300, 410)
+	menuState.NextRect = image.Rect(500, 180, 800, 410)
+	menuState.ItemRects = make([]image.Rectangle, len(menuState.ShipTemplates))
+	menuState.ItemRects[menuState.SelectedIndex] = image.Rect(300, 180, 500, 410)
 }
 
 // DrawUpgrade 绘制升级界面（需要从场景传递额外信息）
@@ -178,7 +218,9 @@ func (s *MenuSystem) DrawUpgradeWithDetails(screen *ebiten.Image, menuState *com
 
 	// 显示升级选项
 	y := 220
+	menuState.ItemRects = menuState.ItemRects[:0]
 	for i, item := range items {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 28))
 		prefix := "  "
 		col := cfg.UITextColor
 		if i == menuState.SelectedIndex {
@@ -187,12 +229,158 @@ func (s *MenuSystem) DrawUpgradeWithDetails(screen *ebiten.Image, menuState *com
 		}
 
 		line := fmt.Sprintf("%s%s: %s  (%s: %d)", prefix, i18n.T(item.Key), item.Value, i18n.T("upgrade.cost"), item.Cost)
+		if item.Note != "" {
+			line = fmt.Sprintf("%s%s: %s  (%s)", prefix, i18n.T(item.Key), item.Value, item.Note)
+		}
 		fonts.DrawTextCentered(screen, line, 0, y, 800, col)
 		y += 28
 	}
 
 	// 控制提示
 	fonts.DrawTextCentered(screen, i18n.T("common.confirm"), 0, 500, 800, cfg.UIHintColor)
+	menuState.ConfirmRect = rowRect(500, 30)
+	menuState.ItemRects = append(menuState.ItemRects[:0], rowRect(410, 40))
+}
+
+// DrawUpgradeStatus 绘制升级界面底部的加点方案快捷键提示与最近一次操作的结果
+func (s *MenuSystem) DrawUpgradeStatus(screen *ebiten.Image, messageKey string, isError bool) {
+	cfg := config.DefaultConfig()
+	fonts.DrawTextCentered(screen, i18n.T("upgrade.presets_hint"), 0, 540, 800, cfg.UIHintColor)
+	if messageKey != "" {
+		col := cfg.UITextColor
+		if isError {
+			col = cfg.UIMeritColor
+		}
+		fonts.DrawTextCentered(screen, i18n.T(messageKey), 0, 570, 800, col)
+	}
+}
+
+// UpgradePresetRow 加点方案列表中的一行
+type UpgradePresetRow struct {
+	Name  string
+	Net   int  // 切换到该方案的净花费（负数为净退还）
+	Valid bool // 能否切换（含无法购买的升级时为 false）
+}
+
+// UpgradePresetsView 加点方案面板状态
+type UpgradePresetsView struct {
+	Rows          []UpgradePresetRow
+	Code          string // 当前加点的加点码
+	Editing       bool   // 正在输入
+	Importing     bool   // 输入的是加点码（否则为方案名称）
+	EditText      string // 已输入的文本
+	ConfirmDelete bool   // 等待再次按删除确认
+	MessageKey    string // 最近一次操作的提示
+	MessageError  bool
+}
+
+// DrawUpgradePresets 绘制加点方案面板：方案列表、保存、导入与当前加点码
+func (s *MenuSystem) DrawUpgradePresets(screen *ebiten.Image, menuState *components.MenuStateData, view UpgradePresetsView) {
+	cfg := config.DefaultConfig()
+	screen.Fill(cfg.UIBackgroundColor)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("preset.title"), 0, 80, 800, color.White)
+	fonts.DrawTextCentered(screen, i18n.T("preset.hint"), 0, 120, 800, cfg.UIHintColor)
+	meritText := fmt.Sprintf("%s: %d", i18n.T("common.merits"), menuState.AvailableMerits)
+	fonts.DrawTextCentered(screen, meritText, 0, 145, 800, cfg.UIMeritColor)
+
+	// 方案列表（数字键即序号），末尾为保存、导入与返回
+	options := make([]string, 0, len(view.Rows)+3)
+	for i, row := range view.Rows {
+		detail := i18n.T("preset.unavailable")
+		switch {
+		case !row.Valid:
+		case row.Net >= 0:
+			detail = fmt.Sprintf(i18n.T("preset.net_cost"), row.Net)
+		default:
+			detail = fmt.Sprintf(i18n.T("preset.net_refund"), -row.Net)
+		}
+		options = append(options, fmt.Sprintf("%d. %s  (%s)", i+1, row.Name, detail))
+	}
+	options = append(options, "+ "+i18n.T("preset.save"), i18n.T("preset.import"), i18n.T("settings.back"))
+
+	y := 180
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, option := range options {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 28))
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+option, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+option, 0, y, 800, cfg.UITextColor)
+		}
+		y += 28
+	}
+
+	// 名称或加点码输入框
+	if view.Editing {
+		prompt := i18n.T("preset.name_prompt")
+		if view.Importing {
+			prompt = i18n.T("preset.code_prompt")
+		}
+		fonts.DrawTextCentered(screen, prompt, 0, 510, 800, cfg.UIHintColor)
+		fonts.DrawTextCentered(screen, view.EditText+"_", 0, 535, 800, cfg.UIHighlightColor)
+	} else if view.ConfirmDelete {
+		fonts.DrawTextCentered(screen, i18n.T("preset.confirm_delete"), 0, 520, 800, cfg.UIMeritColor)
+	}
+	if view.MessageKey != "" {
+		col := cfg.UITextColor
+		if view.MessageError {
+			col = cfg.UIMeritColor
+		}
+		fonts.DrawTextCentered(screen, i18n.T(view.MessageKey), 0, 560, 800, col)
+	}
+	fonts.DrawTextCentered(screen, fmt.Sprintf(i18n.T("preset.code"), view.Code), 0, 585, 800, cfg.UIHintColor)
+}
+
+// MeritHistoryRow 功勋流水中的一行
+type MeritHistoryRow struct {
+	Time      time.Time
+	SourceKey string // 来源的 i18n 键
+	Detail    string // 关联对象（升级名称、模式、难度等）
+	Amount    int
+	Balance   int
+	Undone    bool // 已被撤销
+}
+
+// MeritHistoryView 功勋流水面板
+type MeritHistoryView struct {
+	Rows     []MeritHistoryRow
+	Merits   int
+	Mismatch bool // 档案余额与流水合计不一致
+}
+
+// DrawMeritHistory 绘制最近的功勋流水，新的在上
+func (s *MenuSystem) DrawMeritHistory(screen *ebiten.Image, view MeritHistoryView) {
+	cfg := config.DefaultConfig()
+	screen.Fill(cfg.UIBackgroundColor)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("ledger.title"), 0, 60, 800, color.White)
+	meritText := fmt.Sprintf("%s: %d", i18n.T("common.merits"), view.Merits)
+	fonts.DrawTextCentered(screen, meritText, 0, 105, 800, cfg.UIMeritColor)
+	if view.Mismatch {
+		fonts.DrawTextCentered(screen, i18n.T("ledger.mismatch"), 0, 130, 800, cfg.UIMeritColor)
+	}
+
+	y := 165
+	if len(view.Rows) == 0 {
+		fonts.DrawTextCentered(screen, i18n.T("ledger.empty"), 0, y, 800, cfg.UIGreyTextColor)
+	}
+	for _, row := range view.Rows {
+		source := i18n.T(row.SourceKey)
+		if row.Detail != "" {
+			source += " (" + row.Detail + ")"
+		}
+		line := fmt.Sprintf("%s  %s  %+d  → %d", row.Time.Format("01-02 15:04"), source, row.Amount, row.Balance)
+		col := cfg.UITextColor
+		if row.Undone {
+			col = cfg.UIGreyTextColor
+			line += "  " + i18n.T("ledger.undone_mark")
+		}
+		fonts.DrawTextCentered(screen, line, 0, y, 800, col)
+		y += 24
+	}
+
+	fonts.DrawTextCentered(screen, i18n.T("ledger.hint"), 0, 570, 800, cfg.UIHintColor)
 }
 
 // UpgradeItem 升级项信息
@@ -200,6 +388,7 @@ type UpgradeItem struct {
 	Key   string
 	Value string
 	Cost  int
+	Note  string // 不可购买的原因（非空时代替成本显示）
 }
 
 // DrawDeploy 绘制出征界面
@@ -248,7 +437,8 @@ func (s *MenuSystem) DrawDeploy(w donburi.World, screen *ebiten.Image) {
 }
 
 // DrawDeployWithDetails 绘制详细的出征界面（连续可调难度）
-func (s *MenuSystem) DrawDeployWithDetails(screen *ebiten.Image, menuState *components.MenuStateData, difficulty float64, cost int) {
+// rows 为难度下方的选项行，focus 为聚焦的选项行（-1 表示聚焦难度），descKey 为当前模式说明
+func (s *MenuSystem) DrawDeployWithDetails(screen *ebiten.Image, menuState *components.MenuStateData, difficulty float64, cost int, rows []SettingItem, focus int, descKey string) {
 	cfg := config.DefaultConfig()
 	// 绘制背景
 	screen.Fill(cfg.UIBackgroundColor)
@@ -272,12 +462,544 @@ func (s *MenuSystem) DrawDeployWithDetails(screen *ebiten.Image, menuState *comp
 		diffColor = cfg.UIGameOverColor
 		diffText = fmt.Sprintf("x%.2f  (%s: %d) - INSUFFICIENT MERITS!", difficulty, i18n.T("upgrade.cost"), cost)
 	}
+	if focus < 0 {
+		diffText = "> " + diffText + " <"
+	}
 	fonts.DrawTextCenteredLarge(screen, diffText, 0, 260, 800, diffColor)
 
 	// 控制提示
 	fonts.DrawTextCentered(screen, "Right: Increase  |  Left: MAX Challenge", 0, 330, 800, cfg.UIHighlightColor)
 	fonts.DrawTextCentered(screen, "(Difficulty capped by available merits)", 0, 355, 800, cfg.UIGreyTextColor)
 
+	// 选项行（模式及练习设置）
+	y := 395
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, row := range rows {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 30))
+		line := fmt.Sprintf("%s: < %s >", i18n.T(row.Key), row.Value)
+		if i == focus {
+			fonts.DrawTextCentered(screen, "> "+line, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+line, 0, y, 800, color.White)
+		}
+		y += 30
+	}
+	fonts.DrawTextCentered(screen, i18n.T(descKey), 0, y+5, 800, cfg.UIGreyTextColor)
+
 	// 确认提示
-	fonts.DrawTextCentered(screen, i18n.T("common.confirm"), 0, 500, 800, cfg.UIHintColor)
+	fonts.DrawTextCentered(screen, i18n.T("common.confirm"), 0, 530, 800, cfg.UIHintColor)
+
+	// 点击区域：难度左侧切换极限挑战，右侧增加难度
+	menuState.PrevRect = image.Rect(0, 230, 400, 280)
+	menuState.NextRect = image.Rect(400, 230, 800, 280)
+	menuState.ConfirmRect = rowRect(530, 30)
+}
+
+// SettingItem 设置项信息
+type SettingItem struct {
+	Key   string
+	Value string
+}
+
+// DrawSettings 绘制设置界面
+func (s *MenuSystem) DrawSettings(screen *ebiten.Image, menuState *components.MenuStateData, items []SettingItem) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	// 绘制标题
+	fonts.DrawTextCenteredLarge(screen, i18n.T("settings.title"), 0, 100, 800, color.White)
+	fonts.DrawTextCentered(screen, i18n.T("settings.hint"), 0, 160, 800, cfg.UIHintColor)
+
+	// 显示设置项（最后一项为返回）
+	y := 240
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, item := range items {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 40))
+
+		line := i18n.T(item.Key)
+		if item.Value != "" {
+			line = fmt.Sprintf("%s: < %s >", line, item.Value)
+		}
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+line, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+line, 0, y, 800, cfg.UITextColor)
+		}
+		y += 40
+	}
+}
+
+// DailyBoardRow 每日排行榜中的一行
+type DailyBoardRow struct {
+	Score   int
+	Kills   int
+	Elapsed time.Duration
+	Victory bool
+}
+
+// DailyInfo 每日挑战界面信息
+type DailyInfo struct {
+	Date        string
+	ShipNameKey string
+	Difficulty  float64
+	AttemptUsed bool
+	Board       []DailyBoardRow
+}
+
+// DrawDaily 绘制每日挑战界面
+func (s *MenuSystem) DrawDaily(screen *ebiten.Image, menuState *components.MenuStateData, info DailyInfo) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	// 标题与当日固定配置
+	fonts.DrawTextCenteredLarge(screen, i18n.T("daily.title"), 0, 70, 800, color.White)
+	loadoutText := fmt.Sprintf("%s  |  %s  |  x%.2f", info.Date, i18n.T(info.ShipNameKey), info.Difficulty)
+	fonts.DrawTextCentered(screen, loadoutText, 0, 110, 800, cfg.UIMeritColor)
+
+	// 选项
+	options := []string{i18n.T("daily.scored"), i18n.T("daily.practice"), i18n.T("settings.back")}
+	if info.AttemptUsed {
+		options[0] += "  (" + i18n.T("daily.used") + ")"
+	}
+	y := 170
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, option := range options {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 36))
+
+		var optionColor color.Color = cfg.UITextColor
+		if i == 0 && info.AttemptUsed {
+			optionColor = cfg.UIGreyTextColor
+		}
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+option, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+option, 0, y, 800, optionColor)
+		}
+		y += 36
+	}
+
+	// 当日排行榜
+	y += 20
+	fonts.DrawTextCentered(screen, i18n.T("daily.leaderboard"), 0, y, 800, cfg.UIHintColor)
+	y += 30
+	if len(info.Board) == 0 {
+		fonts.DrawTextCentered(screen, i18n.T("daily.empty"), 0, y, 800, cfg.UIGreyTextColor)
+	}
+	for i, row := range info.Board {
+		result := i18n.T("common.game_over")
+		if row.Victory {
+			result = i18n.T("common.victory")
+		}
+		line := fmt.Sprintf("#%d  %s: %d  %s: %d  %.1fs  %s", i+1, i18n.T("common.score"), row.Score,
+			i18n.T("ability.kills"), row.Kills, row.Elapsed.Seconds(), result)
+		fonts.DrawTextCentered(screen, line, 0, y, 800, cfg.UITextColor)
+		y += 26
+	}
+}
+
+// StageRow 关卡选择列表中的一行
+type StageRow struct {
+	NameKey   string
+	Locked    bool
+	BestGrade string
+	BestScore int
+}
+
+// DrawStageSelect 绘制关卡选择界面
+func (s *MenuSystem) DrawStageSelect(screen *ebiten.Image, menuState *components.MenuStateData, rows []StageRow) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	// 绘制标题
+	fonts.DrawTextCenteredLarge(screen, i18n.T("stage.title"), 0, 100, 800, color.White)
+	fonts.DrawTextCentered(screen, i18n.T("stage.hint"), 0, 160, 800, cfg.UIHintColor)
+
+	// 关卡列表：名称、最佳评级与分数，未解锁显示锁定
+	y := 240
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, row := range rows {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 50))
+
+		line := fmt.Sprintf("%d. %s", i+1, i18n.T(row.NameKey))
+		switch {
+		case row.Locked:
+			line += "  [" + i18n.T("stage.locked") + "]"
+		case row.BestGrade != "":
+			line += fmt.Sprintf("  %s: %s  %s: %d", i18n.T("stage.grade"), row.BestGrade, i18n.T("common.score"), row.BestScore)
+		}
+
+		var lineColor color.Color = cfg.UITextColor
+		if row.Locked {
+			lineColor = cfg.UIGreyTextColor
+		}
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+line, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+line, 0, y, 800, lineColor)
+		}
+		y += 50
+	}
+
+	fonts.DrawTextCentered(screen, i18n.T("common.confirm"), 0, 520, 800, cfg.UIHintColor)
+}
+
+// rowRect 返回以基线 y 绘制、行高为 height 的整行文本点击区域
+func rowRect(y, height int) image.Rectangle {
+	return image.Rect(0, y-height+8, 800, y+8)
+}
+
+// TalentEffectView 天赋节点每级效果
+type TalentEffectView struct {
+	Stat    string
+	PerRank float64
+}
+
+// TalentNodeView 天赋图中的一个节点
+type TalentNodeView struct {
+	NameKey  string
+	Col, Row int
+	Rank     int
+	MaxRank  int
+	Cost     int   // 下一级消耗，0 表示已满级或未解锁
+	Locked   bool  // 前置节点未满足
+	Prereqs  []int // 前置节点索引，用于绘制连线
+	Effects  []TalentEffectView
+}
+
+// 天赋图布局
+const (
+	talentColX0   = 160 // 第一列节点中心 x
+	talentColStep = 240
+	talentRowY0   = 190 // 第一行节点中心 y
+	talentRowStep = 82
+	talentNodeW   = 200
+	talentNodeH   = 56
+)
+
+// talentNodeCenter 节点中心坐标
+func talentNodeCenter(n TalentNodeView) (float32, float32) {
+	return float32(talentColX0 + n.Col*talentColStep), float32(talentRowY0 + n.Row*talentRowStep)
+}
+
+// DrawTalentTree 绘制天赋树界面
+func (s *MenuSystem) DrawTalentTree(screen *ebiten.Image, menuState *components.MenuStateData, nodes []TalentNodeView, spent int) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("talent.title"), 0, 60, 800, color.White)
+	meritText := fmt.Sprintf("%s: %d   %s: %d", i18n.T("common.merits"), menuState.AvailableMerits, i18n.T("talent.spent"), spent)
+	fonts.DrawTextCentered(screen, meritText, 0, 100, 800, cfg.UIMeritColor)
+	fonts.DrawTextCentered(screen, i18n.T("talent.hint"), 0, 130, 800, cfg.UIHintColor)
+
+	// 前置连线
+	for _, n := range nodes {
+		x1, y1 := talentNodeCenter(n)
+		for _, p := range n.Prereqs {
+			x0, y0 := talentNodeCenter(nodes[p])
+			lineColor := cfg.UIGreyTextColor
+			if nodes[p].Rank > 0 {
+				lineColor = cfg.UIHintColor
+			}
+			vector.StrokeLine(screen, x0, y0+talentNodeH/2, x1, y1-talentNodeH/2, 2, lineColor, true)
+		}
+	}
+
+	// 节点：名称、等级与下一级消耗
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, n := range nodes {
+		cx, cy := talentNodeCenter(n)
+		x, y := int(cx)-talentNodeW/2, int(cy)-talentNodeH/2
+		menuState.ItemRects = append(menuState.ItemRects, image.Rect(x, y, x+talentNodeW, y+talentNodeH))
+
+		var textColor color.Color = cfg.UITextColor
+		switch {
+		case n.Locked:
+			textColor = cfg.UIGreyTextColor
+		case n.Rank >= n.MaxRank:
+			textColor = cfg.UIMeritColor
+		}
+		borderColor := textColor
+		if i == menuState.SelectedIndex {
+			borderColor = cfg.UIHighlightColor
+		}
+		vector.StrokeRect(screen, float32(x), float32(y), talentNodeW, talentNodeH, 2, borderColor, true)
+
+		fonts.DrawTextCentered(screen, i18n.T(n.NameKey), x, y+22, talentNodeW, textColor)
+		status := fmt.Sprintf("%d/%d", n.Rank, n.MaxRank)
+		if n.Cost > 0 {
+			status += fmt.Sprintf("  %s: %d", i18n.T("upgrade.cost"), n.Cost)
+		}
+		fonts.DrawTextCentered(screen, status, x, y+46, talentNodeW, textColor)
+	}
+
+	// 选中节点的效果说明
+	if sel := menuState.SelectedIndex; sel >= 0 && sel < len(nodes) {
+		desc := ""
+		for _, e := range nodes[sel].Effects {
+			if desc != "" {
+				desc += ", "
+			}
+			desc += fmt.Sprintf("%s %+g", i18n.T("talent.stat."+e.Stat), e.PerRank)
+		}
+		desc += " / " + i18n.T("talent.per_rank")
+		if nodes[sel].Locked {
+			desc += "  [" + i18n.T("talent.locked") + "]"
+		}
+		fonts.DrawTextCentered(screen, desc, 0, 520, 800, cfg.UITextColor)
+	}
+
+	// 底部按钮：左侧重置，右侧返回
+	fonts.DrawTextCentered(screen, i18n.T("talent.respec"), 0, 565, 400, cfg.UIHintColor)
+	fonts.DrawTextCentered(screen, i18n.T("settings.back"), 400, 565, 400, cfg.UIHintColor)
+	menuState.PrevRect = image.Rect(0, 540, 400, 580)
+	menuState.ConfirmRect = image.Rect(400, 540, 800, 580)
+}
+
+// ProfileRow 档案列表中的一行
+type ProfileRow struct {
+	Name    string
+	Current bool
+}
+
+// ProfilesView 档案管理界面状态
+type ProfilesView struct {
+	Rows          []ProfileRow
+	Editing       bool   // 正在输入名称
+	Renaming      bool   // 输入用于重命名（否则为新建）
+	EditText      string // 已输入的名称
+	ConfirmDelete bool   // 等待再次按删除确认
+	ErrorKey      string // 最近一次操作的错误提示
+}
+
+// DrawProfiles 绘制档案管理界面
+func (s *MenuSystem) DrawProfiles(screen *ebiten.Image, menuState *components.MenuStateData, view ProfilesView) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("profile.title"), 0, 80, 800, color.White)
+	fonts.DrawTextCentered(screen, i18n.T("profile.hint"), 0, 120, 800, cfg.UIHintColor)
+
+	// 档案列表，末尾为新建与返回
+	options := make([]string, 0, len(view.Rows)+2)
+	for _, row := range view.Rows {
+		line := row.Name
+		if row.Current {
+			line += "  (" + i18n.T("profile.current") + ")"
+		}
+		options = append(options, line)
+	}
+	options = append(options, "+ "+i18n.T("profile.new"), i18n.T("settings.back"))
+
+	y := 180
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, option := range options {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 36))
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+option, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+option, 0, y, 800, cfg.UITextColor)
+		}
+		y += 36
+	}
+
+	// 名称输入框
+	if view.Editing {
+		prompt := i18n.T("profile.new_prompt")
+		if view.Renaming {
+			prompt = i18n.T("profile.rename_prompt")
+		}
+		fonts.DrawTextCentered(screen, prompt, 0, 470, 800, cfg.UIHintColor)
+		fonts.DrawTextCentered(screen, view.EditText+"_", 0, 500, 800, cfg.UIHighlightColor)
+	} else if view.ConfirmDelete {
+		fonts.DrawTextCentered(screen, i18n.T("profile.confirm_delete"), 0, 485, 800, cfg.UIMeritColor)
+	}
+	if view.ErrorKey != "" {
+		fonts.DrawTextCentered(screen, i18n.T(view.ErrorKey), 0, 530, 800, cfg.UIMeritColor)
+	}
+
+	// 底部按钮：左侧重命名，右侧删除
+	fonts.DrawTextCentered(screen, i18n.T("profile.rename"), 0, 570, 400, cfg.UIHintColor)
+	fonts.DrawTextCentered(screen, i18n.T("profile.delete"), 400, 570, 400, cfg.UIHintColor)
+	menuState.PrevRect = image.Rect(0, 548, 400, 585)
+	menuState.NextRect = image.Rect(400, 548, 800, 585)
+}
+
+// StatsRunRow 统计界面中的一条出击记录
+type StatsRunRow struct {
+	Mode       string
+	ShipKey    string
+	Difficulty float64
+	Score      int
+	Grade      string
+	Victory    bool
+	Elapsed    time.Duration
+}
+
+// StatsView 统计界面信息
+type StatsView struct {
+	Runs            int
+	Victories       int
+	WinRate         float64
+	Kills           int
+	BossKills       int
+	Merits          int
+	PlayTime        time.Duration
+	FavoriteShipKey string
+	Best            []StatsRunRow // 各难度最佳
+	Recent          []StatsRunRow // 最近出击
+	Message         string        // 导出结果（文件名或错误）
+	MessageError    bool
+}
+
+// statsRunLine 格式化一条出击记录
+func statsRunLine(row StatsRunRow) string {
+	result := i18n.T("common.game_over")
+	if row.Victory {
+		result = i18n.T("common.victory")
+	}
+	line := fmt.Sprintf("x%.1f  %s  %s  %s: %d  %s  %s", row.Difficulty, i18n.T("mode."+row.Mode), i18n.T(row.ShipKey),
+		i18n.T("common.score"), row.Score, formatClock(row.Elapsed), result)
+	if row.Grade != "" {
+		line += "  " + row.Grade
+	}
+	return line
+}
+
+// DrawStats 绘制出击统计界面
+func (s *MenuSystem) DrawStats(screen *ebiten.Image, menuState *components.MenuStateData, view StatsView) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("stats.title"), 0, 60, 800, color.White)
+
+	// 汇总
+	favorite := "-"
+	if view.FavoriteShipKey != "" {
+		favorite = i18n.T(view.FavoriteShipKey)
+	}
+	line1 := fmt.Sprintf("%s: %d   %s: %d (%.0f%%)   %s: %d   %s: %d", i18n.T("stats.runs"), view.Runs,
+		i18n.T("stats.wins"), view.Victories, view.WinRate*100, i18n.T("ability.kills"), view.Kills,
+		i18n.T("stats.bosses"), view.BossKills)
+	line2 := fmt.Sprintf("%s: %d   %s: %s   %s: %s", i18n.T("stats.merits"), view.Merits,
+		i18n.T("stats.play_time"), formatClock(view.PlayTime), i18n.T("stats.favorite"), favorite)
+	fonts.DrawTextCentered(screen, line1, 0, 105, 800, cfg.UIMeritColor)
+	fonts.DrawTextCentered(screen, line2, 0, 132, 800, cfg.UIMeritColor)
+
+	// 各难度最佳
+	y := 175
+	fonts.DrawTextCentered(screen, i18n.T("stats.best"), 0, y, 800, cfg.UIHintColor)
+	y += 26
+	if len(view.Best) == 0 {
+		fonts.DrawTextCentered(screen, i18n.T("stats.empty"), 0, y, 800, cfg.UIGreyTextColor)
+		y += 24
+	}
+	for i, row := range view.Best {
+		if i >= 6 {
+			break
+		}
+		fonts.DrawTextCentered(screen, statsRunLine(row), 0, y, 800, cfg.UITextColor)
+		y += 24
+	}
+
+	// 最近出击
+	y += 12
+	fonts.DrawTextCentered(screen, i18n.T("stats.recent"), 0, y, 800, cfg.UIHintColor)
+	y += 26
+	for _, row := range view.Recent {
+		fonts.DrawTextCentered(screen, statsRunLine(row), 0, y, 800, cfg.UIGreyTextColor)
+		y += 22
+	}
+
+	// 选项：导出 CSV、成就、返回
+	options := []string{i18n.T("stats.export"), i18n.T("achievement.title"), i18n.T("settings.back")}
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, option := range options {
+		oy := 490 + i*30
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(oy, 30))
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+option, 0, oy, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+option, 0, oy, 800, cfg.UITextColor)
+		}
+	}
+
+	if view.Message != "" {
+		msg := i18n.T("stats.exported") + ": " + view.Message
+		msgColor := cfg.UIHintColor
+		if view.MessageError {
+			msg = i18n.T("stats.export_failed") + ": " + view.Message
+			msgColor = cfg.UIMeritColor
+		}
+		fonts.DrawTextCentered(screen, msg, 0, 585, 800, msgColor)
+	}
+}
+
+// AchievementRow 成就列表中的一行
+type AchievementRow struct {
+	NameKey    string
+	DescKey    string
+	Unlocked   bool
+	UnlockedAt time.Time
+	Progress   int
+	Target     int // 大于 1 时显示累计进度
+}
+
+// DrawAchievements 绘制成就列表：名称、达成时间或进度，选中项下方显示说明
+func (s *MenuSystem) DrawAchievements(screen *ebiten.Image, menuState *components.MenuStateData, rows []AchievementRow) {
+	cfg := config.DefaultConfig()
+	// 绘制背景
+	screen.Fill(cfg.UIBackgroundColor)
+
+	unlocked := 0
+	for _, row := range rows {
+		if row.Unlocked {
+			unlocked++
+		}
+	}
+	fonts.DrawTextCenteredLarge(screen, i18n.T("achievement.title"), 0, 60, 800, color.White)
+	fonts.DrawTextCentered(screen, fmt.Sprintf("%d / %d", unlocked, len(rows)), 0, 95, 800, cfg.UIMeritColor)
+
+	y := 135
+	menuState.ItemRects = menuState.ItemRects[:0]
+	for i, row := range rows {
+		menuState.ItemRects = append(menuState.ItemRects, rowRect(y, 32))
+
+		status := i18n.T("achievement.locked")
+		switch {
+		case row.Unlocked:
+			status = row.UnlockedAt.Format("2006-01-02")
+		case row.Target > 1:
+			status = fmt.Sprintf("%d / %d", row.Progress, row.Target)
+		}
+		line := fmt.Sprintf("%s  [%s]", i18n.T(row.NameKey), status)
+
+		var lineColor color.Color = cfg.UIGreyTextColor
+		if row.Unlocked {
+			lineColor = cfg.UIVictoryColor
+		}
+		if i == menuState.SelectedIndex {
+			fonts.DrawTextCentered(screen, "> "+line, 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+line, 0, y, 800, lineColor)
+		}
+		y += 32
+	}
+
+	// 返回
+	menuState.ItemRects = append(menuState.ItemRects, rowRect(y+8, 32))
+	if menuState.SelectedIndex == len(rows) {
+		fonts.DrawTextCentered(screen, "> "+i18n.T("settings.back"), 0, y+8, 800, cfg.UIHighlightColor)
+	} else {
+		fonts.DrawTextCentered(screen, "  "+i18n.T("settings.back"), 0, y+8, 800, cfg.UITextColor)
+	}
+
+	if menuState.SelectedIndex < len(rows) {
+		fonts.DrawTextCentered(screen, i18n.T(rows[menuState.SelectedIndex].DescKey), 0, 570, 800, cfg.UIHintColor)
+	}
 }
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"spacebattle/internal/config"
+	"spacebattle/internal/ecs"
 	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/simulation"
 	"spacebattle/internal/ecs/tags"
 	"spacebattle/internal/fonts"
 	"spacebattle/internal/i18n"
@@ -19,17 +21,37 @@ import (
 )
 
 // RenderSystem 渲染系统
-type RenderSystem struct{}
+type RenderSystem struct {
+	history *ecs.PositionHistory // 上一 tick 的实体位置，为空时不插值
+	alpha   float64              // 插值系数 [0, 1]
+}
 
 // NewRenderSystem 创建渲染系统
 func NewRenderSystem() *RenderSystem {
 	return &RenderSystem{}
 }
 
+// SetInterpolation 设置本帧的插值数据：在上一 tick 与当前 tick 的位置之间按 alpha 插值
+func (s *RenderSystem) SetInterpolation(history *ecs.PositionHistory, alpha float64) {
+	s.history = history
+	s.alpha = alpha
+}
+
+// lerp 返回实体本帧的绘制位置
+func (s *RenderSystem) lerp(entry *donburi.Entry) (float64, float64) {
+	return s.history.Lerp(entry, s.alpha)
+}
+
 // Draw 绘制所有实体
 func (s *RenderSystem) Draw(w donburi.World, screen *ebiten.Image) {
-	// 绘制背景
-	screen.Fill(config.DefaultConfig().BackgroundColor)
+	// 绘制背景（战役关卡使用关卡配色）
+	var background color.Color = config.DefaultConfig().BackgroundColor
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		if stage := components.GameState.Get(entry).Stage; stage != nil {
+			background = stage.Background
+		}
+	})
+	screen.Fill(background)
 
 	// 绘制星星
 	s.DrawStars(w, screen)
@@ -58,10 +80,43 @@ func (s *RenderSystem) Draw(w donburi.World, screen *ebiten.Image) {
 	// 绘制 HUD
 	s.DrawHUD(w, screen)
 
+	// 绘制 Boss 连战强化选择
+	s.DrawRushPick(w, screen)
+
 	// 绘制结算界面
 	s.DrawGameOver(w, screen)
 }
 
+// DrawParticles 绘制粒子
+func (s *RenderSystem) DrawParticles(w donburi.World, screen *ebiten.Image) {
+	particleQuery := query.NewQuery(
+		filter.Contains(tags.Particle, components.Position, components.Particle),
+	)
+
+	particleQuery.Each(w, func(entry *donburi.Entry) {
+		pos := components.Position.Get(entry)
+		particle := components.Particle.Get(entry)
+
+		// 绘制粒子（小方块）
+		col := color.RGBA{
+			R: particle.ColorR,
+			G: particle.ColorG,
+			B: particle.ColorB,
+			A: particle.Alpha,
+		}
+
+		vector.DrawFilledRect(
+			screen,
+			float32(pos.X),
+			float32(pos.Y),
+			float32(particle.Size),
+			float32(particle.Size),
+			col,
+			false,
+		)
+	})
+}
+
 // DrawStars 绘制星星
 func (s *RenderSystem) DrawStars(w donburi.World, screen *ebiten.Image) {
 	starQuery := query.NewQuery(
@@ -69,12 +124,12 @@ func (s *RenderSystem) DrawStars(w donburi.World, screen *ebiten.Image) {
 	)
 
 	starQuery.Each(w, func(entry *donburi.Entry) {
-		pos := components.Position.Get(entry)
+		x, y := s.lerp(entry)
 		star := components.Star.Get(entry)
 		sprite := components.Sprite.Get(entry)
 
 		c := sprite.Color.(color.RGBA)
-		vector.DrawFilledCircle(screen, float32(pos.X), float32(pos.Y), float32(star.Size), c, true)
+		vector.DrawFilledCircle(screen, float32(x), float32(y), float32(star.Size), c, true)
 	})
 }
 
@@ -85,7 +140,7 @@ func (s *RenderSystem) DrawEntities(w donburi.World, screen *ebiten.Image, tag d
 	)
 
 	entityQuery.Each(w, func(entry *donburi.Entry) {
-		pos := components.Position.Get(entry)
+		x, y := s.lerp(entry)
 		size := components.Size.Get(entry)
 		sprite := components.Sprite.Get(entry)
 
@@ -94,8 +149,8 @@ func (s *RenderSystem) DrawEntities(w donburi.World, screen *ebiten.Image, tag d
 		if sprite.Shape == "circle" {
 			vector.DrawFilledCircle(
 				screen,
-				float32(pos.X+size.Width/2),
-				float32(pos.Y+size.Height/2),
+				float32(x+size.Width/2),
+				float32(y+size.Height/2),
 				float32(size.Width/2),
 				c,
 				true,
@@ -104,8 +159,8 @@ func (s *RenderSystem) DrawEntities(w donburi.World, screen *ebiten.Image, tag d
 			// 默认为矩形
 			vector.DrawFilledRect(
 				screen,
-				float32(pos.X),
-				float32(pos.Y),
+				float32(x),
+				float32(y),
 				float32(size.Width),
 				float32(size.Height),
 				c,
@@ -122,7 +177,7 @@ func (s *RenderSystem) DrawBoss(w donburi.World, screen *ebiten.Image) {
 	)
 
 	bossQuery.Each(w, func(entry *donburi.Entry) {
-		pos := components.Position.Get(entry)
+		x, y := s.lerp(entry)
 		size := components.Size.Get(entry)
 		health := components.Health.Get(entry)
 		sprite := components.Sprite.Get(entry)
@@ -131,8 +186,8 @@ func (s *RenderSystem) DrawBoss(w donburi.World, screen *ebiten.Image) {
 		c := sprite.Color.(color.RGBA)
 		vector.DrawFilledRect(
 			screen,
-			float32(pos.X),
-			float32(pos.Y),
+			float32(x),
+			float32(y),
 			float32(size.Width),
 			float32(size.Height),
 			c,
@@ -145,8 +200,8 @@ func (s *RenderSystem) DrawBoss(w donburi.World, screen *ebiten.Image) {
 		barHeight := 5.0
 		vector.DrawFilledRect(
 			screen,
-			float32(pos.X),
-			float32(pos.Y-10),
+			float32(x),
+			float32(y-10),
 			float32(barWidth),
 			float32(barHeight),
 			cfg.BossHPBarBg,
@@ -157,8 +212,8 @@ func (s *RenderSystem) DrawBoss(w donburi.World, screen *ebiten.Image) {
 		healthPercent := float64(health.Current) / float64(health.Max)
 		vector.DrawFilledRect(
 			screen,
-			float32(pos.X),
-			float32(pos.Y-10),
+			float32(x),
+			float32(y-10),
 			float32(barWidth*healthPercent),
 			float32(barHeight),
 			cfg.BossHPBarFg,
@@ -209,13 +264,66 @@ func (s *RenderSystem) DrawHUD(w donburi.World, screen *ebiten.Image) {
 	scoreText := fmt.Sprintf("%s: %d", i18n.T("common.score"), gameState.Score)
 	fonts.DrawText(screen, scoreText, 10, 10, color.White)
 
-	livesText := fmt.Sprintf("%s: %d", i18n.T("common.lives"), gameState.Lives)
-	fonts.DrawText(screen, livesText, 10, 30, color.White)
+	if gameState.PlayerCount > 1 {
+		// 合作：每名玩家一个面板
+		s.drawPlayerPanels(w, screen, gameState)
+	} else {
+		livesText := fmt.Sprintf("%s: %d", i18n.T("common.lives"), gameState.Lives)
+		fonts.DrawText(screen, livesText, 10, 30, color.White)
+	}
 
 	// 绘制时间/波次信息
 	cfg := config.DefaultConfig()
-	elapsed := time.Since(gameState.StartTime)
-	if elapsed < gameState.SmallPhaseDuration {
+	elapsed := gameState.Elapsed()
+
+	// 自适应难度：当前调整等级（正值为减难）
+	if assist := gameState.Assist; assist.Enabled {
+		var assistColor color.Color = cfg.UIGreyTextColor
+		if assist.Level > 0 {
+			assistColor = cfg.UIVictoryColor
+		} else if assist.Level < 0 {
+			assistColor = cfg.UIGameOverColor
+		}
+		assistText := fmt.Sprintf("%s %+.2f", i18n.T("hud.assist"), assist.Level)
+		fonts.DrawText(screen, assistText, 10, 575, assistColor)
+	}
+	if gameState.Stage != nil {
+		fonts.DrawTextCentered(screen, i18n.T(gameState.Stage.NameKey), 0, 10, 800, cfg.UIHintColor)
+	}
+	if gameState.Mode == components.ModePractice {
+		practiceText := i18n.T("mode.practice")
+		if gameState.SlowMotion {
+			practiceText += "  [T] " + i18n.T("practice.slow_on")
+		} else {
+			practiceText += "  [T] " + i18n.T("practice.slow_off")
+		}
+		practiceText += "  [F5] " + i18n.T("practice.rewind")
+		fonts.DrawTextCentered(screen, practiceText, 0, 10, 800, cfg.UIHintColor)
+	}
+	if gameState.Mode == components.ModeDaily {
+		dailyText := fmt.Sprintf("%s %s", i18n.T("daily.title"), gameState.DailyDate)
+		if gameState.DailyPractice {
+			dailyText += " (" + i18n.T("daily.practice") + ")"
+		}
+		fonts.DrawTextCentered(screen, dailyText, 0, 10, 800, cfg.UIHintColor)
+	}
+	if gameState.Mode == components.ModeEndless {
+		// 无尽模式：累计波次、存活时间与当前难度
+		waveText := fmt.Sprintf("Wave: %d", gameState.WaveReached)
+		fonts.DrawText(screen, waveText, 680, 10, color.White)
+		fonts.DrawText(screen, formatClock(elapsed), 680, 30, color.White)
+		fonts.DrawText(screen, fmt.Sprintf("x%.2f", gameState.DifficultyMul), 680, 50, cfg.UIMeritColor)
+		if query.NewQuery(filter.Contains(tags.Boss)).Count(w) > 0 {
+			fonts.DrawText(screen, "BOSS!", 680, 70, cfg.UIBossWarningColor)
+		}
+	} else if gameState.Mode == components.ModeBossRush {
+		// Boss 连战：当前进度与本场用时
+		index := min(len(gameState.Bosses), len(gameState.BossQueue))
+		fonts.DrawText(screen, fmt.Sprintf("BOSS %d/%d", index, len(gameState.BossQueue)), 680, 10, cfg.UIBossWarningColor)
+		if current := gameState.CurrentBoss(); current != nil {
+			fonts.DrawText(screen, fmt.Sprintf("%.1fs", gameState.Clock.Sub(current.SpawnTime).Seconds()), 680, 30, color.White)
+		}
+	} else if elapsed < gameState.SmallPhaseDuration {
 		waveText := fmt.Sprintf("Wave: %d/%d", gameState.WaveIndex+1, gameState.WaveCount)
 		fonts.DrawText(screen, waveText, 700, 10, color.White)
 
@@ -227,6 +335,94 @@ func (s *RenderSystem) DrawHUD(w donburi.World, screen *ebiten.Image) {
 	}
 }
 
+// drawPlayerPanels 绘制合作模式下每名玩家的面板（生命、击杀）
+func (s *RenderSystem) drawPlayerPanels(w donburi.World, screen *ebiten.Image, gameState *components.GameStateData) {
+	cfg := config.DefaultConfig()
+	players := simulation.PlayersBySlot(w)
+
+	y := 30
+	if gameState.SharedLives {
+		livesText := fmt.Sprintf("%s: %d", i18n.T("common.lives"), gameState.Lives)
+		fonts.DrawText(screen, livesText, 10, y, color.White)
+		y += 20
+	}
+
+	for slot := 0; slot < gameState.PlayerCount; slot++ {
+		label := fmt.Sprintf(i18n.T("hud.player"), slot+1)
+		var panelColor color.Color = cfg.UIGreyTextColor
+		status := i18n.T("hud.down")
+		if player, ok := players[slot]; ok {
+			panelColor = components.Sprite.Get(player).Color
+			status = ""
+			if !gameState.SharedLives {
+				status = fmt.Sprintf("%s: %d", i18n.T("common.lives"), components.Health.Get(player).Current)
+			}
+		}
+
+		kills := 0
+		if slot < len(gameState.PlayerKills) {
+			kills = gameState.PlayerKills[slot]
+		}
+		line := fmt.Sprintf("%s  %s  %s: %d", label, status, i18n.T("ability.kills"), kills)
+		fonts.DrawText(screen, line, 10, y, panelColor)
+		y += 20
+	}
+}
+
+// DrawRushPick 绘制 Boss 连战两场之间的强化选择
+func (s *RenderSystem) DrawRushPick(w donburi.World, screen *ebiten.Image) {
+	var gameState *components.GameStateData
+	query.NewQuery(filter.Contains(components.GameState)).Each(w, func(entry *donburi.Entry) {
+		gameState = components.GameState.Get(entry)
+	})
+	if gameState == nil || !gameState.RushPicking || len(gameState.RushChoices) == 0 {
+		return
+	}
+
+	cfg := config.DefaultConfig()
+	vector.DrawFilledRect(screen, 0, 0, 800, 600, cfg.UIOverlayColor, true)
+
+	fonts.DrawTextCenteredLarge(screen, i18n.T("rush.title"), 0, 200, 800, cfg.UIVictoryColor)
+	if n := len(gameState.Bosses); n > 0 {
+		last := gameState.Bosses[n-1]
+		splitText := fmt.Sprintf("%s: %.1fs", i18n.T(last.NameKey), last.Split().Seconds())
+		fonts.DrawTextCentered(screen, splitText, 0, 240, 800, cfg.UIMeritColor)
+	}
+
+	y := rushChoiceTop
+	for i, key := range gameState.RushChoices {
+		if i == gameState.RushSelection {
+			fonts.DrawTextCentered(screen, "> "+i18n.T(key), 0, y, 800, cfg.UIHighlightColor)
+		} else {
+			fonts.DrawTextCentered(screen, "  "+i18n.T(key), 0, y, 800, cfg.UITextColor)
+		}
+		y += rushChoiceStep
+	}
+	fonts.DrawTextCentered(screen, i18n.T("rush.hint"), 0, 450, 800, cfg.UIHintColor)
+}
+
+// drawBossSplits 在结算界面左侧列出每只 Boss 的用时
+func (s *RenderSystem) drawBossSplits(screen *ebiten.Image, gameState *components.GameStateData) {
+	cfg := config.DefaultConfig()
+	fonts.DrawText(screen, i18n.T("rush.splits"), 30, 285, cfg.UIHintColor)
+
+	y := 310
+	for i, spec := range gameState.BossQueue {
+		split := "--"
+		if i < len(gameState.Bosses) && gameState.Bosses[i].Killed {
+			split = fmt.Sprintf("%.1fs", gameState.Bosses[i].Split().Seconds())
+		}
+		fonts.DrawText(screen, fmt.Sprintf("%d. %s  %s", i+1, i18n.T(spec.NameKey), split), 30, y, cfg.UITextColor)
+		y += 22
+	}
+}
+
+// formatClock 将时长格式化为 mm:ss
+func formatClock(d time.Duration) string {
+	secs := int(d.Seconds())
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
 // DrawGameOver 绘制游戏结束界面
 func (s *RenderSystem) DrawGameOver(w donburi.World, screen *ebiten.Image) {
 	// 获取游戏状态
@@ -267,6 +463,45 @@ func (s *RenderSystem) DrawGameOver(w donburi.World, screen *ebiten.Image) {
 	// 功勋奖励详情（如果已结算）
 	if gameState.Settled {
 		y := 285
+
+		// 无尽模式：存活时间、波次与最高分
+		if gameState.Mode == components.ModeEndless {
+			survivalText := fmt.Sprintf("%s: %s  %s: %d", i18n.T("endless.survival"), formatClock(gameState.SurvivalTime), i18n.T("endless.wave"), gameState.WaveReached)
+			fonts.DrawTextCentered(screen, survivalText, 0, y, 800, cfg.UITextColor)
+			y += 24
+		}
+		// 本模式最高分（练习等不计分的对局不显示）
+		if gameState.BestScore > 0 {
+			bestText := fmt.Sprintf("%s: %d", i18n.T("endless.best"), gameState.BestScore)
+			var bestColor color.Color = cfg.UIGreyTextColor
+			if gameState.NewRecord {
+				bestText += "  " + i18n.T("endless.new_record")
+				bestColor = cfg.UIVictoryColor
+			}
+			fonts.DrawTextCentered(screen, bestText, 0, y, 800, bestColor)
+			y += 30
+		}
+
+		// Boss 连战分段用时
+		if gameState.Mode == components.ModeBossRush {
+			s.drawBossSplits(screen, gameState)
+		}
+
+		// 每日挑战排名
+		if gameState.DailyRank > 0 {
+			rankText := fmt.Sprintf("%s: #%d", i18n.T("daily.rank"), gameState.DailyRank)
+			fonts.DrawTextCenteredLarge(screen, rankText, 0, 140, 800, cfg.UIMeritColor)
+		}
+
+		// 战役关卡评级
+		if stage := gameState.Stage; stage != nil && stage.Grade != "" {
+			gradeText := fmt.Sprintf("%s: %s", i18n.T("stage.grade"), stage.Grade)
+			if stage.NewBest {
+				gradeText += "  " + i18n.T("endless.new_record")
+			}
+			fonts.DrawTextCenteredLarge(screen, gradeText, 0, 140, 800, cfg.UIMeritColor)
+		}
+
 		breakdown := gameState.RewardBreakdown
 
 		// 显示表现评分
@@ -307,12 +542,30 @@ func (s *RenderSystem) DrawGameOver(w donburi.World, screen *ebiten.Image) {
 			fonts.DrawTextCentered(screen, fmt.Sprintf("PERFECT: +%d", breakdown.PerfectBonus), 0, y, 800, cfg.UIVictoryColor)
 			y += 22
 		}
+		if breakdown.AssistDiscount > 0 {
+			fonts.DrawTextCentered(screen, fmt.Sprintf("%s: -%d", i18n.T("hud.assist"), breakdown.AssistDiscount), 0, y, 800, cfg.UIGameOverColor)
+			y += 22
+		}
 
 		// 总计
 		if breakdown.TotalReward > 0 {
 			y += 8
 			totalText := fmt.Sprintf("Total Merits: +%d", breakdown.TotalReward)
 			fonts.DrawTextCentered(screen, totalText, 0, y, 800, cfg.UIMeritColor)
+			y += 22
+		}
+		if gameState.RewardFailed {
+			fonts.DrawTextCentered(screen, i18n.T("ledger.error_reward"), 0, y, 800, cfg.UIGameOverColor)
+			y += 22
+		}
+
+		// 合作贡献份额（功勋全部计入当前档案，份额仅供参考）
+		if len(gameState.PlayerShares) > 1 {
+			for slot, merits := range gameState.PlayerShares {
+				shareText := fmt.Sprintf("%s: +%d", fmt.Sprintf(i18n.T("coop.share"), slot+1), merits)
+				fonts.DrawTextCentered(screen, shareText, 0, y, 800, cfg.UIMeritColor)
+				y += 22
+			}
 		}
 	}
 
@@ -323,3 +576,21 @@ func (s *RenderSystem) DrawGameOver(w donburi.World, screen *ebiten.Image) {
 	backText := i18n.T("common.back_menu")
 	fonts.DrawTextCentered(screen, backText, 0, 550, 800, cfg.UIHintColor)
 }
+
+// DrawSystemStats 在左下角列出各系统最近一次与平均耗时（调试用）
+func (s *RenderSystem) DrawSystemStats(screen *ebiten.Image, stats []ecs.SystemStats) {
+	cfg := config.DefaultConfig()
+	y := 590 - len(stats)*16
+	vector.DrawFilledRect(screen, 0, float32(y-16), 300, float32(len(stats)*16+12), cfg.UIOverlayColor, true)
+	for _, st := range stats {
+		line := fmt.Sprintf("%-9s %-13s %6.0fus %6.0fus", st.Phase, st.Name,
+			float64(st.Last.Microseconds()), float64(st.Average().Microseconds()))
+		clr := cfg.UITextColor
+		if !st.Enabled {
+			line = fmt.Sprintf("%-9s %-13s  off", st.Phase, st.Name)
+			clr = cfg.UIGreyTextColor
+		}
+		fonts.DrawText(screen, line, 8, y, clr)
+		y += 16
+	}
+}
@@ -0,0 +1,32 @@
+package systems
+
+import (
+	"spacebattle/internal/achievements"
+	"spacebattle/internal/config"
+	"spacebattle/internal/fonts"
+	"spacebattle/internal/i18n"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// 成就提示的位置与尺寸（右上角，自上而下排列）
+const (
+	toastX      = 530
+	toastTop    = 60
+	toastWidth  = 260
+	toastHeight = 44
+	toastStep   = 50
+)
+
+// DrawAchievementToasts 绘制成就达成提示
+func DrawAchievementToasts(screen *ebiten.Image, toasts []achievements.Toast) {
+	cfg := config.DefaultConfig()
+	for i, toast := range toasts {
+		y := toastTop + i*toastStep
+		vector.DrawFilledRect(screen, toastX, float32(y), toastWidth, toastHeight, cfg.UIOverlayColor, true)
+		vector.StrokeRect(screen, toastX, float32(y), toastWidth, toastHeight, 1, cfg.UIVictoryColor, true)
+		fonts.DrawTextCentered(screen, i18n.T("achievement.unlocked"), toastX, y+18, toastWidth, cfg.UIHintColor)
+		fonts.DrawTextCentered(screen, i18n.T(toast.Achievement.NameKey), toastX, y+38, toastWidth, cfg.UIVictoryColor)
+	}
+}
@@ -0,0 +1,46 @@
+package ecs
+
+import "time"
+
+// TickRate 模拟频率（每秒 tick 数），与 ebiten TPS 和显示刷新率无关
+const TickRate = 60
+
+// TickDuration 单个模拟 tick 的时长
+const TickDuration = time.Second / TickRate
+
+// TickSeconds 单个模拟 tick 的时长（秒），即系统收到的 dt
+const TickSeconds = 1.0 / TickRate
+
+// maxTicksPerAdvance 单次最多追赶的 tick 数，超出的时长直接丢弃，避免卡顿后连锁追帧
+const maxTicksPerAdvance = 5
+
+// PerSecond 将调参数值（按 60 tick/秒 时每 tick 的位移或转角）换算为每秒的量。
+// 速度组件统一以像素/秒存储，调参数值保持原有量级
+func PerSecond(perTick float64) float64 {
+	return perTick * TickRate
+}
+
+// FixedStep 固定步长累加器：累计真实经过的时间，按整 tick 推进模拟，
+// 余量用于渲染时在上一 tick 与当前 tick 之间插值
+type FixedStep struct {
+	acc time.Duration
+}
+
+// Advance 累加经过的时长，返回本次应执行的 tick 数
+func (f *FixedStep) Advance(elapsed time.Duration) int {
+	f.acc += max(elapsed, 0)
+	ticks := int(f.acc / TickDuration)
+	if ticks > maxTicksPerAdvance {
+		ticks = maxTicksPerAdvance
+		f.acc = 0
+		return ticks
+	}
+	f.acc -= time.Duration(ticks) * TickDuration
+	return ticks
+}
+
+// Alpha 插值系数 [0, 1]：sinceAdvance 为上次 Advance 之后经过的时间
+func (f *FixedStep) Alpha(sinceAdvance time.Duration) float64 {
+	a := float64(f.acc+sinceAdvance) / float64(TickDuration)
+	return min(max(a, 0), 1)
+}
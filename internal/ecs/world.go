@@ -16,18 +16,72 @@ import (
 // World ECS World 包装器
 type World struct {
 	*ecs.ECS
+	// Rand 本局玩法随机源（生成、射击等），每日挑战以日期种子重置以保证可复现
+	Rand *rand.Rand
+	// Events 本局事件总线
+	Events *EventBus
+	// rng Rand 的底层随机源，记录种子与抽取次数，快照据此还原随机序列
+	rng *countingSource
 }
 
 // NewWorld 创建新的 ECS World
 func NewWorld() *World {
-	return &World{
-		ECS: ecs.NewECS(donburi.NewWorld()),
+	w := &World{
+		ECS:    ecs.NewECS(donburi.NewWorld()),
+		Events: NewEventBus(),
 	}
+	w.Seed(time.Now().UnixNano())
+	return w
 }
 
-// CreatePlayer 创建玩家实体
-func (w *World) CreatePlayer(x, y, width, height, speed float64, fireConfig components.FireSkillData) *donburi.Entry {
+// Seed 使用固定种子重置本局随机源
+func (w *World) Seed(seed int64) {
+	w.rng = newCountingSource(seed)
+	w.Rand = rand.New(w.rng)
+}
+
+// countingSource 记录种子与抽取次数的随机源：同一种子重放相同次数即可回到相同状态
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+// newCountingSource 创建计数随机源
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// skip 丢弃 n 次抽取，使随机源前进到与原状态一致的位置
+func (s *countingSource) skip(n uint64) {
+	for ; s.draws < n; s.draws++ {
+		s.src.Uint64()
+	}
+}
+
+// CreatePlayer 创建玩家实体，slot 为玩家编号（0 为 1P，1 为 2P）
+func (w *World) CreatePlayer(slot int, x, y, width, height, speed float64, fireConfig components.FireSkillData) *donburi.Entry {
 	cfg := config.DefaultConfig()
+	playerColor := cfg.PlayerColor
+	if slot == 1 {
+		playerColor = cfg.Player2Color
+	}
 	player := w.ECS.World.Entry(w.ECS.World.Create(
 		tags.Player,
 		components.Position,
@@ -43,10 +97,15 @@ func (w *World) CreatePlayer(x, y, width, height, speed float64, fireConfig comp
 	components.Position.Set(player, &components.PositionData{X: x, Y: y})
 	components.Velocity.Set(player, &components.VelocityData{VX: 0, VY: 0})
 	components.Size.Set(player, &components.SizeData{Width: width, Height: height})
-	components.PlayerInput.Set(player, &components.PlayerInputData{Speed: speed})
+	components.PlayerInput.Set(player, &components.PlayerInputData{
+		Speed:  speed,
+		Slot:   slot,
+		SpawnX: x,
+		SpawnY: y,
+	})
 	components.FireSkill.Set(player, &fireConfig)
 	components.Sprite.Set(player, &components.SpriteData{
-		Color: cfg.PlayerColor,
+		Color: playerColor,
 		Shape: "rect",
 	})
 
@@ -76,7 +135,7 @@ func (w *World) CreateBullet(x, y, vx, vy, speed float64, damage, penetration in
 	bullet := w.ECS.World.Entry(w.ECS.World.Create(comps...))
 
 	components.Position.Set(bullet, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(bullet, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(bullet, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(bullet, &components.SizeData{Width: 4, Height: 10})
 	components.Sprite.Set(bullet, &components.SpriteData{
 		Color: cfg.BulletColor,
@@ -90,10 +149,10 @@ func (w *World) CreateBullet(x, y, vx, vy, speed float64, damage, penetration in
 
 	if homing {
 		components.Homing.Set(bullet, &components.HomingData{
-			TurnRate:         homingTurnRate,
-			Speed:            speed,
+			TurnRate:         PerSecond(homingTurnRate),
+			Speed:            PerSecond(speed),
 			TargetEntity:     0, // 初始无目标，系统会自动分配
-			LastRetargetTime: time.Now(),
+			LastRetargetTime: components.Now(w.ECS.World),
 			RetargetInterval: 2 * time.Second, // 2秒重新评估一次目标
 		})
 	}
@@ -113,7 +172,7 @@ func (w *World) CreateEnemy(x, y, vx, vy, width, height float64, health int) *do
 	))
 
 	components.Position.Set(enemy, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(enemy, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(enemy, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(enemy, &components.SizeData{Width: width, Height: height})
 	components.Health.Set(enemy, &components.HealthData{Current: health, Max: health})
 	components.Sprite.Set(enemy, &components.SpriteData{
@@ -136,7 +195,7 @@ func (w *World) CreateBoss(x, y, vx, vy, width, height float64, health int) *don
 	))
 
 	components.Position.Set(boss, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(boss, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(boss, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(boss, &components.SizeData{Width: width, Height: height})
 	components.Health.Set(boss, &components.HealthData{Current: health, Max: health})
 	components.Sprite.Set(boss, &components.SpriteData{
@@ -182,7 +241,7 @@ func (w *World) CreateStar(x, y, speed, size float64) *donburi.Entry {
 	))
 
 	components.Position.Set(star, &components.PositionData{X: x, Y: y})
-	components.Star.Set(star, &components.StarData{Speed: speed, Size: size})
+	components.Star.Set(star, &components.StarData{Speed: PerSecond(speed), Size: size})
 	components.Sprite.Set(star, &components.SpriteData{
 		Color: cfg.StarColor,
 		Shape: "circle",
@@ -194,10 +253,12 @@ func (w *World) CreateStar(x, y, speed, size float64) *donburi.Entry {
 // CreateGameState 创建游戏状态实体（单例）
 func (w *World) CreateGameState(lives int, difficultyMul float64) *donburi.Entry {
 	cfg := config.DefaultConfig()
+	now := time.Now()
 
 	gameState := w.ECS.World.Entry(w.ECS.World.Create(components.GameState))
 
 	components.GameState.Set(gameState, &components.GameStateData{
+		Mode:               components.ModeStandard,
 		Score:              0,
 		Lives:              lives,
 		GameOver:           false,
@@ -205,11 +266,15 @@ func (w *World) CreateGameState(lives int, difficultyMul float64) *donburi.Entry
 		Settled:            false,
 		KilledEnemyCount:   0,
 		SpawnedCount:       0,
-		BossSpawned:        false,
-		BossKilled:         false,
 		RewardCached:       0,
 		DifficultyMul:      difficultyMul,
-		StartTime:          time.Now(),
+		BaseDifficulty:     difficultyMul,
+		BossInterval:       cfg.EndlessBossInterval,
+		NextBossTime:       now.Add(cfg.EndlessBossInterval),
+		StartTime:          now,
+		Clock:              now,
+		PlayerCount:        1,
+		PlayerKills:        make([]int, 1),
 		TotalDuration:      cfg.TotalDuration,
 		SmallPhaseDuration: cfg.SmallPhaseDuration,
 		WaveLength:         cfg.WaveLength,
@@ -218,10 +283,15 @@ func (w *World) CreateGameState(lives int, difficultyMul float64) *donburi.Entry
 		WaveMinIntervals:   cfg.WaveMinIntervals,
 		MaxSimultaneous:    cfg.MaxSimultaneous,
 		BatchSize:          cfg.BatchSize,
-		LastEnemyTime:      time.Now(),
+		LastEnemyTime:      now,
 		GMOpen:             false,
 		GMIndex:            0,
 		GMTab:              0,
+		Assist: components.AssistData{
+			SpawnIntervalMul: 1,
+			EnemyHPMul:       1,
+			EnemyBulletMul:   1,
+		},
 	})
 
 	return gameState
@@ -283,7 +353,7 @@ func (w *World) CreateEnemyShooter(x, y, vx, vy, width, height float64, health i
 	))
 
 	components.Position.Set(enemy, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(enemy, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(enemy, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(enemy, &components.SizeData{Width: width, Height: height})
 	components.Health.Set(enemy, &components.HealthData{Current: health, Max: health})
 	components.Sprite.Set(enemy, &components.SpriteData{
@@ -293,7 +363,7 @@ func (w *World) CreateEnemyShooter(x, y, vx, vy, width, height float64, health i
 	components.EnemyAI.Set(enemy, &components.EnemyAIData{
 		EnemyType:     "shooter",
 		ShootInterval: cfg.EnemyShooterShootInterval,
-		LastShotTime:  time.Now(),
+		LastShotTime:  components.Now(w.ECS.World),
 	})
 
 	return enemy
@@ -313,7 +383,7 @@ func (w *World) CreateEnemyZigzag(x, y, vx, vy, width, height float64, health in
 	))
 
 	components.Position.Set(enemy, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(enemy, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(enemy, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(enemy, &components.SizeData{Width: width, Height: height})
 	components.Health.Set(enemy, &components.HealthData{Current: health, Max: health})
 	components.Sprite.Set(enemy, &components.SpriteData{
@@ -322,7 +392,7 @@ func (w *World) CreateEnemyZigzag(x, y, vx, vy, width, height float64, health in
 	})
 	components.EnemyAI.Set(enemy, &components.EnemyAIData{
 		EnemyType:    "zigzag",
-		ZigzagPhase:  rand.Float64() * 2 * math.Pi,
+		ZigzagPhase:  w.Rand.Float64() * 2 * math.Pi,
 		ZigzagSpeed:  2 * math.Pi / cfg.EnemyZigzagPeriod,
 		ZigzagPeriod: cfg.EnemyZigzagPeriod,
 	})
@@ -343,7 +413,7 @@ func (w *World) CreateEnemyTank(x, y, vx, vy, width, height float64, health int)
 	))
 
 	components.Position.Set(enemy, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(enemy, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(enemy, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(enemy, &components.SizeData{Width: width, Height: height})
 	components.Health.Set(enemy, &components.HealthData{Current: health, Max: health})
 	components.Sprite.Set(enemy, &components.SpriteData{
@@ -369,7 +439,7 @@ func (w *World) CreateEnemyBullet(x, y, vx, vy float64) *donburi.Entry {
 	))
 
 	components.Position.Set(bullet, &components.PositionData{X: x, Y: y})
-	components.Velocity.Set(bullet, &components.VelocityData{VX: vx, VY: vy})
+	components.Velocity.Set(bullet, &components.VelocityData{VX: PerSecond(vx), VY: PerSecond(vy)})
 	components.Size.Set(bullet, &components.SizeData{Width: 5, Height: 5})
 	components.Sprite.Set(bullet, &components.SpriteData{
 		Color: cfg.EnemyBulletColor,
@@ -390,8 +460,8 @@ func (w *World) CreateParticle(x, y, vx, vy, size float64, r, g, b uint8) *donbu
 
 	components.Position.Set(particle, &components.PositionData{X: x, Y: y})
 	components.Particle.Set(particle, &components.ParticleData{
-		VX:        vx,
-		VY:        vy,
+		VX:        PerSecond(vx),
+		VY:        PerSecond(vy),
 		Life:      cfg.ParticleLifetime,
 		MaxLife:   cfg.ParticleLifetime,
 		Size:      size,
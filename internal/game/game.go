@@ -25,9 +25,11 @@ func NewGame() *Game {
 func (g *Game) Update() error {
 	g.input.Update()
 
-	// 检查ESC键返回主菜单（在战斗场景中）
+	// 检查ESC键返回主菜单（在战斗、设置、关卡选择、每日挑战、天赋、统计场景中）
 	if g.input.IsKeyJustPressed(ebiten.KeyEscape) {
-		if g.sceneManager.GetCurrentSceneType() == scenes.SceneTypeBattle {
+		switch g.sceneManager.GetCurrentSceneType() {
+		case scenes.SceneTypeBattle, scenes.SceneTypeSettings, scenes.SceneTypeStageSelect, scenes.SceneTypeDaily,
+			scenes.SceneTypeTalent, scenes.SceneTypeStats, scenes.SceneTypeAchievements:
 			g.sceneManager.SwitchToMainMenu()
 			return nil
 		}
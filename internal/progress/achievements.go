@@ -0,0 +1,115 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+// createAchievementTable 创建成就表
+func createAchievementTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS achievements (
+            profile_id INTEGER NOT NULL,
+            id TEXT NOT NULL,
+            unlocked_at INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, id)
+        );`)
+	return err
+}
+
+// createAchievementCounterTable 创建成就累计计数表
+func createAchievementCounterTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS achievement_counters (
+            profile_id INTEGER NOT NULL,
+            id TEXT NOT NULL,
+            value INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, id)
+        );`)
+	return err
+}
+
+// HasAchievement 成就是否已达成
+func HasAchievement(id string) bool {
+	if db == nil {
+		return false
+	}
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM achievements WHERE profile_id=? AND id=?", currentProfileID, id).Scan(&n)
+	return err == nil && n > 0
+}
+
+// UnlockAchievement 记录成就达成，首次达成时返回 true
+func UnlockAchievement(id string) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("progress DB not initialized")
+	}
+	res, err := db.Exec("INSERT OR IGNORE INTO achievements(profile_id, id, unlocked_at) VALUES(?, ?, ?)", currentProfileID, id, time.Now().Unix())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// AchievementUnlocks 返回当前档案已达成的成就及达成时间
+func AchievementUnlocks() (map[string]time.Time, error) {
+	unlocks := make(map[string]time.Time)
+	if db == nil {
+		return unlocks, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT id, unlocked_at FROM achievements WHERE profile_id=?", currentProfileID)
+	if err != nil {
+		return unlocks, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var at int64
+		if err := rows.Scan(&id, &at); err != nil {
+			return unlocks, err
+		}
+		unlocks[id] = time.Unix(at, 0)
+	}
+	return unlocks, rows.Err()
+}
+
+// AchievementCounters 返回当前档案的成就累计计数
+func AchievementCounters() (map[string]int, error) {
+	counters := make(map[string]int)
+	if db == nil {
+		return counters, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT id, value FROM achievement_counters WHERE profile_id=?", currentProfileID)
+	if err != nil {
+		return counters, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var v int
+		if err := rows.Scan(&id, &v); err != nil {
+			return counters, err
+		}
+		counters[id] = v
+	}
+	return counters, rows.Err()
+}
+
+// SaveAchievementCounters 批量写入成就累计计数
+func SaveAchievementCounters(counters map[string]int) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for id, v := range counters {
+		_, err := tx.Exec(`INSERT INTO achievement_counters(profile_id, id, value) VALUES(?, ?, ?)
+            ON CONFLICT(profile_id, id) DO UPDATE SET value=excluded.value`, currentProfileID, id, v)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
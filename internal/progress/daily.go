@@ -0,0 +1,89 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+const keyDailyAttempt = "daily_attempt"
+
+// DailyScore 每日挑战成绩
+type DailyScore struct {
+	Date      string
+	Score     int
+	Kills     int
+	Elapsed   time.Duration
+	Victory   bool
+	CreatedAt time.Time
+}
+
+// createDailyTable 创建每日挑战排行榜表
+func createDailyTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS daily_scores (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            profile_id INTEGER NOT NULL,
+            date TEXT NOT NULL,
+            score INTEGER NOT NULL,
+            kills INTEGER NOT NULL,
+            elapsed_ms INTEGER NOT NULL,
+            victory INTEGER NOT NULL,
+            created_at INTEGER NOT NULL
+        );`)
+	return err
+}
+
+// DailyAttemptUsed 当天的计分挑战是否已使用
+func DailyAttemptUsed(date string) bool {
+	v, ok, err := kvGet(keyDailyAttempt)
+	return err == nil && ok && v == date
+}
+
+// UseDailyAttempt 标记当天的计分挑战已使用（开局即消耗，避免中途退出重来）
+func UseDailyAttempt(date string) error {
+	return kvSet(keyDailyAttempt, date)
+}
+
+// AddDailyScore 记录每日挑战成绩
+func AddDailyScore(d DailyScore) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	victory := 0
+	if d.Victory {
+		victory = 1
+	}
+	_, err := db.Exec("INSERT INTO daily_scores(profile_id, date, score, kills, elapsed_ms, victory, created_at) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		currentProfileID, d.Date, d.Score, d.Kills, d.Elapsed.Milliseconds(), victory, d.CreatedAt.Unix())
+	return err
+}
+
+// TopDailyScores 返回指定日期的排行榜
+func TopDailyScores(date string, limit int) ([]DailyScore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT date, score, kills, elapsed_ms, victory, created_at FROM daily_scores WHERE profile_id=? AND date=? ORDER BY score DESC, elapsed_ms ASC LIMIT ?",
+		currentProfileID, date, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []DailyScore
+	for rows.Next() {
+		var d DailyScore
+		var elapsedMs, createdAt int64
+		var victory int
+		if err := rows.Scan(&d.Date, &d.Score, &d.Kills, &elapsedMs, &victory, &createdAt); err != nil {
+			return nil, err
+		}
+		d.Elapsed = time.Duration(elapsedMs) * time.Millisecond
+		d.Victory = victory != 0
+		d.CreatedAt = time.Unix(createdAt, 0)
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
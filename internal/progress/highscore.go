@@ -0,0 +1,77 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+// HighScore 单条最高分记录
+type HighScore struct {
+	Mode      string
+	Score     int
+	Survival  time.Duration
+	Wave      int
+	CreatedAt time.Time
+}
+
+// createHighScoreTable 创建最高分表（按模式区分）
+func createHighScoreTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS high_scores (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            profile_id INTEGER NOT NULL,
+            mode TEXT NOT NULL,
+            score INTEGER NOT NULL,
+            survival_ms INTEGER NOT NULL,
+            wave INTEGER NOT NULL,
+            created_at INTEGER NOT NULL
+        );`)
+	return err
+}
+
+// AddHighScore 记录一局成绩
+func AddHighScore(h HighScore) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	if h.CreatedAt.IsZero() {
+		h.CreatedAt = time.Now()
+	}
+	_, err := db.Exec("INSERT INTO high_scores(profile_id, mode, score, survival_ms, wave, created_at) VALUES(?, ?, ?, ?, ?, ?)",
+		currentProfileID, h.Mode, h.Score, h.Survival.Milliseconds(), h.Wave, h.CreatedAt.Unix())
+	return err
+}
+
+// TopHighScores 返回指定模式得分最高的 limit 条记录
+func TopHighScores(mode string, limit int) ([]HighScore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT mode, score, survival_ms, wave, created_at FROM high_scores WHERE profile_id=? AND mode=? ORDER BY score DESC, survival_ms DESC LIMIT ?",
+		currentProfileID, mode, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []HighScore
+	for rows.Next() {
+		var h HighScore
+		var survivalMs, createdAt int64
+		if err := rows.Scan(&h.Mode, &h.Score, &survivalMs, &h.Wave, &createdAt); err != nil {
+			return nil, err
+		}
+		h.Survival = time.Duration(survivalMs) * time.Millisecond
+		h.CreatedAt = time.Unix(createdAt, 0)
+		list = append(list, h)
+	}
+	return list, rows.Err()
+}
+
+// BestScore 返回指定模式的历史最高分，无记录时为 0
+func BestScore(mode string) int {
+	list, err := TopHighScores(mode, 1)
+	if err != nil || len(list) == 0 {
+		return 0
+	}
+	return list[0].Score
+}
@@ -0,0 +1,222 @@
+package progress
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MeritSource 功勋变动的来源
+type MeritSource string
+
+const (
+	SourceOpeningBalance  MeritSource = "opening_balance"  // 启用流水前已有的余额
+	SourceBattleReward    MeritSource = "battle_reward"    // 战斗结算奖励
+	SourceDifficultyCost  MeritSource = "difficulty_cost"  // 出征难度成本
+	SourceUpgradePurchase MeritSource = "upgrade_purchase" // 升级加点
+	SourceUpgradeRefund   MeritSource = "upgrade_refund"   // 升级减点退还
+	SourceTalentPurchase  MeritSource = "talent_purchase"  // 天赋升级
+	SourceTalentRefund    MeritSource = "talent_refund"    // 天赋重置退还
+	SourceShipUnlock      MeritSource = "ship_unlock"      // 购买战机
+	SourceUndo            MeritSource = "undo"             // 撤销一笔记录
+)
+
+// 功勋错误
+var (
+	ErrInsufficientMerits = errors.New("progress: insufficient merits")
+	ErrNothingToUndo      = errors.New("progress: nothing to undo")
+	ErrLedgerMismatch     = errors.New("progress: merit balance does not match ledger")
+)
+
+// MeritEntry 功勋流水中的一笔记录（只追加，不修改）
+type MeritEntry struct {
+	ID        int64
+	Source    MeritSource
+	Ref       string // 关联对象：升级为 "战机/升级标识"，难度、战机、天赋节点等
+	Amount    int    // 正数为获得，负数为花费
+	Balance   int    // 记账后的余额
+	Reverses  int64  // 撤销记录所撤销的记录 ID（其他记录为 0）
+	CreatedAt time.Time
+}
+
+// UpgradeRef 升级加点/退还记录的关联对象
+func UpgradeRef(shipID, upgrade string) string {
+	return shipID + "/" + upgrade
+}
+
+// createMeritLedgerTable 创建功勋流水表
+func createMeritLedgerTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS merit_ledger (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            profile_id INTEGER NOT NULL,
+            source TEXT NOT NULL,
+            ref TEXT NOT NULL DEFAULT '',
+            amount INTEGER NOT NULL,
+            balance INTEGER NOT NULL,
+            reverses INTEGER,
+            created_at INTEGER NOT NULL
+        );
+        CREATE INDEX IF NOT EXISTS idx_merit_ledger_profile ON merit_ledger(profile_id, id);`)
+	return err
+}
+
+// ledgerBalance 由流水合计得出的余额
+func ledgerBalance(tx dbtx, profileID int64) (int, error) {
+	var balance int
+	err := tx.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM merit_ledger WHERE profile_id=?", profileID).Scan(&balance)
+	return balance, err
+}
+
+// recordMerits 在事务中记一笔流水并同步档案表中的余额；余额不足时返回 ErrInsufficientMerits
+func recordMerits(tx dbtx, profileID int64, amount int, source MeritSource, ref string, reverses int64) (int, error) {
+	balance, err := ledgerBalance(tx, profileID)
+	if err != nil {
+		return 0, err
+	}
+	balance += amount
+	if balance < 0 {
+		return 0, ErrInsufficientMerits
+	}
+	var rev sql.NullInt64
+	if reverses > 0 {
+		rev = sql.NullInt64{Int64: reverses, Valid: true}
+	}
+	_, err = tx.Exec("INSERT INTO merit_ledger(profile_id, source, ref, amount, balance, reverses, created_at) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		profileID, string(source), ref, amount, balance, rev, time.Now().Unix())
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("UPDATE profiles SET merits=? WHERE id=?", balance, profileID); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// withMeritTx 在事务中执行涉及功勋的写入，fn 返回记账后的余额；提交成功后才更新内存中的余额
+func withMeritTx(fn func(tx dbtx) (int, error)) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	balance, err := fn(tx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	globalProfile.Merits = balance
+	return nil
+}
+
+// ChangeMerits 按差额记一笔流水：正数获得、负数花费，余额不足时返回 ErrInsufficientMerits
+func ChangeMerits(amount int, source MeritSource, ref string) error {
+	if amount == 0 {
+		return nil
+	}
+	return withMeritTx(func(tx dbtx) (int, error) {
+		return recordMerits(tx, currentProfileID, amount, source, ref, 0)
+	})
+}
+
+// scanMeritEntries 读取流水查询结果
+func scanMeritEntries(rows *sql.Rows) ([]MeritEntry, error) {
+	defer rows.Close()
+	var list []MeritEntry
+	for rows.Next() {
+		var e MeritEntry
+		var source string
+		var reverses sql.NullInt64
+		var createdAt int64
+		if err := rows.Scan(&e.ID, &source, &e.Ref, &e.Amount, &e.Balance, &reverses, &createdAt); err != nil {
+			return nil, err
+		}
+		e.Source = MeritSource(source)
+		e.Reverses = reverses.Int64
+		e.CreatedAt = time.Unix(createdAt, 0)
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+const meritEntryColumns = "id, source, ref, amount, balance, reverses, created_at"
+
+// MeritHistory 当前档案最近的功勋流水，新的在前；limit <= 0 时返回全部
+func MeritHistory(limit int) ([]MeritEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := db.Query("SELECT "+meritEntryColumns+" FROM merit_ledger WHERE profile_id=? ORDER BY id DESC LIMIT ?", currentProfileID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanMeritEntries(rows)
+}
+
+// LastMeritEntry 最近一笔尚未撤销、也不是撤销操作本身的记录；连续撤销时依次向前
+func LastMeritEntry() (MeritEntry, bool, error) {
+	if db == nil {
+		return MeritEntry{}, false, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query(`SELECT `+meritEntryColumns+` FROM merit_ledger l
+        WHERE profile_id=? AND reverses IS NULL
+          AND NOT EXISTS (SELECT 1 FROM merit_ledger u WHERE u.reverses=l.id)
+        ORDER BY id DESC LIMIT 1`, currentProfileID)
+	if err != nil {
+		return MeritEntry{}, false, err
+	}
+	list, err := scanMeritEntries(rows)
+	if err != nil || len(list) == 0 {
+		return MeritEntry{}, false, err
+	}
+	return list[0], true, nil
+}
+
+// UndoMeritEntry 追加一笔反向记录撤销 e；e 须仍是 LastMeritEntry 返回的记录
+func UndoMeritEntry(e MeritEntry) error {
+	return withMeritTx(func(tx dbtx) (int, error) {
+		return undoMeritEntry(tx, e)
+	})
+}
+
+// undoMeritEntry 在事务中撤销 e；e 已撤销或之后还有未撤销的记录时返回 ErrNothingToUndo
+func undoMeritEntry(tx dbtx, e MeritEntry) (int, error) {
+	var n int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM merit_ledger l
+        WHERE profile_id=? AND (l.reverses=? OR l.id>? AND l.reverses IS NULL
+          AND NOT EXISTS (SELECT 1 FROM merit_ledger u WHERE u.reverses=l.id))`, currentProfileID, e.ID, e.ID).Scan(&n)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		return 0, ErrNothingToUndo
+	}
+	return recordMerits(tx, currentProfileID, -e.Amount, SourceUndo, e.Ref, e.ID)
+}
+
+// AuditMerits 核对档案表中的余额与流水合计，返回流水合计；不一致时返回 ErrLedgerMismatch
+func AuditMerits() (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("progress DB not initialized")
+	}
+	var stored int
+	if err := db.QueryRow("SELECT merits FROM profiles WHERE id=?", currentProfileID).Scan(&stored); err != nil {
+		return 0, err
+	}
+	sum, err := ledgerBalance(db, currentProfileID)
+	if err != nil {
+		return 0, err
+	}
+	if stored != sum {
+		return sum, fmt.Errorf("%w: stored %d, ledger %d", ErrLedgerMismatch, stored, sum)
+	}
+	return sum, nil
+}
@@ -0,0 +1,472 @@
+package progress
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dbtx 数据库或事务（建表与迁移在事务中执行）
+type dbtx interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// migration 一次有序的结构迁移
+type migration struct {
+	version int
+	name    string
+	up      func(tx dbtx) error
+}
+
+// migrations 按版本递增排列，只能追加，不能修改已发布的迁移
+var migrations = []migration{
+	{1, "baseline", migrateBaseline},
+	{2, "typed_progress", migrateTypedProgress},
+	{3, "run_details", migrateRunDetails},
+	{4, "achievement_counters", migrateAchievementCounters},
+	{5, "suspended_runs", migrateSuspendedRuns},
+	{6, "upgrade_levels", migrateUpgradeLevels},
+	{7, "upgrade_presets", migrateUpgradePresets},
+	{8, "merit_ledger", migrateMeritLedger},
+	{9, "run_assist", migrateRunAssist},
+	{10, "run_bosses_spawned", migrateRunBossesSpawned},
+}
+
+// SchemaVersion 返回数据库当前的结构版本
+func SchemaVersion() (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("progress DB not initialized")
+	}
+	return schemaVersion(db)
+}
+
+func schemaVersion(tx dbtx) (int, error) {
+	var v sql.NullInt64
+	if err := tx.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&v); err != nil {
+		return 0, err
+	}
+	return int(v.Int64), nil
+}
+
+// migrate 依次执行尚未应用的迁移，每个迁移一个事务
+func migrate() error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+            version INTEGER PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at INTEGER NOT NULL
+        );`)
+	if err != nil {
+		return err
+	}
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(m); err != nil {
+			return fmt.Errorf("progress: migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO schema_version(version, name, applied_at) VALUES(?, ?, ?)", m.version, m.name, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrateBaseline 版本 1：kv、档案与按档案隔离的表。
+// 兼容无版本号的旧库：只有 kv 的最早格式，以及各表尚无 profile_id 列的格式。
+func migrateBaseline(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS kv (
+            key TEXT PRIMARY KEY,
+            value TEXT NOT NULL
+        );`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS profiles (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            name TEXT NOT NULL UNIQUE,
+            created_at INTEGER NOT NULL
+        );`)
+	if err != nil {
+		return err
+	}
+
+	// 首次建立默认档案，并把旧版 kv 数据归入其中
+	var n int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM profiles").Scan(&n); err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = tx.Exec("INSERT INTO profiles(id, name, created_at) VALUES(?, ?, ?)", defaultProfileID, "Default", time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("UPDATE kv SET key = ? || key WHERE key NOT LIKE ? AND key <> ?",
+			profileKey(defaultProfileID, ""), profileKeyPrefix+"%", keyLastProfile)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range baselineTables {
+		if err := ensureProfileTable(tx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// profileTable 基线中按档案隔离的表
+type profileTable struct {
+	name    string
+	columns string // 旧版表（无 profile_id）迁移时需复制的列
+	create  func(tx dbtx) error
+}
+
+var baselineTables = []profileTable{
+	{"high_scores", "id, mode, score, survival_ms, wave, created_at", createHighScoreTable},
+	{"stage_progress", "stage_id, best_score, best_grade, best_time_ms, clears", createStageTable},
+	{"daily_scores", "id, date, score, kills, elapsed_ms, victory, created_at", createDailyTable},
+	{"ship_unlocks", "ship_id, unlocked_at", createShipUnlockTable},
+	{"achievements", "id, unlocked_at", createAchievementTable},
+}
+
+// ensureProfileTable 建表；旧版无 profile_id 列的表重建后数据归入默认档案
+func ensureProfileTable(tx dbtx, t profileTable) error {
+	var n int
+	err := tx.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", t.name).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return t.create(tx)
+	}
+	err = tx.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name='profile_id'", t.name).Scan(&n)
+	if err != nil || n > 0 {
+		return err
+	}
+	legacy := t.name + "_legacy"
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", t.name, legacy)); err != nil {
+		return err
+	}
+	if err := t.create(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s(profile_id, %s) SELECT ?, %s FROM %s", t.name, t.columns, t.columns, legacy), defaultProfileID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(fmt.Sprintf("DROP TABLE %s", legacy))
+	return err
+}
+
+// migrateTypedProgress 版本 2：功勋、加点与出击记录改为类型化的表，并迁移 kv 中的旧数据
+func migrateTypedProgress(tx dbtx) error {
+	if _, err := tx.Exec("ALTER TABLE profiles ADD COLUMN merits INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := createLegacyUpgradeTable(tx); err != nil {
+		return err
+	}
+	if err := createRunTable(tx); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT key, value FROM kv WHERE key LIKE ?", profileKeyPrefix+"%")
+	if err != nil {
+		return err
+	}
+	type kvRow struct{ key, value string }
+	var legacy []kvRow
+	for rows.Next() {
+		var r kvRow
+		if err := rows.Scan(&r.key, &r.value); err != nil {
+			rows.Close()
+			return err
+		}
+		legacy = append(legacy, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range legacy {
+		profileID, key, ok := splitProfileKey(r.key)
+		if !ok {
+			continue
+		}
+		switch {
+		case key == keyMerits:
+			m, err := strconv.Atoi(strings.TrimSpace(r.value))
+			if err != nil {
+				continue // 损坏的旧值：保留在 kv 中，不阻断迁移
+			}
+			if _, err := tx.Exec("UPDATE profiles SET merits=? WHERE id=?", m, profileID); err != nil {
+				return err
+			}
+		case key == keyUpgrades || strings.HasPrefix(key, keyShipUpgradesPrefix):
+			var u legacyUpgradeData
+			if err := json.Unmarshal([]byte(r.value), &u); err != nil {
+				continue
+			}
+			shipID := strings.TrimPrefix(key, keyShipUpgradesPrefix)
+			if key == keyUpgrades {
+				shipID = globalUpgradesShip
+			}
+			if err := saveLegacyUpgradeRow(tx, profileID, shipID, u); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		if _, err := tx.Exec("DELETE FROM kv WHERE key=?", r.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitProfileKey 拆分档案 kv 键为档案 ID 与原始键
+func splitProfileKey(k string) (int64, string, bool) {
+	rest, ok := strings.CutPrefix(k, profileKeyPrefix)
+	if !ok {
+		return 0, "", false
+	}
+	idText, key, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, "", false
+	}
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return id, key, true
+}
+
+// migrateRunDetails 版本 3：出击记录补充生成数、加点快照、奖励明细与评级
+func migrateRunDetails(tx dbtx) error {
+	for _, col := range []string{
+		"spawned INTEGER NOT NULL DEFAULT 0",
+		"upgrades TEXT NOT NULL DEFAULT '{}'",
+		"base_reward INTEGER NOT NULL DEFAULT 0",
+		"difficulty_bonus INTEGER NOT NULL DEFAULT 0",
+		"kill_bonus INTEGER NOT NULL DEFAULT 0",
+		"speed_bonus INTEGER NOT NULL DEFAULT 0",
+		"perfect_bonus INTEGER NOT NULL DEFAULT 0",
+		"boss_bonus INTEGER NOT NULL DEFAULT 0",
+		"assist_discount INTEGER NOT NULL DEFAULT 0",
+		"performance REAL NOT NULL DEFAULT 0",
+		"grade TEXT NOT NULL DEFAULT ''",
+	} {
+		if _, err := tx.Exec("ALTER TABLE runs ADD COLUMN " + col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAchievementCounters 版本 4：成就累计计数（击杀数等跨局条件）
+func migrateAchievementCounters(tx dbtx) error {
+	return createAchievementCounterTable(tx)
+}
+
+// migrateSuspendedRuns 版本 5：挂起的出击（中途退出后继续）
+func migrateSuspendedRuns(tx dbtx) error {
+	return createSuspendedRunTable(tx)
+}
+
+// migrateUpgradeLevels 版本 6：加点改为按升级标识保存等级，旧的属性增量按当时的每级步长换算，
+// 出击记录中的加点快照一并换算
+func migrateUpgradeLevels(tx dbtx) error {
+	if err := createUpgradeLevelTable(tx); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT profile_id, ship_id, fire_rate_hz, bullets_per_shot, penetration, spread_delta_deg,
+        bullet_speed, bullet_damage, burst_chance, enable_homing, turn_rate_rad FROM upgrades`)
+	if err != nil {
+		return err
+	}
+	type legacyRow struct {
+		profileID int64
+		shipID    string
+		u         legacyUpgradeData
+	}
+	var legacy []legacyRow
+	for rows.Next() {
+		var r legacyRow
+		if err := rows.Scan(&r.profileID, &r.shipID, &r.u.ModFireRateHz, &r.u.ModBulletsPerShot, &r.u.ModPenetration,
+			&r.u.ModSpreadDeltaDeg, &r.u.ModBulletSpeed, &r.u.ModBulletDamage, &r.u.ModBurstChance,
+			&r.u.ModEnableHoming, &r.u.ModTurnRateRad); err != nil {
+			rows.Close()
+			return err
+		}
+		legacy = append(legacy, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, r := range legacy {
+		if err := saveUpgradeRow(tx, r.profileID, r.shipID, r.u.levels()); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DROP TABLE upgrades"); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query("SELECT id, upgrades FROM runs")
+	if err != nil {
+		return err
+	}
+	type runRow struct {
+		id       int64
+		upgrades string
+	}
+	var runs []runRow
+	for rows.Next() {
+		var r runRow
+		if err := rows.Scan(&r.id, &r.upgrades); err != nil {
+			rows.Close()
+			return err
+		}
+		runs = append(runs, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, r := range runs {
+		var u legacyUpgradeData
+		if err := json.Unmarshal([]byte(r.upgrades), &u); err != nil {
+			continue // 损坏的快照保持原样，读取时按空加点处理
+		}
+		b, err := json.Marshal(u.levels())
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE runs SET upgrades=? WHERE id=?", string(b), r.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateUpgradePresets 版本 7：加点方案
+func migrateUpgradePresets(tx dbtx) error {
+	return createUpgradePresetTable(tx)
+}
+
+// migrateMeritLedger 版本 8：功勋变动改记流水，各档案已有的余额记为一笔期初余额
+func migrateMeritLedger(tx dbtx) error {
+	if err := createMeritLedgerTable(tx); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`INSERT INTO merit_ledger(profile_id, source, ref, amount, balance, created_at)
+        SELECT id, ?, '', merits, merits, ? FROM profiles WHERE merits<>0 ORDER BY id`, string(SourceOpeningBalance), time.Now().Unix())
+	return err
+}
+
+// migrateRunAssist 版本 9：出击记录保存辅助模式的调整记录（旧记录为空）
+func migrateRunAssist(tx dbtx) error {
+	_, err := tx.Exec("ALTER TABLE runs ADD COLUMN assist TEXT NOT NULL DEFAULT '{}'")
+	return err
+}
+
+// migrateRunBossesSpawned 版本 10：出击记录增加出场 Boss 数；旧记录无从得知，按击杀数补齐（即旧的重算口径）
+func migrateRunBossesSpawned(tx dbtx) error {
+	if _, err := tx.Exec("ALTER TABLE runs ADD COLUMN bosses_spawned INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	_, err := tx.Exec("UPDATE runs SET bosses_spawned = boss_kills")
+	return err
+}
+
+// legacyUpgradeData 版本 2 至 5 的加点格式：各属性的增量（仅迁移时使用）
+type legacyUpgradeData struct {
+	ModFireRateHz     float64 `json:"fire_rate_hz"`
+	ModBulletsPerShot int     `json:"bullets_per_shot"`
+	ModPenetration    int     `json:"penetration"`
+	ModSpreadDeltaDeg float64 `json:"spread_delta_deg"`
+	ModBulletSpeed    float64 `json:"bullet_speed"`
+	ModBulletDamage   int     `json:"bullet_damage"`
+	ModBurstChance    float64 `json:"burst_chance"`
+	ModEnableHoming   bool    `json:"enable_homing"`
+	ModTurnRateRad    float64 `json:"turn_rate_rad"`
+}
+
+// levels 按旧版升级界面的每级步长把增量换算为等级
+func (u legacyUpgradeData) levels() UpgradeData {
+	homing := 0
+	if u.ModEnableHoming {
+		homing = 1
+	}
+	return UpgradeData{
+		"fire_rate":        int(math.Round(u.ModFireRateHz / 0.5)),
+		"bullets_per_shot": u.ModBulletsPerShot,
+		"penetration":      u.ModPenetration,
+		"spread_narrow":    int(math.Round(math.Abs(u.ModSpreadDeltaDeg) / 5)),
+		"bullet_speed":     int(math.Round(u.ModBulletSpeed / 0.5)),
+		"bullet_damage":    u.ModBulletDamage,
+		"burst_chance":     int(math.Round(u.ModBurstChance / 0.05)),
+		"enable_homing":    homing,
+		"turn_rate":        int(math.Round(u.ModTurnRateRad / 0.02)),
+	}
+}
+
+// createLegacyUpgradeTable 创建版本 2 的加点表（每个档案、每艘战机一行，版本 6 起废弃）
+func createLegacyUpgradeTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS upgrades (
+            profile_id INTEGER NOT NULL,
+            ship_id TEXT NOT NULL,
+            fire_rate_hz REAL NOT NULL,
+            bullets_per_shot INTEGER NOT NULL,
+            penetration INTEGER NOT NULL,
+            spread_delta_deg REAL NOT NULL,
+            bullet_speed REAL NOT NULL,
+            bullet_damage INTEGER NOT NULL,
+            burst_chance REAL NOT NULL,
+            enable_homing INTEGER NOT NULL,
+            turn_rate_rad REAL NOT NULL,
+            PRIMARY KEY (profile_id, ship_id)
+        );`)
+	return err
+}
+
+// saveLegacyUpgradeRow 写入版本 2 格式的加点
+func saveLegacyUpgradeRow(tx dbtx, profileID int64, shipID string, u legacyUpgradeData) error {
+	_, err := tx.Exec(`INSERT INTO upgrades(profile_id, ship_id, fire_rate_hz, bullets_per_shot, penetration, spread_delta_deg,
+        bullet_speed, bullet_damage, burst_chance, enable_homing, turn_rate_rad) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT(profile_id, ship_id) DO UPDATE SET fire_rate_hz=excluded.fire_rate_hz, bullets_per_shot=excluded.bullets_per_shot,
+        penetration=excluded.penetration, spread_delta_deg=excluded.spread_delta_deg, bullet_speed=excluded.bullet_speed,
+        bullet_damage=excluded.bullet_damage, burst_chance=excluded.burst_chance, enable_homing=excluded.enable_homing,
+        turn_rate_rad=excluded.turn_rate_rad`,
+		profileID, shipID, u.ModFireRateHz, u.ModBulletsPerShot, u.ModPenetration, u.ModSpreadDeltaDeg,
+		u.ModBulletSpeed, u.ModBulletDamage, u.ModBurstChance, u.ModEnableHoming, u.ModTurnRateRad)
+	return err
+}
@@ -0,0 +1,91 @@
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxPresetNameLen 加点方案名称的最大长度（字符数）
+const maxPresetNameLen = 16
+
+// ErrPresetName 加点方案名称为空或过长
+var ErrPresetName = errors.New("progress: invalid preset name")
+
+// UpgradePreset 保存的加点方案（每个档案独立，按名称区分）
+type UpgradePreset struct {
+	Name      string
+	Upgrades  UpgradeData
+	CreatedAt time.Time
+}
+
+// createUpgradePresetTable 创建加点方案表
+func createUpgradePresetTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS upgrade_presets (
+            profile_id INTEGER NOT NULL,
+            name TEXT NOT NULL,
+            upgrades TEXT NOT NULL,
+            created_at INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, name)
+        );`)
+	return err
+}
+
+// SaveUpgradePreset 保存加点方案，同名方案被覆盖
+func SaveUpgradePreset(name string, u UpgradeData) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxPresetNameLen {
+		return ErrPresetName
+	}
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`INSERT INTO upgrade_presets(profile_id, name, upgrades, created_at) VALUES(?, ?, ?, ?)
+        ON CONFLICT(profile_id, name) DO UPDATE SET upgrades=excluded.upgrades`,
+		currentProfileID, name, string(b), time.Now().Unix())
+	return err
+}
+
+// UpgradePresets 按创建顺序列出当前档案的加点方案
+func UpgradePresets() ([]UpgradePreset, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT name, upgrades, created_at FROM upgrade_presets WHERE profile_id=? ORDER BY created_at, name", currentProfileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []UpgradePreset
+	for rows.Next() {
+		var p UpgradePreset
+		var upgrades string
+		var createdAt int64
+		if err := rows.Scan(&p.Name, &upgrades, &createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(upgrades), &p.Upgrades); err != nil {
+			continue // 损坏的方案不列出
+		}
+		p.CreatedAt = time.Unix(createdAt, 0)
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// DeleteUpgradePreset 删除加点方案
+func DeleteUpgradePreset(name string) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	_, err := db.Exec("DELETE FROM upgrade_presets WHERE profile_id=? AND name=?", currentProfileID, name)
+	return err
+}
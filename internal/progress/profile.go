@@ -1,5 +1,14 @@
 package progress
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
 // 简易全局档案：存储功勋等进度
 
 type Profile struct {
@@ -10,27 +19,22 @@ var globalProfile = &Profile{}
 
 func GetProfile() *Profile { return globalProfile }
 
-func AddMerits(n int) {
+// AddMerits 获得功勋并记入流水
+func AddMerits(n int, source MeritSource, ref string) error {
 	if n <= 0 {
-		return
+		return nil
 	}
-	globalProfile.Merits += n
-	_ = saveMerits(globalProfile.Merits)
+	return ChangeMerits(n, source, ref)
 }
 
 func GetMerits() int { return globalProfile.Merits }
 
-// SpendMerits 消耗功勋，成功返回 true
-func SpendMerits(n int) bool {
+// SpendMerits 消耗功勋并记入流水，余额不足时返回 ErrInsufficientMerits
+func SpendMerits(n int, source MeritSource, ref string) error {
 	if n <= 0 {
-		return true
-	}
-	if globalProfile.Merits < n {
-		return false
+		return nil
 	}
-	globalProfile.Merits -= n
-	_ = saveMerits(globalProfile.Merits)
-	return true
+	return ChangeMerits(-n, source, ref)
 }
 
 // Load 从SQLite加载当前档案
@@ -41,3 +45,214 @@ func Load() error {
 	}
 	return nil
 }
+
+// 存档档案（多名玩家共用一台电脑时各自独立的进度）
+
+const (
+	defaultProfileID  int64 = 1
+	profileKeyPrefix        = "profile:"     // 档案隔离的 kv 键前缀
+	keyLastProfile          = "last_profile" // 全局键：上次使用的档案
+	maxProfileNameLen       = 16
+)
+
+// 档案操作错误
+var (
+	ErrProfileName   = errors.New("progress: invalid profile name")
+	ErrProfileExists = errors.New("progress: profile name already exists")
+	ErrLastProfile   = errors.New("progress: cannot delete the only profile")
+)
+
+// 当前档案
+var currentProfileID = defaultProfileID
+
+// ProfileInfo 存档档案
+type ProfileInfo struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// profileScopedTables 含 profile_id 列、删除档案时需一并清理的表
+var profileScopedTables = []string{
+	"high_scores", "stage_progress", "daily_scores", "ship_unlocks", "achievements", "upgrade_levels", "runs",
+	"achievement_counters", "suspended_runs", "upgrade_presets", "merit_ledger",
+}
+
+// restoreLastProfile 恢复上次使用的档案，不存在时退回第一个档案
+func restoreLastProfile() error {
+	currentProfileID = defaultProfileID
+	if s, ok, err := rawGet(keyLastProfile); err == nil && ok {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil && profileExists(id) {
+			currentProfileID = id
+		}
+	}
+	if !profileExists(currentProfileID) {
+		list, err := ListProfiles()
+		if err != nil || len(list) == 0 {
+			return err
+		}
+		currentProfileID = list[0].ID
+	}
+	return nil
+}
+
+// profileExists 档案是否存在
+func profileExists(id int64) bool {
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM profiles WHERE id=?", id).Scan(&n)
+	return err == nil && n > 0
+}
+
+// ListProfiles 按创建顺序列出所有档案
+func ListProfiles() ([]ProfileInfo, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query("SELECT id, name, created_at FROM profiles ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []ProfileInfo
+	for rows.Next() {
+		var p ProfileInfo
+		var createdAt int64
+		if err := rows.Scan(&p.ID, &p.Name, &createdAt); err != nil {
+			return nil, err
+		}
+		p.CreatedAt = time.Unix(createdAt, 0)
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// CurrentProfileID 当前档案 ID（不查询数据库）
+func CurrentProfileID() int64 {
+	return currentProfileID
+}
+
+// CurrentProfile 返回当前档案
+func CurrentProfile() ProfileInfo {
+	p := ProfileInfo{ID: currentProfileID}
+	if db == nil {
+		return p
+	}
+	var createdAt int64
+	if err := db.QueryRow("SELECT name, created_at FROM profiles WHERE id=?", currentProfileID).Scan(&p.Name, &createdAt); err == nil {
+		p.CreatedAt = time.Unix(createdAt, 0)
+	}
+	return p
+}
+
+// normalizeProfileName 校验并整理档案名称
+func normalizeProfileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxProfileNameLen {
+		return "", ErrProfileName
+	}
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM profiles WHERE name=?", name).Scan(&n); err != nil {
+		return "", err
+	}
+	if n > 0 {
+		return "", ErrProfileExists
+	}
+	return name, nil
+}
+
+// CreateProfile 新建档案（不会自动切换）
+func CreateProfile(name string) (ProfileInfo, error) {
+	if db == nil {
+		return ProfileInfo{}, fmt.Errorf("progress DB not initialized")
+	}
+	name, err := normalizeProfileName(name)
+	if err != nil {
+		return ProfileInfo{}, err
+	}
+	now := time.Now()
+	res, err := db.Exec("INSERT INTO profiles(name, created_at) VALUES(?, ?)", name, now.Unix())
+	if err != nil {
+		return ProfileInfo{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return ProfileInfo{}, err
+	}
+	return ProfileInfo{ID: id, Name: name, CreatedAt: now}, nil
+}
+
+// RenameProfile 重命名档案
+func RenameProfile(id int64, name string) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	name, err := normalizeProfileName(name)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE profiles SET name=? WHERE id=?", name, id)
+	return err
+}
+
+// DeleteProfile 删除档案及其全部进度；删除当前档案时切换到剩余的第一个档案
+func DeleteProfile(id int64) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	list, err := ListProfiles()
+	if err != nil {
+		return err
+	}
+	if len(list) <= 1 {
+		return ErrLastProfile
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, table := range profileScopedTables {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE profile_id=?", table), id); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM kv WHERE key LIKE ?", profileKey(id, "")+"%"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM profiles WHERE id=?", id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if id == currentProfileID {
+		for _, p := range list {
+			if p.ID != id {
+				return SwitchProfile(p.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// SwitchProfile 切换当前档案并记住选择
+func SwitchProfile(id int64) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	if !profileExists(id) {
+		return fmt.Errorf("progress: profile %d not found", id)
+	}
+	currentProfileID = id
+	if err := rawSet(keyLastProfile, strconv.FormatInt(id, 10)); err != nil {
+		return err
+	}
+
+	// 重新加载档案相关的缓存
+	currentSettings = nil
+	globalProfile.Merits = 0
+	return Load()
+}
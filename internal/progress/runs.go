@@ -0,0 +1,280 @@
+package progress
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunReward 出击结算的奖励明细
+type RunReward struct {
+	Base           int
+	Difficulty     int
+	Kill           int
+	Speed          int
+	Perfect        int
+	Boss           int
+	AssistDiscount int
+	Total          int
+	Performance    float64 // 表现分数（0-100）
+}
+
+// AssistChange 辅助模式（自适应难度）的一次调整
+type AssistChange struct {
+	At    time.Duration `json:"at"` // 距开局的时间
+	Level float64       `json:"level"`
+}
+
+// RunAssist 出击中辅助模式的调整记录，用于复查折扣的依据
+type RunAssist struct {
+	Enabled     bool           `json:"enabled"`
+	AverageEase float64        `json:"average_ease"` // 按时间加权的平均减难程度
+	Changes     []AssistChange `json:"changes"`      // 按时间顺序的各次调整（首项为开局时的等级）
+}
+
+// Run 一次出击记录
+type Run struct {
+	ID            int64
+	Mode          string
+	StageID       string
+	ShipID        string
+	Difficulty    float64
+	Upgrades      UpgradeData // 出击时的加点快照
+	Score         int
+	Kills         int
+	Spawned       int
+	BossKills     int
+	BossesSpawned int // 出场的 Boss 数（含未击杀的），与 Spawned 合计即结算使用的生成数
+	Elapsed       time.Duration
+	Victory       bool
+	Reward        RunReward
+	Assist        RunAssist
+	Grade         string
+	CreatedAt     time.Time
+}
+
+// createRunTable 创建出击记录表
+func createRunTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS runs (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            profile_id INTEGER NOT NULL,
+            mode TEXT NOT NULL,
+            stage_id TEXT NOT NULL,
+            ship_id TEXT NOT NULL,
+            difficulty REAL NOT NULL,
+            score INTEGER NOT NULL,
+            kills INTEGER NOT NULL,
+            boss_kills INTEGER NOT NULL,
+            elapsed_ms INTEGER NOT NULL,
+            victory INTEGER NOT NULL,
+            reward INTEGER NOT NULL,
+            created_at INTEGER NOT NULL
+        );`)
+	return err
+}
+
+// AddRun 记录一次出击
+func AddRun(r Run) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	upgrades, err := json.Marshal(r.Upgrades)
+	if err != nil {
+		return err
+	}
+	assist, err := json.Marshal(r.Assist)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`INSERT INTO runs(profile_id, mode, stage_id, ship_id, difficulty, score, kills, boss_kills,
+        elapsed_ms, victory, reward, created_at, spawned, upgrades, base_reward, difficulty_bonus, kill_bonus,
+        speed_bonus, perfect_bonus, boss_bonus, assist_discount, performance, grade, assist, bosses_spawned)
+        VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		currentProfileID, r.Mode, r.StageID, r.ShipID, r.Difficulty, r.Score, r.Kills, r.BossKills,
+		r.Elapsed.Milliseconds(), r.Victory, r.Reward.Total, r.CreatedAt.Unix(), r.Spawned, string(upgrades),
+		r.Reward.Base, r.Reward.Difficulty, r.Reward.Kill, r.Reward.Speed, r.Reward.Perfect, r.Reward.Boss,
+		r.Reward.AssistDiscount, r.Reward.Performance, r.Grade, string(assist), r.BossesSpawned)
+	return err
+}
+
+const runColumns = `id, mode, stage_id, ship_id, difficulty, score, kills, boss_kills, elapsed_ms, victory, reward,
+        created_at, spawned, upgrades, base_reward, difficulty_bonus, kill_bonus, speed_bonus, perfect_bonus,
+        boss_bonus, assist_discount, performance, grade, assist, bosses_spawned`
+
+// scanRun 按 runColumns 的顺序读取一行
+func scanRun(rows *sql.Rows) (Run, error) {
+	var r Run
+	var elapsedMs, createdAt int64
+	var upgrades, assist string
+	err := rows.Scan(&r.ID, &r.Mode, &r.StageID, &r.ShipID, &r.Difficulty, &r.Score, &r.Kills, &r.BossKills,
+		&elapsedMs, &r.Victory, &r.Reward.Total, &createdAt, &r.Spawned, &upgrades, &r.Reward.Base,
+		&r.Reward.Difficulty, &r.Reward.Kill, &r.Reward.Speed, &r.Reward.Perfect, &r.Reward.Boss,
+		&r.Reward.AssistDiscount, &r.Reward.Performance, &r.Grade, &assist, &r.BossesSpawned)
+	if err != nil {
+		return Run{}, err
+	}
+	r.Elapsed = time.Duration(elapsedMs) * time.Millisecond
+	r.CreatedAt = time.Unix(createdAt, 0)
+	_ = json.Unmarshal([]byte(upgrades), &r.Upgrades) // 旧记录没有快照，保持零值
+	_ = json.Unmarshal([]byte(assist), &r.Assist)
+	return r, nil
+}
+
+// queryRuns 查询当前档案的出击记录
+func queryRuns(query string, args ...any) ([]Run, error) {
+	if db == nil {
+		return nil, fmt.Errorf("progress DB not initialized")
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []Run
+	for rows.Next() {
+		r, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
+
+// ListRuns 返回最近的出击记录（新的在前），limit <= 0 时返回全部
+func ListRuns(limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	return queryRuns("SELECT "+runColumns+" FROM runs WHERE profile_id=? ORDER BY created_at DESC, id DESC LIMIT ?",
+		currentProfileID, limit)
+}
+
+// BestRunsByDifficulty 返回每个难度下得分最高的一局（按难度升序）
+func BestRunsByDifficulty() ([]Run, error) {
+	return queryRuns(`SELECT `+runColumns+` FROM runs r WHERE profile_id=? AND id = (
+            SELECT id FROM runs WHERE profile_id=r.profile_id AND difficulty=r.difficulty
+            ORDER BY score DESC, elapsed_ms ASC LIMIT 1)
+        ORDER BY difficulty`, currentProfileID)
+}
+
+// RunStats 出击统计汇总
+type RunStats struct {
+	Runs         int
+	Victories    int
+	Kills        int
+	BossKills    int
+	Merits       int
+	PlayTime     time.Duration
+	FavoriteShip string // 出击次数最多的战机
+}
+
+// WinRate 胜率（0-1）
+func (s RunStats) WinRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Victories) / float64(s.Runs)
+}
+
+// GetRunStats 汇总当前档案的出击记录
+func GetRunStats() (RunStats, error) {
+	var st RunStats
+	if db == nil {
+		return st, fmt.Errorf("progress DB not initialized")
+	}
+	var playMs int64
+	err := db.QueryRow(`SELECT COUNT(*), COALESCE(SUM(victory), 0), COALESCE(SUM(kills), 0), COALESCE(SUM(boss_kills), 0),
+        COALESCE(SUM(reward), 0), COALESCE(SUM(elapsed_ms), 0) FROM runs WHERE profile_id=?`, currentProfileID).
+		Scan(&st.Runs, &st.Victories, &st.Kills, &st.BossKills, &st.Merits, &playMs)
+	if err != nil {
+		return st, err
+	}
+	st.PlayTime = time.Duration(playMs) * time.Millisecond
+
+	err = db.QueryRow(`SELECT ship_id FROM runs WHERE profile_id=? AND ship_id <> ''
+        GROUP BY ship_id ORDER BY COUNT(*) DESC, MAX(created_at) DESC LIMIT 1`, currentProfileID).Scan(&st.FavoriteShip)
+	if err != nil && err != sql.ErrNoRows {
+		return st, err
+	}
+	return st, nil
+}
+
+// ExportRunsCSV 将当前档案的全部出击记录按时间顺序导出为 CSV
+func ExportRunsCSV(w io.Writer) error {
+	runs, err := ListRuns(0)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	header := []string{
+		"id", "created_at", "mode", "stage", "ship", "difficulty", "score", "kills", "spawned", "boss_kills", "bosses_spawned",
+		"duration_s", "victory", "grade", "performance", "base_reward", "difficulty_bonus", "kill_bonus",
+		"speed_bonus", "perfect_bonus", "boss_bonus", "assist_discount", "total_reward",
+		"assist_average_ease", "assist_changes",
+	}
+	// 加点快照每项升级一列（按标识排序），未出现在快照中的记 0 级
+	upgradeKeys := runUpgradeKeys(runs)
+	for _, key := range upgradeKeys {
+		header = append(header, "upgrade."+key)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	ftoa := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		record := []string{
+			strconv.FormatInt(r.ID, 10), r.CreatedAt.Format(time.RFC3339), r.Mode, r.StageID, r.ShipID,
+			ftoa(r.Difficulty), strconv.Itoa(r.Score), strconv.Itoa(r.Kills), strconv.Itoa(r.Spawned),
+			strconv.Itoa(r.BossKills), strconv.Itoa(r.BossesSpawned), ftoa(r.Elapsed.Seconds()), strconv.FormatBool(r.Victory), r.Grade,
+			ftoa(r.Reward.Performance), strconv.Itoa(r.Reward.Base), strconv.Itoa(r.Reward.Difficulty),
+			strconv.Itoa(r.Reward.Kill), strconv.Itoa(r.Reward.Speed), strconv.Itoa(r.Reward.Perfect),
+			strconv.Itoa(r.Reward.Boss), strconv.Itoa(r.Reward.AssistDiscount), strconv.Itoa(r.Reward.Total),
+			ftoa(r.Assist.AverageEase), r.Assist.changesText(),
+		}
+		for _, key := range upgradeKeys {
+			record = append(record, strconv.Itoa(r.Upgrades[key]))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// changesText 辅助模式调整的紧凑文本（"秒:等级"，空格分隔），未启用时为空
+func (a RunAssist) changesText() string {
+	parts := make([]string, 0, len(a.Changes))
+	for _, c := range a.Changes {
+		parts = append(parts, strconv.FormatFloat(c.At.Seconds(), 'f', 1, 64)+":"+strconv.FormatFloat(c.Level, 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+// runUpgradeKeys 出击记录加点快照中出现过的升级标识（排序后）
+func runUpgradeKeys(runs []Run) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, r := range runs {
+		for key := range r.Upgrades {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
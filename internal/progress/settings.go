@@ -0,0 +1,71 @@
+package progress
+
+import "encoding/json"
+
+// 控制方式
+const (
+	ControlModeKeyboard = "keyboard" // 键盘（方向键/WASD + 空格）
+	ControlModePointer  = "pointer"  // 鼠标/触摸跟随，按住自动射击
+)
+
+// Settings 玩家设置
+type Settings struct {
+	ControlMode string `json:"control_mode"`
+	// 本地合作
+	Players          int  `json:"players"`      // 玩家数量（1 或 2）
+	SharedLives      bool `json:"shared_lives"` // 合作时共用生命池
+	ShowContribution bool `json:"split_merits"` // 合作结算时显示按击杀计算的贡献份额（沿用旧键名）
+	// 自适应难度（辅助模式）
+	AdaptiveAssist bool `json:"adaptive_assist"`
+}
+
+const keySettings = "settings"
+
+// 内存中的设置缓存（数据库不可用时仍可在本次运行内生效）
+var currentSettings *Settings
+
+// DefaultSettings 返回默认设置
+func DefaultSettings() Settings {
+	return Settings{
+		ControlMode: ControlModeKeyboard,
+		Players:     1,
+	}
+}
+
+// GetSettings 获取设置，未保存或读取失败时返回默认设置
+func GetSettings() Settings {
+	if currentSettings != nil {
+		return *currentSettings
+	}
+	st := loadSettings()
+	currentSettings = &st
+	return st
+}
+
+// SaveSettings 保存设置
+func SaveSettings(st Settings) error {
+	currentSettings = &st
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return kvSet(keySettings, string(b))
+}
+
+func loadSettings() Settings {
+	st := DefaultSettings()
+	s, ok, err := kvGet(keySettings)
+	if err != nil || !ok {
+		return st
+	}
+	if err := json.Unmarshal([]byte(s), &st); err != nil {
+		return DefaultSettings()
+	}
+	if st.ControlMode != ControlModePointer {
+		st.ControlMode = ControlModeKeyboard
+	}
+	if st.Players != 2 {
+		st.Players = 1
+	}
+	return st
+}
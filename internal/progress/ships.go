@@ -0,0 +1,134 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// 旧版 kv 中每艘战机加点的键前缀（仅迁移时使用）
+	keyShipUpgradesPrefix = "upgrades:"
+	// 旧版全局加点归属的战机（默认战机），避免每艘战机都白得一份加点
+	legacyUpgradesShip = "alpha"
+)
+
+// createShipUnlockTable 创建战机解锁表
+func createShipUnlockTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS ship_unlocks (
+            profile_id INTEGER NOT NULL,
+            ship_id TEXT NOT NULL,
+            unlocked_at INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, ship_id)
+        );`)
+	return err
+}
+
+// IsShipOwned 战机是否已购买
+func IsShipOwned(shipID string) bool {
+	if db == nil {
+		return false
+	}
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM ship_unlocks WHERE profile_id=? AND ship_id=?", currentProfileID, shipID).Scan(&n)
+	return err == nil && n > 0
+}
+
+// UnlockShip 记录战机已购买
+func UnlockShip(shipID string) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	_, err := db.Exec("INSERT OR IGNORE INTO ship_unlocks(profile_id, ship_id, unlocked_at) VALUES(?, ?, ?)", currentProfileID, shipID, time.Now().Unix())
+	return err
+}
+
+// BuyShip 花费功勋购买战机：扣款与解锁在同一事务中完成
+func BuyShip(shipID string, cost int) error {
+	return withMeritTx(func(tx dbtx) (int, error) {
+		balance, err := recordMerits(tx, currentProfileID, -cost, SourceShipUnlock, shipID, 0)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.Exec("INSERT OR IGNORE INTO ship_unlocks(profile_id, ship_id, unlocked_at) VALUES(?, ?, ?)", currentProfileID, shipID, time.Now().Unix())
+		return balance, err
+	})
+}
+
+// GetShipUpgrades 读取指定战机的加点；默认战机尚未单独保存时沿用旧的全局加点
+func GetShipUpgrades(shipID string) (UpgradeData, error) {
+	u, ok, err := loadUpgradeRow(currentProfileID, shipID)
+	if err != nil || ok {
+		return u, err
+	}
+	if shipID == legacyUpgradesShip {
+		return GetUpgrades()
+	}
+	return UpgradeData{}, nil
+}
+
+// SaveShipUpgrades 保存指定战机的加点
+func SaveShipUpgrades(shipID string, u UpgradeData) error {
+	return saveUpgrades(shipID, u)
+}
+
+// PurchaseUpgrade 花费功勋为战机加点：扣款与保存加点后的等级 u 在同一事务中完成
+func PurchaseUpgrade(shipID, upgrade string, cost int, u UpgradeData) error {
+	return withUpgradeTx(shipID, u, func(tx dbtx) (int, error) {
+		return recordMerits(tx, currentProfileID, -cost, SourceUpgradePurchase, UpgradeRef(shipID, upgrade), 0)
+	})
+}
+
+// RefundUpgrade 为战机减点并退还功勋：退款与保存减点后的等级 u 在同一事务中完成
+func RefundUpgrade(shipID, upgrade string, refund int, u UpgradeData) error {
+	return withUpgradeTx(shipID, u, func(tx dbtx) (int, error) {
+		return recordMerits(tx, currentProfileID, refund, SourceUpgradeRefund, UpgradeRef(shipID, upgrade), 0)
+	})
+}
+
+// UpgradeStep 切换整套加点时的一级变动：Amount 为正表示减点退还，为负表示加点花费
+type UpgradeStep struct {
+	Upgrade string
+	Amount  int
+}
+
+// RespecUpgrades 切换战机的整套加点：先逐级记退还，再逐级记花费，与保存新的等级 u 在同一事务中完成；
+// 记录与逐级加减点相同，可在流水中查看并撤销最近一次加点
+func RespecUpgrades(shipID string, steps []UpgradeStep, u UpgradeData) error {
+	return withUpgradeTx(shipID, u, func(tx dbtx) (int, error) {
+		balance := globalProfile.Merits
+		for _, refund := range []bool{true, false} {
+			for _, step := range steps {
+				if step.Amount == 0 || (step.Amount > 0) != refund {
+					continue
+				}
+				source := SourceUpgradePurchase
+				if refund {
+					source = SourceUpgradeRefund
+				}
+				var err error
+				if balance, err = recordMerits(tx, currentProfileID, step.Amount, source, UpgradeRef(shipID, step.Upgrade), 0); err != nil {
+					return 0, err
+				}
+			}
+		}
+		return balance, nil
+	})
+}
+
+// UndoUpgradePurchase 撤销加点记录 e 并保存撤销后的等级 u；e 须仍是 LastMeritEntry 返回的记录
+func UndoUpgradePurchase(e MeritEntry, shipID string, u UpgradeData) error {
+	return withUpgradeTx(shipID, u, func(tx dbtx) (int, error) {
+		return undoMeritEntry(tx, e)
+	})
+}
+
+// withUpgradeTx 在同一事务中记账并保存战机加点，任一步失败时两者都不生效
+func withUpgradeTx(shipID string, u UpgradeData, record func(tx dbtx) (int, error)) error {
+	return withMeritTx(func(tx dbtx) (int, error) {
+		balance, err := record(tx)
+		if err != nil {
+			return 0, err
+		}
+		return balance, saveUpgradeRow(tx, currentProfileID, shipID, u)
+	})
+}
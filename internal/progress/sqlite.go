@@ -14,7 +14,7 @@ var (
 	once sync.Once
 )
 
-// Init 初始化SQLite存储
+// Init 初始化SQLite存储，并执行尚未应用的结构迁移
 func Init(path string) error {
 	var err error
 	once.Do(func() {
@@ -22,15 +22,30 @@ func Init(path string) error {
 		if err != nil {
 			return
 		}
-		_, err = db.Exec(`CREATE TABLE IF NOT EXISTS kv (
-            key TEXT PRIMARY KEY,
-            value TEXT NOT NULL
-        );`)
+		if err = migrate(); err != nil {
+			return
+		}
+		err = restoreLastProfile()
 	})
 	return err
 }
 
+// kvGet 读取当前档案的键值
 func kvGet(key string) (string, bool, error) {
+	return rawGet(profileKey(currentProfileID, key))
+}
+
+// kvSet 写入当前档案的键值
+func kvSet(key, value string) error {
+	return rawSet(profileKey(currentProfileID, key), value)
+}
+
+// profileKey 档案隔离的 kv 键
+func profileKey(profileID int64, key string) string {
+	return fmt.Sprintf("%s%d:%s", profileKeyPrefix, profileID, key)
+}
+
+func rawGet(key string) (string, bool, error) {
 	if db == nil {
 		return "", false, fmt.Errorf("progress DB not initialized")
 	}
@@ -45,67 +60,166 @@ func kvGet(key string) (string, bool, error) {
 	return val, true, nil
 }
 
-func kvSet(key, value string) error {
+func rawSet(key, value string) error {
 	if db == nil {
 		return fmt.Errorf("progress DB not initialized")
 	}
-	_, err := db.Exec("INSERT INTO kv(key, value) VALUES(?, ?) ON CONFLICT(key) DO UPDATE SET value=excluded.value", key, value)
-	return err
+	return rawSetTx(db, key, value)
 }
 
-// UpgradeData 用于持久化的加点数据
-type UpgradeData struct {
-	ModFireRateHz     float64 `json:"fire_rate_hz"`
-	ModBulletsPerShot int     `json:"bullets_per_shot"`
-	ModPenetration    int     `json:"penetration"`
-	ModSpreadDeltaDeg float64 `json:"spread_delta_deg"`
-	ModBulletSpeed    float64 `json:"bullet_speed"`
-	ModBulletDamage   int     `json:"bullet_damage"`
-	ModBurstChance    float64 `json:"burst_chance"`
-	ModEnableHoming   bool    `json:"enable_homing"`
-	ModTurnRateRad    float64 `json:"turn_rate_rad"`
+// rawSetTx 在事务中写入键值
+func rawSetTx(tx dbtx, key, value string) error {
+	_, err := tx.Exec("INSERT INTO kv(key, value) VALUES(?, ?) ON CONFLICT(key) DO UPDATE SET value=excluded.value", key, value)
+	return err
 }
 
+// UpgradeData 用于持久化的加点数据（升级标识 -> 等级）
+type UpgradeData map[string]int
+
 const (
 	keyMerits   = "merits"
 	keyUpgrades = "upgrades"
+	keyTalents  = "talents"
 )
 
-// 持久化API
+// globalUpgradesShip 旧版全局加点在加点表中的战机 ID
+const globalUpgradesShip = ""
 
-func loadMerits() (int, error) {
-	s, ok, err := kvGet(keyMerits)
-	if err != nil || !ok {
-		return 0, err
+// createUpgradeLevelTable 创建加点表（每个档案、每艘战机、每项升级一行）
+func createUpgradeLevelTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS upgrade_levels (
+            profile_id INTEGER NOT NULL,
+            ship_id TEXT NOT NULL,
+            upgrade TEXT NOT NULL,
+            level INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, ship_id, upgrade)
+        );`)
+	return err
+}
+
+// loadUpgradeRow 读取加点，没有记录时返回 false
+func loadUpgradeRow(profileID int64, shipID string) (UpgradeData, bool, error) {
+	if db == nil {
+		return UpgradeData{}, false, fmt.Errorf("progress DB not initialized")
 	}
-	var m int
-	_, err = fmt.Sscanf(s, "%d", &m)
+	rows, err := db.Query("SELECT upgrade, level FROM upgrade_levels WHERE profile_id=? AND ship_id=?", profileID, shipID)
 	if err != nil {
-		return 0, err
+		return UpgradeData{}, false, err
 	}
-	return m, nil
+	defer rows.Close()
+	u := UpgradeData{}
+	found := false
+	for rows.Next() {
+		var key string
+		var level int
+		if err := rows.Scan(&key, &level); err != nil {
+			return UpgradeData{}, false, err
+		}
+		u[key] = level
+		found = true
+	}
+	return u, found, rows.Err()
 }
 
-func saveMerits(m int) error {
-	return kvSet(keyMerits, fmt.Sprintf("%d", m))
+// saveUpgradeRow 写入加点（整体替换该战机的全部等级，0 级也保存以区分"未保存过"）
+func saveUpgradeRow(tx dbtx, profileID int64, shipID string, u UpgradeData) error {
+	if _, err := tx.Exec("DELETE FROM upgrade_levels WHERE profile_id=? AND ship_id=?", profileID, shipID); err != nil {
+		return err
+	}
+	for key, level := range u {
+		_, err := tx.Exec("INSERT INTO upgrade_levels(profile_id, ship_id, upgrade, level) VALUES(?, ?, ?, ?)", profileID, shipID, key, level)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+// saveUpgrades 在事务中写入加点
+func saveUpgrades(shipID string, u UpgradeData) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := saveUpgradeRow(tx, currentProfileID, shipID, u); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 持久化API
+
+// loadMerits 当前档案的余额（由功勋流水得出）
+func loadMerits() (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("progress DB not initialized")
+	}
+	return ledgerBalance(db, currentProfileID)
+}
+
+// GetUpgrades 读取旧版全局加点
 func GetUpgrades() (UpgradeData, error) {
-	var u UpgradeData
-	s, ok, err := kvGet(keyUpgrades)
+	u, _, err := loadUpgradeRow(currentProfileID, globalUpgradesShip)
+	return u, err
+}
+
+// SaveUpgrades 保存全局加点
+func SaveUpgrades(u UpgradeData) error {
+	return saveUpgrades(globalUpgradesShip, u)
+}
+
+// GetTalents 读取天赋等级（节点 ID -> 等级）
+func GetTalents() (map[string]int, error) {
+	t := make(map[string]int)
+	s, ok, err := kvGet(keyTalents)
 	if err != nil || !ok {
-		return u, err
+		return t, err
 	}
-	if err := json.Unmarshal([]byte(s), &u); err != nil {
-		return UpgradeData{}, err
+	if err := json.Unmarshal([]byte(s), &t); err != nil {
+		return make(map[string]int), err
 	}
-	return u, nil
+	return t, nil
 }
 
-func SaveUpgrades(u UpgradeData) error {
-	b, err := json.Marshal(u)
+// SaveTalents 保存天赋等级
+func SaveTalents(t map[string]int) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	return saveTalents(db, t)
+}
+
+// saveTalents 在事务中写入天赋等级
+func saveTalents(tx dbtx, t map[string]int) error {
+	b, err := json.Marshal(t)
 	if err != nil {
 		return err
 	}
-	return kvSet(keyUpgrades, string(b))
+	return rawSetTx(tx, profileKey(currentProfileID, keyTalents), string(b))
+}
+
+// PurchaseTalent 花费功勋为天赋节点升级：扣款与保存升级后的等级 t 在同一事务中完成
+func PurchaseTalent(nodeID string, cost int, t map[string]int) error {
+	return withMeritTx(func(tx dbtx) (int, error) {
+		balance, err := recordMerits(tx, currentProfileID, -cost, SourceTalentPurchase, nodeID, 0)
+		if err != nil {
+			return 0, err
+		}
+		return balance, saveTalents(tx, t)
+	})
+}
+
+// ResetTalents 清空全部天赋并返还 refund：清空与退款在同一事务中完成
+func ResetTalents(refund int) error {
+	return withMeritTx(func(tx dbtx) (int, error) {
+		balance, err := recordMerits(tx, currentProfileID, refund, SourceTalentRefund, "", 0)
+		if err != nil {
+			return 0, err
+		}
+		return balance, saveTalents(tx, map[string]int{})
+	})
 }
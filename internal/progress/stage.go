@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+// StageRecord 单个关卡的最佳通关记录
+type StageRecord struct {
+	StageID   string
+	BestScore int
+	BestGrade string
+	BestTime  time.Duration // 最快通关用时
+	Clears    int
+}
+
+// createStageTable 创建关卡进度表
+func createStageTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS stage_progress (
+            profile_id INTEGER NOT NULL,
+            stage_id TEXT NOT NULL,
+            best_score INTEGER NOT NULL,
+            best_grade TEXT NOT NULL,
+            best_time_ms INTEGER NOT NULL,
+            clears INTEGER NOT NULL,
+            PRIMARY KEY (profile_id, stage_id)
+        );`)
+	return err
+}
+
+// GetStageRecord 读取关卡记录，未通关时返回 false
+func GetStageRecord(stageID string) (StageRecord, bool) {
+	rec := StageRecord{StageID: stageID}
+	if db == nil {
+		return rec, false
+	}
+	var timeMs int64
+	err := db.QueryRow("SELECT best_score, best_grade, best_time_ms, clears FROM stage_progress WHERE profile_id=? AND stage_id=?", currentProfileID, stageID).
+		Scan(&rec.BestScore, &rec.BestGrade, &timeMs, &rec.Clears)
+	if err != nil {
+		return rec, false
+	}
+	rec.BestTime = time.Duration(timeMs) * time.Millisecond
+	return rec, true
+}
+
+// IsStageCleared 判断关卡是否已通关
+func IsStageCleared(stageID string) bool {
+	_, ok := GetStageRecord(stageID)
+	return ok
+}
+
+// SaveStageRecord 写入关卡记录（调用方负责与旧记录合并取优）
+func SaveStageRecord(rec StageRecord) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	_, err := db.Exec(`INSERT INTO stage_progress(profile_id, stage_id, best_score, best_grade, best_time_ms, clears) VALUES(?, ?, ?, ?, ?, ?)
+        ON CONFLICT(profile_id, stage_id) DO UPDATE SET best_score=excluded.best_score, best_grade=excluded.best_grade,
+        best_time_ms=excluded.best_time_ms, clears=excluded.clears`,
+		currentProfileID, rec.StageID, rec.BestScore, rec.BestGrade, rec.BestTime.Milliseconds(), rec.Clears)
+	return err
+}
@@ -0,0 +1,75 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+// SuspendedRun 中途退出时挂起的出击，下次可从退出处继续（每个档案最多一条）
+type SuspendedRun struct {
+	Options  []byte // 出击选项（JSON）
+	Snapshot []byte // 战斗世界快照
+	SavedAt  time.Time
+}
+
+// createSuspendedRunTable 创建挂起出击表
+func createSuspendedRunTable(tx dbtx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS suspended_runs (
+            profile_id INTEGER PRIMARY KEY,
+            options TEXT NOT NULL,
+            snapshot BLOB NOT NULL,
+            saved_at INTEGER NOT NULL
+        );`)
+	return err
+}
+
+// SaveSuspendedRun 挂起当前出击（覆盖已有的挂起记录）
+func SaveSuspendedRun(run SuspendedRun) error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	if run.SavedAt.IsZero() {
+		run.SavedAt = time.Now()
+	}
+	_, err := db.Exec(`INSERT INTO suspended_runs(profile_id, options, snapshot, saved_at) VALUES(?, ?, ?, ?)
+        ON CONFLICT(profile_id) DO UPDATE SET options=excluded.options, snapshot=excluded.snapshot, saved_at=excluded.saved_at`,
+		currentProfileID, string(run.Options), run.Snapshot, run.SavedAt.Unix())
+	return err
+}
+
+// GetSuspendedRun 读取当前档案挂起的出击，没有时返回 false
+func GetSuspendedRun() (SuspendedRun, bool) {
+	var run SuspendedRun
+	if db == nil {
+		return run, false
+	}
+	var options string
+	var savedAt int64
+	err := db.QueryRow("SELECT options, snapshot, saved_at FROM suspended_runs WHERE profile_id=?", currentProfileID).
+		Scan(&options, &run.Snapshot, &savedAt)
+	if err != nil {
+		return run, false
+	}
+	run.Options = []byte(options)
+	run.SavedAt = time.Unix(savedAt, 0)
+	return run, true
+}
+
+// HasSuspendedRun 当前档案是否有挂起的出击
+func HasSuspendedRun() bool {
+	if db == nil {
+		return false
+	}
+	var n int
+	err := db.QueryRow("SELECT 1 FROM suspended_runs WHERE profile_id=?", currentProfileID).Scan(&n)
+	return err == nil
+}
+
+// ClearSuspendedRun 删除挂起的出击（继续或放弃后调用）
+func ClearSuspendedRun() error {
+	if db == nil {
+		return fmt.Errorf("progress DB not initialized")
+	}
+	_, err := db.Exec("DELETE FROM suspended_runs WHERE profile_id=?", currentProfileID)
+	return err
+}
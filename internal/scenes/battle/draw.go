@@ -173,6 +173,9 @@ func (sg *ShooterGame) Draw(screen *ebiten.Image) {
 		meritsText := fmt.Sprintf("%s: +%d  (%s: %d)", i18n.T("common.merits"), sg.rewardCached, i18n.T("common.merits_total"), progress.GetMerits())
 		fonts.DrawTextCentered(screen, meritsText, 0, 340, 800, color.White)
 		fonts.DrawTextCentered(screen, i18n.T("common.back_menu"), 0, 310, 800, color.White)
+		if sg.rewardPending {
+			fonts.DrawTextCentered(screen, i18n.T("ledger.error_reward"), 0, 370, 800, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+		}
 	}
 	if !sg.gameOver && sg.victory {
 		fonts.DrawTextCentered(screen, i18n.T("common.victory"), 0, 250, 800, color.RGBA{R: 0, G: 200, B: 0, A: 255})
@@ -181,6 +184,9 @@ func (sg *ShooterGame) Draw(screen *ebiten.Image) {
 		meritsText := fmt.Sprintf("%s: +%d  (%s: %d)", i18n.T("common.merits"), sg.rewardCached, i18n.T("common.merits_total"), progress.GetMerits())
 		fonts.DrawTextCentered(screen, meritsText, 0, 340, 800, color.White)
 		fonts.DrawTextCentered(screen, i18n.T("common.back_menu"), 0, 310, 800, color.White)
+		if sg.rewardPending {
+			fonts.DrawTextCentered(screen, i18n.T("ledger.error_reward"), 0, 370, 800, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+		}
 	}
 }
 
@@ -28,6 +28,7 @@ type ShooterGame struct {
 	killedEnemyCount int
 	bossKilled       bool
 	rewardCached     int
+	rewardPending    bool // 结算奖励尚未写入存档（写入失败时保持，按回车重试）
 	input            *utils.InputManager
 	lastShot         time.Time
 	shotDelay        time.Duration
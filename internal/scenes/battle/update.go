@@ -33,10 +33,11 @@ func (sg *ShooterGame) Update() error {
 			} else {
 				sg.rewardCached = winReward / 3
 			}
-			if sg.rewardCached > 0 {
-				progress.AddMerits(sg.rewardCached)
-			}
+			sg.rewardPending = sg.rewardCached > 0
+			sg.grantReward()
 			sg.settled = true
+		} else if sg.rewardPending && sg.input.IsKeyJustPressed(ebiten.KeyEnter) {
+			sg.grantReward()
 		}
 		return nil
 	}
@@ -92,3 +93,10 @@ func (sg *ShooterGame) processScheduledShots() {
 		sg.scheduledShots = sg.scheduledShots[idx:]
 	}
 }
+
+// grantReward 写入待发放的结算奖励；失败时保持待发放，结算画面提示按回车重试
+func (sg *ShooterGame) grantReward() {
+	if sg.rewardPending && progress.AddMerits(sg.rewardCached, progress.SourceBattleReward, "standard") == nil {
+		sg.rewardPending = false
+	}
+}
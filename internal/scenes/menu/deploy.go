@@ -106,7 +106,7 @@ func (d *DeployScene) Update() error {
 
 	if d.input.IsKeyJustPressed(ebiten.KeyEnter) || d.input.IsKeyJustPressed(ebiten.KeySpace) {
 		cost := balance.DifficultyCost(d.difficulty)
-		if progress.SpendMerits(cost) {
+		if progress.SpendMerits(cost, progress.SourceDifficultyCost, fmt.Sprintf("%.2f", d.difficulty)) == nil {
 			d.opts.DifficultyMultiplier = d.difficulty
 			d.confirmed = true
 		}
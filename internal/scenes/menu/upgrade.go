@@ -9,6 +9,7 @@ import (
 	"spacebattle/internal/i18n"
 	"spacebattle/internal/progress"
 	"spacebattle/internal/scenes/battle"
+	"spacebattle/internal/upgrades"
 	"spacebattle/internal/utils"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -25,15 +26,19 @@ type UpgradeScene struct {
 func NewUpgradeScene(opts battle.PlayerOptions) *UpgradeScene {
 	// 预填上次保存的加点
 	if last, err := progress.GetUpgrades(); err == nil {
-		opts.ModFireRateHz = last.ModFireRateHz
-		opts.ModBulletsPerShot = last.ModBulletsPerShot
-		opts.ModPenetration = last.ModPenetration
-		opts.ModBulletDamage = last.ModBulletDamage
-		opts.ModSpreadDeltaDeg = last.ModSpreadDeltaDeg
-		opts.ModBulletSpeed = last.ModBulletSpeed
-		opts.ModBurstChance = last.ModBurstChance
-		opts.ModEnableHoming = last.ModEnableHoming
-		opts.ModTurnRateRad = last.ModTurnRateRad
+		bonus := func(key string) float64 {
+			up, _ := upgrades.ByKey(key)
+			return up.Bonus(last[key])
+		}
+		opts.ModFireRateHz = bonus("fire_rate")
+		opts.ModBulletsPerShot = last["bullets_per_shot"]
+		opts.ModPenetration = last["penetration"]
+		opts.ModBulletDamage = last["bullet_damage"]
+		opts.ModSpreadDeltaDeg = bonus("spread_narrow")
+		opts.ModBulletSpeed = bonus("bullet_speed")
+		opts.ModBurstChance = bonus("burst_chance")
+		opts.ModEnableHoming = last["enable_homing"] > 0
+		opts.ModTurnRateRad = bonus("turn_rate")
 	}
 
 	return &UpgradeScene{
@@ -68,7 +73,7 @@ func (u *UpgradeScene) Update() error {
 	if u.input.IsKeyJustPressed(ebiten.KeyRight) || u.input.IsKeyJustPressed(ebiten.KeyArrowRight) {
 		if u.canInc(u.sel) {
 			cost := u.nextCost(u.sel)
-			if cost > 0 && progress.SpendMerits(cost) {
+			if cost > 0 && progress.SpendMerits(cost, progress.SourceUpgradePurchase, progress.UpgradeRef("", legacyUpgradeKeys[u.sel])) == nil {
 				u.inc(u.sel)
 			}
 		}
@@ -76,23 +81,20 @@ func (u *UpgradeScene) Update() error {
 	if u.input.IsKeyJustPressed(ebiten.KeyLeft) || u.input.IsKeyJustPressed(ebiten.KeyArrowLeft) {
 		refund := u.refundCost(u.sel)
 		if u.dec(u.sel) && refund > 0 {
-			progress.AddMerits(refund)
+			// 退还写入失败时恢复等级，不让减点白白丢失功勋
+			if err := progress.AddMerits(refund, progress.SourceUpgradeRefund, progress.UpgradeRef("", legacyUpgradeKeys[u.sel])); err != nil {
+				u.inc(u.sel)
+			}
 		}
 	}
 	// Enter 继续
 	if u.input.IsKeyJustPressed(ebiten.KeyEnter) || u.input.IsKeyJustPressed(ebiten.KeySpace) {
 		// 保存升级数据到SQLite
-		_ = progress.SaveUpgrades(progress.UpgradeData{
-			ModFireRateHz:     u.opts.ModFireRateHz,
-			ModBulletsPerShot: u.opts.ModBulletsPerShot,
-			ModPenetration:    u.opts.ModPenetration,
-			ModSpreadDeltaDeg: u.opts.ModSpreadDeltaDeg,
-			ModBulletSpeed:    u.opts.ModBulletSpeed,
-			ModBulletDamage:   u.opts.ModBulletDamage,
-			ModBurstChance:    u.opts.ModBurstChance,
-			ModEnableHoming:   u.opts.ModEnableHoming,
-			ModTurnRateRad:    u.opts.ModTurnRateRad,
-		})
+		levels := progress.UpgradeData{"bullet_damage": u.opts.ModBulletDamage}
+		for i, key := range legacyUpgradeKeys {
+			levels[key] = u.levelOf(i)
+		}
+		_ = progress.SaveUpgrades(levels)
 		u.confirmed = true
 	}
 	return nil
@@ -104,26 +106,10 @@ func (u *UpgradeScene) Draw(screen *ebiten.Image) {
 	// 显示可用功勋
 	fonts.DrawTextCentered(screen, i18n.T("common.merits")+": "+fmtInt(progress.GetMerits()), 0, 210, 800, color.White)
 
-	// 动态成本显示：base * 2^(level)
-	cfg := config.DefaultConfig()
-	levels := []int{
-		int(u.opts.ModFireRateHz / 0.5),
-		u.opts.ModBulletsPerShot,
-		u.opts.ModPenetration,
-		int(absFloat(u.opts.ModSpreadDeltaDeg) / 5),
-		int(u.opts.ModBulletSpeed / 0.5),
-		int(u.opts.ModBurstChance / 0.05),
-		ternaryInt(u.opts.ModEnableHoming, 1, 0),
-		int(u.opts.ModTurnRateRad / 0.02),
-	}
-	bases := []int{cfg.UpgradeCostFireRate, cfg.UpgradeCostBulletsPerShot, cfg.UpgradeCostPenetration, cfg.UpgradeCostSpreadNarrow, cfg.UpgradeCostBulletSpeed, cfg.UpgradeCostBurstChance, cfg.UpgradeCostEnableHoming, cfg.UpgradeCostTurnRate}
-	costs := make([]int, len(bases))
-	for i := range bases {
-		lvl := levels[i]
-		if lvl < 0 {
-			lvl = 0
-		}
-		costs[i] = bases[i] * (1 << lvl)
+	// 动态成本显示：按升级定义的成本曲线
+	costs := make([]int, len(legacyUpgradeKeys))
+	for i := range legacyUpgradeKeys {
+		costs[i] = u.nextCost(i)
 	}
 
 	items := []struct {
@@ -163,12 +149,6 @@ func ternary(cond bool, a, b string) string {
 	}
 	return b
 }
-func ternaryInt(cond bool, a, b int) int {
-	if cond {
-		return a
-	}
-	return b
-}
 func absFloat(v float64) float64 {
 	if v < 0 {
 		return -v
@@ -203,21 +183,21 @@ func (u *UpgradeScene) levelOf(idx int) int {
 	}
 }
 
+// legacyUpgradeKeys 本界面各选项对应的升级标识
+var legacyUpgradeKeys = []string{"fire_rate", "bullets_per_shot", "penetration", "spread_narrow", "bullet_speed", "burst_chance", "enable_homing", "turn_rate"}
+
 func (u *UpgradeScene) nextCost(idx int) int {
-	cfg := config.DefaultConfig()
-	base := []int{cfg.UpgradeCostFireRate, cfg.UpgradeCostBulletsPerShot, cfg.UpgradeCostPenetration, cfg.UpgradeCostSpreadNarrow, cfg.UpgradeCostBulletSpeed, cfg.UpgradeCostBurstChance, cfg.UpgradeCostEnableHoming, cfg.UpgradeCostTurnRate}
-	level := max(u.levelOf(idx), 0)
-	return base[idx] * (1 << level)
+	up, _ := upgrades.ByKey(legacyUpgradeKeys[idx])
+	return up.Cost(u.levelOf(idx))
 }
 
 func (u *UpgradeScene) refundCost(idx int) int {
-	cfg := config.DefaultConfig()
-	base := []int{cfg.UpgradeCostFireRate, cfg.UpgradeCostBulletsPerShot, cfg.UpgradeCostPenetration, cfg.UpgradeCostSpreadNarrow, cfg.UpgradeCostBulletSpeed, cfg.UpgradeCostBurstChance, cfg.UpgradeCostEnableHoming, cfg.UpgradeCostTurnRate}
 	level := u.levelOf(idx) - 1
 	if level < 0 {
 		return 0
 	}
-	return base[idx] * (1 << level)
+	up, _ := upgrades.ByKey(legacyUpgradeKeys[idx])
+	return up.Cost(level)
 }
 
 // 属性增减
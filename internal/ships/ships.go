@@ -0,0 +1,64 @@
+package ships
+
+// 战机被动技能类型（与 ShipAbilitySystem 一致）
+const (
+	AbilityHarvest      = "harvest"
+	AbilitySpeedFrenzy  = "speed_frenzy"
+	AbilityDodgeMaster  = "dodge_master"
+	AbilityEnergyShield = "energy_shield"
+)
+
+// Unlock 战机解锁条件；两项均为空表示默认拥有
+type Unlock struct {
+	Merits      int    // 消耗功勋购买
+	Achievement string // 达成指定成就后解锁
+}
+
+// Ship 战机定义
+type Ship struct {
+	ID          string
+	NameKey     string // 本地化名称键
+	Speed       float64
+	SizeScale   float64
+	Lives       int
+	PassiveKey  string // 属性被动（passive.*）
+	AbilityType string // 战斗被动技能
+	Unlock      Unlock
+}
+
+// Ships 战机列表（按选择界面顺序）
+var Ships = []Ship{
+	{ID: "alpha", NameKey: "ship.alpha", Speed: 5.0, SizeScale: 1.0, Lives: 3, PassiveKey: "passive.none", AbilityType: AbilityHarvest},
+	{ID: "beta", NameKey: "ship.beta", Speed: 6.5, SizeScale: 1.0, Lives: 3, PassiveKey: "passive.speed", AbilityType: AbilitySpeedFrenzy},
+	{ID: "gamma", NameKey: "ship.gamma", Speed: 5.0, SizeScale: 0.8, Lives: 3, PassiveKey: "passive.small", AbilityType: AbilityDodgeMaster,
+		Unlock: Unlock{Merits: 120}},
+	{ID: "delta", NameKey: "ship.delta", Speed: 5.0, SizeScale: 1.0, Lives: 4, PassiveKey: "passive.life", AbilityType: AbilityEnergyShield,
+		Unlock: Unlock{Achievement: "clear_stage1"}},
+}
+
+// ByID 按 ID 查找战机
+func ByID(id string) (Ship, bool) {
+	for _, s := range Ships {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Ship{}, false
+}
+
+// Default 默认战机
+func Default() Ship {
+	return Ships[0]
+}
+
+// IsUnlocked 判断战机是否可用；owned 返回是否已购买，achieved 返回成就是否达成
+func IsUnlocked(ship Ship, owned func(id string) bool, achieved func(id string) bool) bool {
+	switch {
+	case ship.Unlock.Achievement != "":
+		return achieved(ship.Unlock.Achievement)
+	case ship.Unlock.Merits > 0:
+		return owned(ship.ID)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,147 @@
+package talent
+
+import "errors"
+
+// Stat 天赋效果作用的属性
+type Stat string
+
+const (
+	// 射击技能（FireSkillData）
+	StatFireRate       Stat = "fire.rate_hz"
+	StatBulletDamage   Stat = "fire.bullet_damage"
+	StatBulletsPerShot Stat = "fire.bullets_per_shot"
+	StatBulletSpeed    Stat = "fire.bullet_speed"
+	StatPenetration    Stat = "fire.penetration"
+	StatBurstChance    Stat = "fire.burst_chance"
+	StatHoming         Stat = "fire.homing" // > 0 时启用追踪
+	StatHomingTurn     Stat = "fire.homing_turn"
+	// 被动技能（ShipAbilityData）
+	StatShieldMax Stat = "ability.shield_max"
+	// 玩家选项（PlayerOptions）
+	StatSpeed     Stat = "player.speed"
+	StatLives     Stat = "player.lives"
+	StatSizeScale Stat = "player.size_scale" // 体型比例增量（负值为缩小）
+)
+
+// Effect 每级天赋带来的属性变化
+type Effect struct {
+	Stat    Stat
+	PerRank float64
+}
+
+// Node 天赋节点
+type Node struct {
+	ID      string
+	NameKey string
+	Costs   []int    // 每一级的功勋消耗，长度即最高等级
+	Prereqs []string // 前置节点（至少 1 级）
+	Col     int      // 在天赋图中的列（分支）
+	Row     int      // 在天赋图中的行（层级）
+	Effects []Effect
+}
+
+// MaxRank 最高等级
+func (n Node) MaxRank() int {
+	return len(n.Costs)
+}
+
+// Nodes 天赋树定义：攻击、防御、机动三条分支
+var Nodes = []Node{
+	// 攻击
+	{ID: "rapid_fire", NameKey: "talent.rapid_fire", Costs: []int{2, 3, 5}, Col: 0, Row: 0,
+		Effects: []Effect{{StatFireRate, 0.5}}},
+	{ID: "heavy_rounds", NameKey: "talent.heavy_rounds", Costs: []int{5, 8}, Prereqs: []string{"rapid_fire"}, Col: 0, Row: 1,
+		Effects: []Effect{{StatBulletDamage, 1}}},
+	{ID: "split_shot", NameKey: "talent.split_shot", Costs: []int{6, 10}, Prereqs: []string{"heavy_rounds"}, Col: 0, Row: 2,
+		Effects: []Effect{{StatBulletsPerShot, 1}}},
+	{ID: "piercing", NameKey: "talent.piercing", Costs: []int{12}, Prereqs: []string{"split_shot"}, Col: 0, Row: 3,
+		Effects: []Effect{{StatPenetration, 1}}},
+	// 防御
+	{ID: "plating", NameKey: "talent.plating", Costs: []int{4, 8}, Col: 1, Row: 0,
+		Effects: []Effect{{StatLives, 1}}},
+	{ID: "shield_cap", NameKey: "talent.shield_cap", Costs: []int{5, 8}, Prereqs: []string{"plating"}, Col: 1, Row: 1,
+		Effects: []Effect{{StatShieldMax, 1}}},
+	{ID: "compact_hull", NameKey: "talent.compact_hull", Costs: []int{6, 9}, Prereqs: []string{"shield_cap"}, Col: 1, Row: 2,
+		Effects: []Effect{{StatSizeScale, -0.05}}},
+	// 机动
+	{ID: "thrusters", NameKey: "talent.thrusters", Costs: []int{2, 3, 5}, Col: 2, Row: 0,
+		Effects: []Effect{{StatSpeed, 0.5}}},
+	{ID: "velocity_rounds", NameKey: "talent.velocity_rounds", Costs: []int{3, 5}, Prereqs: []string{"thrusters"}, Col: 2, Row: 1,
+		Effects: []Effect{{StatBulletSpeed, 1}}},
+	{ID: "burst_trigger", NameKey: "talent.burst_trigger", Costs: []int{4, 6, 9}, Prereqs: []string{"velocity_rounds", "rapid_fire"}, Col: 2, Row: 2,
+		Effects: []Effect{{StatBurstChance, 0.05}}},
+	{ID: "seeker", NameKey: "talent.seeker", Costs: []int{8, 12}, Prereqs: []string{"burst_trigger"}, Col: 2, Row: 3,
+		Effects: []Effect{{StatHoming, 1}, {StatHomingTurn, 0.01}}},
+}
+
+// 加点错误
+var (
+	ErrUnknownNode = errors.New("talent: unknown node")
+	ErrMaxRank     = errors.New("talent: node at max rank")
+	ErrLocked      = errors.New("talent: prerequisites not met")
+)
+
+// Ranks 各节点当前等级（节点 ID -> 等级）
+type Ranks map[string]int
+
+// ByID 按 ID 查找节点
+func ByID(id string) (Node, bool) {
+	for _, n := range Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return Node{}, false
+}
+
+// Unlocked 前置节点是否都已至少 1 级
+func (r Ranks) Unlocked(node Node) bool {
+	for _, p := range node.Prereqs {
+		if r[p] < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextCost 返回节点升下一级的消耗
+func (r Ranks) NextCost(id string) (int, error) {
+	node, ok := ByID(id)
+	if !ok {
+		return 0, ErrUnknownNode
+	}
+	rank := r[id]
+	if rank >= node.MaxRank() {
+		return 0, ErrMaxRank
+	}
+	if !r.Unlocked(node) {
+		return 0, ErrLocked
+	}
+	return node.Costs[rank], nil
+}
+
+// Spent 已投入的功勋总数（重置时全额返还）
+func (r Ranks) Spent() int {
+	total := 0
+	for _, node := range Nodes {
+		for i := 0; i < min(r[node.ID], node.MaxRank()); i++ {
+			total += node.Costs[i]
+		}
+	}
+	return total
+}
+
+// Bonuses 汇总所有节点的属性加成
+func (r Ranks) Bonuses() map[Stat]float64 {
+	bonuses := make(map[Stat]float64)
+	for _, node := range Nodes {
+		rank := min(r[node.ID], node.MaxRank())
+		if rank <= 0 {
+			continue
+		}
+		for _, e := range node.Effects {
+			bonuses[e.Stat] += e.PerRank * float64(rank)
+		}
+	}
+	return bonuses
+}
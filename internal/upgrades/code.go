@@ -0,0 +1,94 @@
+package upgrades
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+)
+
+// 加点码：版本号 + 按 Registry 顺序的各项等级（uvarint，末尾的 0 级省略）+ 2 字节校验，
+// base32 编码后每 4 个字符用 - 分隔。Registry 只能在末尾追加新升级，否则已分享的加点码会错位
+const (
+	codeVersion  = 1
+	codeGroup    = 4
+	maxCodeLevel = 1 << 16 // 超出即视为损坏的加点码
+)
+
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// 加点码错误
+var (
+	ErrInvalidCode  = errors.New("upgrades: invalid build code")
+	ErrCodeChecksum = errors.New("upgrades: build code checksum mismatch")
+	ErrCodeVersion  = errors.New("upgrades: unsupported build code version")
+)
+
+// Code 把加点编码为便于分享的短文本
+func (l Levels) Code() string {
+	levels := make([]int, len(Registry))
+	n := 0
+	for i, u := range Registry {
+		levels[i] = max(l[u.Key], 0)
+		if levels[i] > 0 {
+			n = i + 1
+		}
+	}
+	payload := []byte{codeVersion}
+	for _, level := range levels[:n] {
+		payload = binary.AppendUvarint(payload, uint64(level))
+	}
+	payload = binary.BigEndian.AppendUint16(payload, codeChecksum(payload))
+
+	text := codeEncoding.EncodeToString(payload)
+	var b strings.Builder
+	for i, r := range text {
+		if i > 0 && i%codeGroup == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ParseCode 解析加点码；忽略大小写、空白与分隔符
+func ParseCode(code string) (Levels, error) {
+	text := strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\t', '\n', '\r':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+	payload, err := codeEncoding.DecodeString(text)
+	if err != nil || len(payload) < 3 {
+		return nil, ErrInvalidCode
+	}
+	body, sum := payload[:len(payload)-2], binary.BigEndian.Uint16(payload[len(payload)-2:])
+	if codeChecksum(body) != sum {
+		return nil, ErrCodeChecksum
+	}
+	if body[0] != codeVersion {
+		return nil, ErrCodeVersion
+	}
+
+	levels := Levels{}
+	rest := body[1:]
+	for i := 0; len(rest) > 0; i++ {
+		level, n := binary.Uvarint(rest)
+		if n <= 0 || i >= len(Registry) || level > maxCodeLevel {
+			return nil, ErrInvalidCode
+		}
+		if level > 0 {
+			levels[Registry[i].Key] = int(level)
+		}
+		rest = rest[n:]
+	}
+	return levels, nil
+}
+
+// codeChecksum CRC-32 的低 16 位
+func codeChecksum(b []byte) uint16 {
+	return uint16(crc32.ChecksumIEEE(b))
+}
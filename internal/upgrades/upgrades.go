@@ -0,0 +1,321 @@
+package upgrades
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"spacebattle/internal/config"
+	"spacebattle/internal/ecs/components"
+)
+
+// Stat 升级作用的射击属性（FireSkillData 字段）
+type Stat string
+
+const (
+	StatFireRate       Stat = "fire_rate_hz"
+	StatBulletsPerShot Stat = "bullets_per_shot"
+	StatPenetration    Stat = "penetration"
+	StatSpread         Stat = "spread_deg"
+	StatBulletSpeed    Stat = "bullet_speed"
+	StatBulletDamage   Stat = "bullet_damage"
+	StatBurstChance    Stat = "burst_chance"
+	StatHoming         Stat = "homing" // >= 1 时启用追踪
+	StatHomingTurn     Stat = "homing_turn_rate"
+)
+
+// Upgrade 一项可用功勋购买的升级
+type Upgrade struct {
+	Key        string   // 存档、出击记录与成就使用的标识
+	NameKey    string   // 显示名称的 i18n 键
+	Stat       Stat     // 作用的属性
+	PerLevel   float64  // 每级增量
+	Cap        float64  // 属性上限（0 表示不限）
+	BaseCost   int      // 第一级的功勋消耗
+	CostGrowth float64  // 每级消耗倍率
+	Requires   []string // 前置升级（至少 1 级）
+	Enabled    bool     // 是否在升级界面开放购买（关闭后已有等级仍生效、可退还）
+	Precision  int      // 显示加成时的小数位数
+}
+
+// Registry 全部升级定义，顺序即升级界面的显示顺序
+var Registry = newRegistry(config.DefaultConfig())
+
+func newRegistry(cfg *config.Config) []Upgrade {
+	return []Upgrade{
+		{Key: "fire_rate", NameKey: "upgrade.fire_rate", Stat: StatFireRate, PerLevel: 0.5, Cap: cfg.MaxFireRateHz,
+			BaseCost: 1, CostGrowth: 2, Enabled: true, Precision: 1},
+		{Key: "bullets_per_shot", NameKey: "upgrade.bullets_per_shot", Stat: StatBulletsPerShot, PerLevel: 1, Cap: float64(cfg.MaxBulletsPerShot),
+			BaseCost: 3, CostGrowth: 2, Enabled: true},
+		{Key: "penetration", NameKey: "upgrade.penetration", Stat: StatPenetration, PerLevel: 1, Cap: float64(cfg.MaxPenetration),
+			BaseCost: 2, CostGrowth: 2, Enabled: true},
+		{Key: "spread_narrow", NameKey: "upgrade.spread_narrow", Stat: StatSpread, PerLevel: 5, Cap: cfg.MaxSpreadDeg,
+			BaseCost: 1, CostGrowth: 2, Enabled: true},
+		{Key: "bullet_speed", NameKey: "upgrade.bullet_speed", Stat: StatBulletSpeed, PerLevel: 0.5, Cap: cfg.MaxBulletSpeed,
+			BaseCost: 1, CostGrowth: 2, Enabled: true, Precision: 1},
+		{Key: "bullet_damage", NameKey: "upgrade.bullet_damage", Stat: StatBulletDamage, PerLevel: 1,
+			BaseCost: 2, CostGrowth: 2, Enabled: true},
+		{Key: "burst_chance", NameKey: "upgrade.burst_chance", Stat: StatBurstChance, PerLevel: 0.05, Cap: cfg.MaxBurstChance,
+			BaseCost: 2, CostGrowth: 2, Enabled: true, Precision: 2},
+		// 追踪暂不开放购买（每日挑战的固定配置仍可带追踪）
+		{Key: "enable_homing", NameKey: "upgrade.enable_homing", Stat: StatHoming, PerLevel: 1, Cap: 1,
+			BaseCost: 8, CostGrowth: 2},
+		{Key: "turn_rate", NameKey: "upgrade.turn_rate", Stat: StatHomingTurn, PerLevel: 0.02, Cap: cfg.MaxTurnRateRad,
+			BaseCost: 2, CostGrowth: 2, Requires: []string{"enable_homing"}, Enabled: true, Precision: 2},
+	}
+}
+
+// 加点错误
+var (
+	ErrUnknownUpgrade = errors.New("upgrades: unknown upgrade")
+	ErrDisabled       = errors.New("upgrades: upgrade not available")
+	ErrMaxLevel       = errors.New("upgrades: upgrade at max level")
+	ErrLocked         = errors.New("upgrades: prerequisites not met")
+)
+
+// ByKey 按标识查找升级
+func ByKey(key string) (Upgrade, bool) {
+	for _, u := range Registry {
+		if u.Key == key {
+			return u, true
+		}
+	}
+	return Upgrade{}, false
+}
+
+// BaseFireSkill 未加点战机的射击参数
+func BaseFireSkill() components.FireSkillData {
+	return components.FireSkillData{
+		FireRateHz:        5.0,
+		BulletsPerShot:    1,
+		SpreadDeg:         2.0,
+		BulletSpeed:       8.0,
+		BulletDamage:      1,
+		HomingTurnRateRad: 0.01,
+		BurstInterval:     60 * time.Millisecond,
+	}
+}
+
+// Cost 从 level 级升到下一级的消耗
+func (u Upgrade) Cost(level int) int {
+	return int(math.Round(float64(u.BaseCost) * math.Pow(u.CostGrowth, float64(max(level, 0)))))
+}
+
+// MaxLevel 属性达到上限前可购买的最高等级（0 表示不限）
+func (u Upgrade) MaxLevel() int {
+	if u.Cap == 0 {
+		return 0
+	}
+	base := BaseFireSkill()
+	return int(math.Floor((u.Cap-get(&base, u.Stat))/u.PerLevel + 1e-9))
+}
+
+// Bonus 指定等级带来的属性增量
+func (u Upgrade) Bonus(level int) float64 {
+	return u.PerLevel * float64(level)
+}
+
+// Available 是否开放购买：自身与前置升级都已启用
+func (u Upgrade) Available() bool {
+	if !u.Enabled {
+		return false
+	}
+	for _, r := range u.Requires {
+		if req, ok := ByKey(r); !ok || !req.Available() {
+			return false
+		}
+	}
+	return true
+}
+
+// Levels 各升级当前等级（升级标识 -> 等级）
+type Levels map[string]int
+
+// Visible 升级界面显示的升级：开放购买的，以及关闭购买但仍有等级可退还的
+func (l Levels) Visible() []Upgrade {
+	var out []Upgrade
+	for _, u := range Registry {
+		if u.Available() || l[u.Key] > 0 {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// Unlocked 前置升级是否都已至少 1 级
+func (l Levels) Unlocked(u Upgrade) bool {
+	for _, r := range u.Requires {
+		if l[r] < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextCost 返回升级升下一级的消耗
+func (l Levels) NextCost(key string) (int, error) {
+	u, ok := ByKey(key)
+	if !ok {
+		return 0, ErrUnknownUpgrade
+	}
+	if !u.Available() {
+		return 0, ErrDisabled
+	}
+	level := l[key]
+	if maxLevel := u.MaxLevel(); maxLevel > 0 && level >= maxLevel {
+		return 0, ErrMaxLevel
+	}
+	if !l.Unlocked(u) {
+		return 0, ErrLocked
+	}
+	return u.Cost(level), nil
+}
+
+// Refund 降一级返还的功勋；已是 0 级或仍有后续升级依赖它时返回 false
+func (l Levels) Refund(key string) (int, bool) {
+	u, ok := ByKey(key)
+	level := l[key]
+	if !ok || level <= 0 {
+		return 0, false
+	}
+	if level == 1 {
+		for _, other := range Registry {
+			for _, r := range other.Requires {
+				if r == key && l[other.Key] > 0 {
+					return 0, false
+				}
+			}
+		}
+	}
+	return u.Cost(level - 1), true
+}
+
+// Spent 已投入的功勋总数
+func (l Levels) Spent() int {
+	total := 0
+	for _, u := range Registry {
+		for i := 0; i < l[u.Key]; i++ {
+			total += u.Cost(i)
+		}
+	}
+	return total
+}
+
+// Apply 把各升级的加成叠加到射击参数上，超出上限的部分截断；未知的升级标识忽略
+func (l Levels) Apply(fire *components.FireSkillData) {
+	for _, u := range Registry {
+		level := l[u.Key]
+		if level <= 0 {
+			continue
+		}
+		v := get(fire, u.Stat) + u.Bonus(level)
+		if u.Cap > 0 {
+			v = math.Min(v, u.Cap)
+		}
+		set(fire, u.Stat, v)
+	}
+}
+
+// FireSkill 未加点射击参数叠加各升级后的结果
+func (l Levels) FireSkill() components.FireSkillData {
+	fire := BaseFireSkill()
+	l.Apply(&fire)
+	return fire
+}
+
+func get(fire *components.FireSkillData, stat Stat) float64 {
+	switch stat {
+	case StatFireRate:
+		return fire.FireRateHz
+	case StatBulletsPerShot:
+		return float64(fire.BulletsPerShot)
+	case StatPenetration:
+		return float64(fire.PenetrationCount)
+	case StatSpread:
+		return fire.SpreadDeg
+	case StatBulletSpeed:
+		return fire.BulletSpeed
+	case StatBulletDamage:
+		return float64(fire.BulletDamage)
+	case StatBurstChance:
+		return fire.BurstChance
+	case StatHoming:
+		if fire.EnableHoming {
+			return 1
+		}
+		return 0
+	case StatHomingTurn:
+		return fire.HomingTurnRateRad
+	}
+	return 0
+}
+
+func set(fire *components.FireSkillData, stat Stat, v float64) {
+	switch stat {
+	case StatFireRate:
+		fire.FireRateHz = v
+	case StatBulletsPerShot:
+		fire.BulletsPerShot = int(math.Round(v))
+	case StatPenetration:
+		fire.PenetrationCount = int(math.Round(v))
+	case StatSpread:
+		fire.SpreadDeg = v
+	case StatBulletSpeed:
+		fire.BulletSpeed = v
+	case StatBulletDamage:
+		fire.BulletDamage = int(math.Round(v))
+	case StatBurstChance:
+		fire.BurstChance = v
+	case StatHoming:
+		fire.EnableHoming = v >= 1
+	case StatHomingTurn:
+		fire.HomingTurnRateRad = v
+	}
+}
+
+// Change 切换加点方案的结算
+type Change struct {
+	Levels Levels // 切换后的等级（只含已定义的升级）
+	Refund int    // 降级退还的功勋
+	Cost   int    // 升级花费的功勋
+	Steps  []Step // 逐级变动：先是全部降级，再是全部升级
+}
+
+// Step 切换方案中的一级变动：Amount 为正表示降级退还，为负表示升级花费
+type Step struct {
+	Key    string
+	Amount int
+}
+
+// Respec 计算从 current 切换到 target 需要退还与花费的功勋。
+// target 中高于当前的等级须能在升级界面买到：开放购买、不超过上限、前置在 target 中已满足
+func Respec(current, target Levels) (Change, error) {
+	change := Change{Levels: Levels{}}
+	var purchases []Step
+	for _, u := range Registry {
+		from, to := max(current[u.Key], 0), max(target[u.Key], 0)
+		if to > from {
+			if !u.Available() {
+				return Change{}, ErrDisabled
+			}
+			if maxLevel := u.MaxLevel(); maxLevel > 0 && to > maxLevel {
+				return Change{}, ErrMaxLevel
+			}
+			if !target.Unlocked(u) {
+				return Change{}, ErrLocked
+			}
+		}
+		for i := from; i < to; i++ {
+			change.Cost += u.Cost(i)
+			purchases = append(purchases, Step{Key: u.Key, Amount: -u.Cost(i)})
+		}
+		// 与逐级减点一样从最高一级退起
+		for i := from - 1; i >= to; i-- {
+			change.Refund += u.Cost(i)
+			change.Steps = append(change.Steps, Step{Key: u.Key, Amount: u.Cost(i)})
+		}
+		change.Levels[u.Key] = to
+	}
+	change.Steps = append(change.Steps, purchases...)
+	return change, nil
+}
@@ -36,3 +36,41 @@ func (im *InputManager) IsKeyJustPressed(key ebiten.Key) bool {
 func (im *InputManager) IsKeyJustReleased(key ebiten.Key) bool {
 	return inpututil.IsKeyJustReleased(key)
 }
+
+// CursorPosition 获取鼠标指针位置（逻辑坐标）
+func (im *InputManager) CursorPosition() (int, int) {
+	return ebiten.CursorPosition()
+}
+
+// IsMousePressed 检查鼠标左键是否按下
+func (im *InputManager) IsMousePressed() bool {
+	return ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+}
+
+// TouchIDs 获取当前所有触点
+func (im *InputManager) TouchIDs() []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(nil)
+}
+
+// TouchPosition 获取触点位置（逻辑坐标）
+func (im *InputManager) TouchPosition(id ebiten.TouchID) (int, int) {
+	return ebiten.TouchPosition(id)
+}
+
+// IsPointerPressed 检查鼠标左键或任意触点是否按下
+func (im *InputManager) IsPointerPressed() bool {
+	return im.IsMousePressed() || len(im.TouchIDs()) > 0
+}
+
+// JustTapped 获取本帧刚按下的指针位置（鼠标左键或触摸）
+func (im *InputManager) JustTapped() (int, int, bool) {
+	if ids := inpututil.AppendJustPressedTouchIDs(nil); len(ids) > 0 {
+		x, y := ebiten.TouchPosition(ids[0])
+		return x, y, true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		return x, y, true
+	}
+	return 0, 0, false
+}
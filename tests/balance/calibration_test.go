@@ -0,0 +1,118 @@
+package balance_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"spacebattle/internal/balance/sim"
+	"spacebattle/internal/bot"
+	"spacebattle/internal/upgrades"
+)
+
+// TestBattleDeterministic 无界面战斗相同种子结果一致，战果在合理范围内
+func TestBattleDeterministic(t *testing.T) {
+	a := sim.Battle(upgrades.Levels{}, 1.5, bot.Medium, 3)
+	b := sim.Battle(upgrades.Levels{}, 1.5, bot.Medium, 3)
+	if a != b {
+		t.Fatalf("相同种子的战斗结果不一致: %+v / %+v", a, b)
+	}
+	if a.Total <= 0 || a.Elapsed <= 0 || a.Elapsed > a.Total {
+		t.Errorf("战斗时长异常: %+v", a)
+	}
+	if a.Spawned <= 0 || a.Kills < 0 || a.Kills > a.Spawned {
+		t.Errorf("击杀数异常: %+v", a)
+	}
+}
+
+// TestFitRecoversModel 由已知模型抽取的战果拟合，能还原模型的系数
+func TestFitRecoversModel(t *testing.T) {
+	truth := sim.Model{
+		Clear:     sim.Curve{Intercept: -1, Slope: 0.5},
+		Kill:      sim.Curve{Intercept: -0.5, Slope: 0.3},
+		ClearTime: sim.Curve{Intercept: 0.9, Slope: -0.03},
+		Lasted:    sim.Curve{Intercept: 0.4, Slope: 0.03},
+		SpawnRate: sim.Curve{Intercept: 2, Slope: 0.5},
+		KillNoise: 0.2,
+		TimeNoise: 0.05,
+		Total:     60e9,
+	}
+	r := rand.New(rand.NewSource(11))
+	var samples []sim.Sample
+	for _, levels := range sim.CalibrationBuilds() {
+		for _, d := range sim.CalibrationDifficulties {
+			for range 40 {
+				samples = append(samples, sim.Sample{Power: sim.Power(levels), Difficulty: d, Outcome: truth.Play(levels, d, r)})
+			}
+		}
+	}
+	got, err := sim.Fit("synthetic", samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		{"Clear.Intercept", got.Clear.Intercept, truth.Clear.Intercept, 0.3},
+		{"Clear.Slope", got.Clear.Slope, truth.Clear.Slope, 0.1},
+		{"Kill.Intercept", got.Kill.Intercept, truth.Kill.Intercept, 0.1},
+		{"Kill.Slope", got.Kill.Slope, truth.Kill.Slope, 0.03},
+		{"ClearTime.Intercept", got.ClearTime.Intercept, truth.ClearTime.Intercept, 0.03},
+		{"Lasted.Intercept", got.Lasted.Intercept, truth.Lasted.Intercept, 0.03},
+		{"SpawnRate.Intercept", got.SpawnRate.Intercept, truth.SpawnRate.Intercept, 0.1},
+		{"SpawnRate.Slope", got.SpawnRate.Slope, truth.SpawnRate.Slope, 0.05},
+	} {
+		if math.Abs(c.got-c.want) > c.tol {
+			t.Errorf("%s = %.3f，期望 %.3f±%.2f", c.name, c.got, c.want, c.tol)
+		}
+	}
+	if got.Total != truth.Total || got.Samples != len(samples) {
+		t.Errorf("总时长或记录数不符: %v %d", got.Total, got.Samples)
+	}
+}
+
+// TestModelMatchesRecordedRuns 随程序发布的模型在每个难度上的通关率、击杀率与录制的机器人出击一致，
+// 且技术越高通关率越高
+func TestModelMatchesRecordedRuns(t *testing.T) {
+	records, err := sim.Calibration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := -1.0
+	for _, skill := range bot.Skills {
+		model, err := sim.ModelFor(skill)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var predicted float64
+		for _, d := range sim.CalibrationDifficulties {
+			var n, wins, chance, ratio, modelRatio float64
+			for _, s := range records[skill.Name] {
+				if s.Difficulty != d {
+					continue
+				}
+				n++
+				chance += model.ClearChance(s.Power, s.Difficulty)
+				if s.Victory {
+					wins++
+				}
+				ratio += float64(s.Kills) / float64(s.Spawned)
+				modelRatio += model.KillRatio(s.Power, s.Difficulty)
+			}
+			if math.Abs(wins/n-chance/n) > 0.2 {
+				t.Errorf("%s 难度 %.1f：录制通关率 %.2f，模型 %.2f", skill.Name, d, wins/n, chance/n)
+			}
+			if math.Abs(ratio/n-modelRatio/n) > 0.15 {
+				t.Errorf("%s 难度 %.1f：录制击杀率 %.2f，模型 %.2f", skill.Name, d, ratio/n, modelRatio/n)
+			}
+			predicted += chance
+		}
+		total := float64(len(records[skill.Name]))
+		if predicted/total < prev {
+			t.Errorf("%s 的模型通关率 %.2f 低于更弱的技术水平 %.2f", skill.Name, predicted/total, prev)
+		}
+		prev = predicted / total
+	}
+}
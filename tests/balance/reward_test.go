@@ -0,0 +1,178 @@
+package balance_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spacebattle/internal/balance"
+	"spacebattle/internal/config"
+	"spacebattle/internal/progress"
+)
+
+// TestDefaultFormula 默认公式与原硬编码数值一致：难度 2、全击杀、用时过半的完美通关
+func TestDefaultFormula(t *testing.T) {
+	if err := config.DefaultConfig().Reward.Validate(); err != nil {
+		t.Fatalf("默认奖励公式无效: %v", err)
+	}
+	b := balance.ComputeDetailedReward(2.0, 41, 41, 30*time.Second, time.Minute, true)
+	want := balance.RewardBreakdown{
+		BaseReward: 33, DifficultyBonus: 17, KillBonus: 17, SpeedBonus: 4, BossBonus: 10, PerfectBonus: 41,
+		TotalReward: 122, PerformanceScore: 85,
+	}
+	if b != want {
+		t.Errorf("奖励分解 %+v，期望 %+v", b, want)
+	}
+
+	// 失败发放基础奖励的 1/3
+	if got := balance.SettleStandardReward(1.0, 3, 20, 20*time.Second, time.Minute, false); got.BaseReward != 15 || got.TotalReward != 5 {
+		t.Errorf("失败安慰奖 %+v，期望基础 15、发放 5", got)
+	}
+}
+
+// TestRewardConfigValidate 越界参数逐项报告
+func TestRewardConfigValidate(t *testing.T) {
+	rc := config.DefaultConfig().Reward
+	rc.BaseCostRatio = 0
+	rc.KillThreshold = 0.5 // 低于 kill_ramp_from
+	rc.ConsolationRatio = 2
+	rc.ScoreBossWeight = 50
+	err := rc.Validate()
+	if err == nil {
+		t.Fatal("期望校验失败")
+	}
+	for _, field := range []string{"base_cost_ratio", "kill_threshold", "consolation_ratio", "score_*_weight"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("错误中缺少 %s: %v", field, err)
+		}
+	}
+}
+
+// TestLoadRewardConfig 文件只覆盖出现的字段，非法值被拒绝
+func TestLoadRewardConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "formula.json")
+	if err := os.WriteFile(path, []byte(`{"boss_rate": 0.6}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := config.LoadRewardConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.BossRate != 0.6 || rc.MinBase != config.DefaultConfig().Reward.MinBase {
+		t.Errorf("覆盖结果不正确: %+v", rc)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"speed_threshold": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.LoadRewardConfig(path); err == nil {
+		t.Error("期望 speed_threshold=1 校验失败")
+	}
+}
+
+// TestEvaluateFormula 按新公式重算历史出击：只有受影响的出击变化，辅助折扣按记录比例扣除，无尽按存活时间重算，练习跳过
+func TestEvaluateFormula(t *testing.T) {
+	runs := []progress.Run{
+		{ID: 1, Mode: "standard", Difficulty: 2, Kills: 40, Spawned: 40, BossKills: 1, BossesSpawned: 1, Elapsed: 30 * time.Second, Victory: true,
+			Reward: progress.RunReward{Total: 122}},
+		{ID: 2, Mode: "standard", Difficulty: 2, Kills: 40, Spawned: 40, BossKills: 1, BossesSpawned: 1, Elapsed: 30 * time.Second, Victory: true,
+			Reward: progress.RunReward{Total: 98, AssistDiscount: 24}},
+		{ID: 3, Mode: "standard", Difficulty: 1, Kills: 3, Spawned: 20, Elapsed: 20 * time.Second,
+			Reward: progress.RunReward{Total: 5}},
+		{ID: 4, Mode: "endless", Difficulty: 1, Elapsed: time.Minute,
+			Reward: progress.RunReward{Total: 15}},
+		{ID: 5, Mode: "practice", Difficulty: 1, Elapsed: time.Minute},
+	}
+
+	same := balance.EvaluateFormula(config.DefaultConfig().Reward, runs)
+	if same.Skipped != 1 || len(same.Payouts) != 4 {
+		t.Fatalf("期望重算 4 局、跳过 1 局，实际 %d / %d", len(same.Payouts), same.Skipped)
+	}
+	for _, p := range same.Payouts {
+		if p.Old != p.New {
+			t.Errorf("公式不变时第 %d 局发放变化: %d -> %d", p.Run.ID, p.Old, p.New)
+		}
+	}
+
+	rc := config.DefaultConfig().Reward
+	rc.ConsolationRatio = 0.5
+	changed := balance.EvaluateFormula(rc, runs)
+	if before, after := changed.Totals(); after-before != 2 {
+		t.Errorf("安慰奖改为 1/2 应多发 2（15/2-15/3），实际 %d -> %d", before, after)
+	}
+
+	var out bytes.Buffer
+	if err := changed.WriteSummary(&out, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "defeat") || !strings.Contains(out.String(), "1 runs skipped") {
+		t.Errorf("汇总缺少内容:\n%s", out.String())
+	}
+}
+
+// TestEvaluateFormulaUnkilledBoss Boss 出场但未被击杀时，重算按出场数计入生成数，与实际结算一致
+func TestEvaluateFormulaUnkilledBoss(t *testing.T) {
+	total := config.DefaultConfig().TotalDuration
+	paid := balance.SettleStandardReward(2, 38, 41, 50*time.Second, total, true).TotalReward
+	if balance.SettleStandardReward(2, 38, 40, 50*time.Second, total, true).TotalReward == paid {
+		t.Fatal("夹具无法区分 Boss 是否计入生成数")
+	}
+	runs := []progress.Run{{ID: 1, Mode: "standard", Difficulty: 2, Kills: 38, Spawned: 40, BossesSpawned: 1,
+		Elapsed: 50 * time.Second, Victory: true, Reward: progress.RunReward{Total: paid}}}
+
+	eval := balance.EvaluateFormula(config.DefaultConfig().Reward, runs)
+	if len(eval.Payouts) != 1 || eval.Payouts[0].New != paid {
+		t.Errorf("公式不变时重算应与实际发放 %d 一致: %+v", paid, eval.Payouts)
+	}
+}
+
+// TestSurvivalAndAssistWith 无尽奖励与辅助折扣按传入的公式参数计算，默认参数与包装函数一致
+func TestSurvivalAndAssistWith(t *testing.T) {
+	rc := config.DefaultConfig().Reward
+	if got, want := balance.ComputeSurvivalRewardWith(rc, 2, 3*time.Minute, 2), balance.ComputeSurvivalReward(2, 3*time.Minute, 2); got != want {
+		t.Errorf("默认参数的无尽奖励 %+v，期望 %+v", got, want)
+	}
+	full := balance.RewardBreakdown{TotalReward: 100}
+	if got, want := balance.ApplyAssistDiscountWith(rc, full, 0.25, 0.5), balance.ApplyAssistDiscount(full, 0.25, 0.5); got != want {
+		t.Errorf("默认参数的辅助折扣 %+v，期望 %+v", got, want)
+	}
+
+	rc.BossRate = 0.6
+	if got, def := balance.ComputeSurvivalRewardWith(rc, 2, time.Minute, 1), balance.ComputeSurvivalReward(2, time.Minute, 1); got.BossBonus != 2*def.BossBonus {
+		t.Errorf("Boss 加成比例翻倍后加成 %d，期望 %d", got.BossBonus, 2*def.BossBonus)
+	}
+
+	// 基础 10% + 平均减难达上限一半时追加 60% 的一半 = 40%
+	if got := balance.ApplyAssistDiscountWith(rc, full, 0.25, 0.5); got.AssistDiscount != 40 || got.TotalReward != 60 {
+		t.Errorf("默认折扣 %+v，期望扣除 40", got)
+	}
+	rc.AssistBaseDiscount, rc.AssistEaseDiscount = 0, 0.2
+	if got := balance.ApplyAssistDiscountWith(rc, full, 0.25, 0.5); got.AssistDiscount != 10 {
+		t.Errorf("调整后的折扣 %+v，期望扣除 10", got)
+	}
+}
+
+// TestEvaluateFormulaAssistHistory 有辅助调整记录的出击按平均减难程度与新公式的折扣参数重算
+func TestEvaluateFormulaAssistHistory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	b := balance.SettleStandardReward(2, 41, 41, 30*time.Second, cfg.TotalDuration, true)
+	paid := balance.ApplyAssistDiscount(b, 0.25, cfg.AssistMaxLevel)
+	runs := []progress.Run{{ID: 1, Mode: "standard", Difficulty: 2, Kills: 40, Spawned: 40, BossKills: 1, BossesSpawned: 1,
+		Elapsed: 30 * time.Second, Victory: true, Assist: progress.RunAssist{Enabled: true, AverageEase: 0.25},
+		Reward: progress.RunReward{Total: paid.TotalReward, AssistDiscount: paid.AssistDiscount}}}
+
+	if eval := balance.EvaluateFormula(cfg.Reward, runs); eval.Payouts[0].New != paid.TotalReward {
+		t.Errorf("公式不变时重算应与实际发放 %d 一致: %+v", paid.TotalReward, eval.Payouts)
+	}
+
+	rc := cfg.Reward
+	rc.AssistEaseDiscount = 0
+	want := balance.ApplyAssistDiscountWith(rc, b, 0.25, cfg.AssistMaxLevel).TotalReward
+	if eval := balance.EvaluateFormula(rc, runs); eval.Payouts[0].New != want || want <= paid.TotalReward {
+		t.Errorf("取消减难折扣后应发放 %d（多于 %d），实际 %+v", want, paid.TotalReward, eval.Payouts)
+	}
+}
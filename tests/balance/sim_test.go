@@ -0,0 +1,88 @@
+package balance_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"spacebattle/internal/balance/sim"
+	"spacebattle/internal/bot"
+)
+
+func simConfig(t *testing.T, difficulty, upgrades string) sim.Config {
+	t.Helper()
+	dp, err := sim.ParseDifficultyPolicy(difficulty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	up, err := sim.ParseUpgradePolicy(upgrades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := sim.ModelFor(bot.Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sim.Config{Sorties: 30, Players: 20, Seed: 7, Sortie: model, Difficulty: dp, Upgrades: up}
+}
+
+// TestRunDeterministic 相同参数与种子得到相同报告，功勋不为负，CSV 每次出击一行
+func TestRunDeterministic(t *testing.T) {
+	cfg := simConfig(t, "affordable:0.5", "balanced")
+	a, err := sim.Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := sim.Run(cfg)
+	if !reflect.DeepEqual(a.Points, b.Points) {
+		t.Fatal("相同种子的模拟结果不一致")
+	}
+	for _, p := range a.Points {
+		if p.MeritsMean < 0 || p.ClearRate < 0 || p.ClearRate > 1 {
+			t.Fatalf("第 %d 次出击统计异常: %+v", p.Sortie, p)
+		}
+	}
+	if last := a.Points[len(a.Points)-1]; last.Power <= 1 || last.Difficulty <= 1 {
+		t.Errorf("购买升级并提升难度后火力与难度应增长: %+v", last)
+	}
+
+	var csv bytes.Buffer
+	if err := a.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(csv.String(), "\n"); lines != cfg.Sorties+1 {
+		t.Errorf("CSV 行数 %d，期望 %d", lines, cfg.Sorties+1)
+	}
+	var html bytes.Buffer
+	if err := a.WriteHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(html.String(), "<svg"); n != 4 {
+		t.Errorf("HTML 图表数 %d，期望 4", n)
+	}
+}
+
+// TestDiagnoseStalledEconomy 固定最低难度且从不升级时报告停滞与功勋堆积
+func TestDiagnoseStalledEconomy(t *testing.T) {
+	report, err := sim.Run(simConfig(t, "fixed:1", "none"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := strings.Join(report.Diagnose(), "\n")
+	if !strings.Contains(notes, "stalled") || !strings.Contains(notes, "runaway") {
+		t.Errorf("未识别停滞经济: %q", notes)
+	}
+}
+
+// TestParsePolicyErrors 非法策略返回错误
+func TestParsePolicyErrors(t *testing.T) {
+	for _, s := range []string{"fixed", "fixed:-1", "steep:2", "affordable:x"} {
+		if _, err := sim.ParseDifficultyPolicy(s); err == nil {
+			t.Errorf("ParseDifficultyPolicy(%q) 应返回错误", s)
+		}
+	}
+	if _, err := sim.ParseUpgradePolicy("random"); err == nil {
+		t.Error("未知升级策略应返回错误")
+	}
+}
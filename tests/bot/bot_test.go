@@ -0,0 +1,127 @@
+package bot_test
+
+import (
+	"testing"
+
+	"spacebattle/internal/bot"
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// step 推进一个 tick：机器人写输入后按速度移动所有实体（代替依赖图形库的移动系统）
+func step(w *ecs.World, pilot *bot.Pilot) {
+	if pilot != nil {
+		pilot.Update(w, ecs.TickSeconds)
+	}
+	query.NewQuery(filter.Contains(components.Position, components.Velocity)).Each(w.ECS.World, func(entry *donburi.Entry) {
+		pos := components.Position.Get(entry)
+		vel := components.Velocity.Get(entry)
+		pos.X += vel.VX * ecs.TickSeconds
+		pos.Y += vel.VY * ecs.TickSeconds
+	})
+}
+
+// hit 玩家是否与敌机子弹重叠
+func hit(w *ecs.World, player *donburi.Entry) bool {
+	p := components.Position.Get(player)
+	ps := components.Size.Get(player)
+	overlap := false
+	query.NewQuery(filter.Contains(tags.EnemyBullet)).Each(w.ECS.World, func(entry *donburi.Entry) {
+		b := components.Position.Get(entry)
+		bs := components.Size.Get(entry)
+		if p.X < b.X+bs.Width && p.X+ps.Width > b.X && p.Y < b.Y+bs.Height && p.Y+ps.Height > b.Y {
+			overlap = true
+		}
+	})
+	return overlap
+}
+
+// volley 一名玩家与正对其落下的一排子弹
+func volley() (*ecs.World, *donburi.Entry) {
+	w := ecs.NewWorld()
+	w.Seed(1)
+	player := w.CreatePlayer(0, 380, 480, 40, 30, 5, components.FireSkillData{})
+	for i := range 3 {
+		w.CreateEnemyBullet(385+float64(i)*12, 300, 0, 6)
+	}
+	return w, player
+}
+
+// TestPilotDodgesIncomingBullets 不操作时会被击中，机器人预判后躲开同一排子弹并持续射击
+func TestPilotDodgesIncomingBullets(t *testing.T) {
+	w, player := volley()
+	struck := false
+	for range 60 {
+		step(w, nil)
+		struck = struck || hit(w, player)
+	}
+	if !struck {
+		t.Fatal("场景无效：不操作时子弹未命中玩家")
+	}
+
+	w, player = volley()
+	pilot := bot.NewPilot(bot.Perfect, 1)
+	for tick := range 60 {
+		step(w, pilot)
+		if hit(w, player) {
+			t.Fatalf("第 %d tick 被击中，位置 %+v", tick, *components.Position.Get(player))
+		}
+		if !components.PlayerInput.Get(player).Fire {
+			t.Fatalf("第 %d tick 未射击", tick)
+		}
+	}
+}
+
+// TestPilotReactionDelay 反应延迟内保持原地，之后才执行决策
+func TestPilotReactionDelay(t *testing.T) {
+	w, player := volley()
+	skill := bot.Hard
+	pilot := bot.NewPilot(skill, 1)
+	delay := int(skill.ReactionDelay / ecs.TickDuration)
+	for tick := range delay {
+		step(w, pilot)
+		if vel := components.Velocity.Get(player); vel.VX != 0 || vel.VY != 0 {
+			t.Fatalf("第 %d tick 在反应延迟内移动: %+v", tick, *vel)
+		}
+	}
+	step(w, pilot)
+	if vel := components.Velocity.Get(player); vel.VX == 0 && vel.VY == 0 {
+		t.Fatal("反应延迟结束后仍未移动")
+	}
+}
+
+// TestSkillByName 预设可按名称查找
+func TestSkillByName(t *testing.T) {
+	for _, s := range bot.Skills {
+		got, ok := bot.SkillByName(s.Name)
+		if !ok || got != s {
+			t.Errorf("SkillByName(%q) = %+v, %v", s.Name, got, ok)
+		}
+	}
+	if _, ok := bot.SkillByName("godlike"); ok {
+		t.Error("未知名称不应找到预设")
+	}
+}
+
+// TestPilotAvoidsBoss Boss 挡在巡航高度前：机器人瞄准 Boss 但不撞上它的机体
+func TestPilotAvoidsBoss(t *testing.T) {
+	w := ecs.NewWorld()
+	w.Seed(1)
+	player := w.CreatePlayer(0, 380, 560, 40, 30, 5, components.FireSkillData{})
+	boss := w.CreateBoss(340, 440, 0, 0, 120, 60, 100)
+
+	pilot := bot.NewPilot(bot.Perfect, 1)
+	for tick := range 60 {
+		step(w, pilot)
+		p, ps := components.Position.Get(player), components.Size.Get(player)
+		b, bs := components.Position.Get(boss), components.Size.Get(boss)
+		if p.X < b.X+bs.Width && p.X+ps.Width > b.X && p.Y < b.Y+bs.Height && p.Y+ps.Height > b.Y {
+			t.Fatalf("第 %d tick 撞上 Boss，位置 %+v", tick, *p)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ecs_test
+
+import (
+	"slices"
+	"testing"
+
+	"spacebattle/internal/ecs"
+)
+
+// TestSchedulerPhases 系统按阶段顺序执行，停用的系统被跳过，tick 末尾派发事件
+func TestSchedulerPhases(t *testing.T) {
+	w := ecs.NewWorld()
+	sched := ecs.NewScheduler()
+
+	var order []string
+	record := func(name string) ecs.System {
+		return ecs.SystemFunc(func(w *ecs.World, dt float64) {
+			order = append(order, name)
+			ecs.Publish(w.Events, ecs.BulletFired{Bullets: 1})
+		})
+	}
+	// 注册顺序与阶段顺序相反
+	sched.Add(ecs.PhaseEffects, "effects", record("effects"))
+	sched.Add(ecs.PhaseCollision, "collision", record("collision"))
+	sched.Add(ecs.PhaseInput, "input", record("input"))
+	sched.Add(ecs.PhaseCollision, "life", record("life"))
+
+	fired := 0
+	systemsRun := 4
+	ecs.Subscribe(w.Events, func(e ecs.BulletFired) {
+		// 订阅者在所有系统之后才收到事件
+		if len(order) != systemsRun {
+			t.Errorf("事件在系统执行途中被派发: %v", order)
+		}
+		fired += e.Bullets
+	})
+
+	sched.Update(w, 1.0/60)
+	if want := []string{"input", "collision", "life", "effects"}; !slices.Equal(order, want) {
+		t.Errorf("执行顺序: 期望 %v，实际得到 %v", want, order)
+	}
+	if fired != 4 || w.Events.Pending() != 0 {
+		t.Errorf("期望派发 4 个事件，实际 %d（剩余 %d）", fired, w.Events.Pending())
+	}
+
+	if !sched.SetEnabled("collision", false) || sched.Enabled("collision") {
+		t.Fatal("停用系统失败")
+	}
+	if sched.SetEnabled("missing", false) {
+		t.Error("不存在的系统应返回 false")
+	}
+	order = nil
+	fired = 0
+	systemsRun = 3
+	sched.Update(w, 1.0/60)
+	if want := []string{"input", "life", "effects"}; !slices.Equal(order, want) {
+		t.Errorf("停用后执行顺序: 期望 %v，实际得到 %v", want, order)
+	}
+
+	for _, st := range sched.Stats() {
+		want := 2
+		if st.Name == "collision" {
+			want = 1
+		}
+		if st.Calls != want {
+			t.Errorf("%s 调用次数: 期望 %d，实际得到 %d", st.Name, want, st.Calls)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ecs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/tags"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// loadFixture 从 testdata 读取中局快照并恢复到新世界
+func loadFixture(t *testing.T, name string) *ecs.World {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("读取夹具失败: %v", err)
+	}
+	snap, err := ecs.DecodeSnapshot(data)
+	if err != nil {
+		t.Fatalf("解析快照失败: %v", err)
+	}
+	w := ecs.NewWorld()
+	if err := w.Restore(snap); err != nil {
+		t.Fatalf("恢复快照失败: %v", err)
+	}
+	return w
+}
+
+// count 统计含指定组件的实体数
+func count(w *ecs.World, c donburi.IComponentType) int {
+	return query.NewQuery(filter.Contains(c)).Count(w.ECS.World)
+}
+
+// TestSnapshotRestoreFixture 从夹具恢复的世界与拍摄时一致：实体、组件、实体引用、随机序列与模拟时钟
+func TestSnapshotRestoreFixture(t *testing.T) {
+	w := loadFixture(t, "midbattle.json")
+
+	if got := count(w, tags.Player); got != 1 {
+		t.Errorf("期望 1 名玩家，实际 %d", got)
+	}
+	if got := count(w, tags.Star); got != 3 {
+		t.Errorf("期望 3 颗星星，实际 %d", got)
+	}
+
+	gs, ok := components.GameState.First(w.ECS.World)
+	if !ok {
+		t.Fatal("缺少游戏状态")
+	}
+	state := components.GameState.Get(gs)
+	if state.Mode != components.ModeEndless || state.Score != 1200 || state.WaveReached != 3 {
+		t.Errorf("游戏状态不一致: %+v", state)
+	}
+	// 对局时间按模拟时钟计算，与恢复时的真实时间无关
+	if elapsed := state.Elapsed(); elapsed != 95*time.Second {
+		t.Errorf("期望已进行 95s，实际 %v", elapsed)
+	}
+	if boss := state.CurrentBoss(); boss == nil || state.Clock.Sub(boss.SpawnTime) != 10*time.Second {
+		t.Errorf("Boss 计时与模拟时钟不一致: %+v", boss)
+	}
+
+	// 追踪子弹的目标指向恢复后的 Boss
+	bullet, ok := components.Homing.First(w.ECS.World)
+	if !ok {
+		t.Fatal("缺少追踪子弹")
+	}
+	target := components.Homing.Get(bullet).TargetEntity
+	if !w.ECS.World.Valid(target) || !w.ECS.World.Entry(target).HasComponent(tags.Boss) {
+		t.Fatalf("追踪目标未映射到 Boss: %v", target)
+	}
+	if hp := components.Health.Get(w.ECS.World.Entry(target)); hp.Current != 31 || hp.Max != 50 {
+		t.Errorf("Boss 生命不一致: %+v", hp)
+	}
+
+	// 再次拍摄并恢复，随机序列与实体数保持一致
+	snap, err := w.Snapshot()
+	if err != nil {
+		t.Fatalf("拍摄快照失败: %v", err)
+	}
+	copyWorld := ecs.NewWorld()
+	if err := copyWorld.Restore(snap); err != nil {
+		t.Fatalf("恢复快照失败: %v", err)
+	}
+	if a, b := w.ECS.World.Len(), copyWorld.ECS.World.Len(); a != b {
+		t.Errorf("实体数不一致: %d vs %d", a, b)
+	}
+	for i := range 5 {
+		if a, b := w.Rand.Int63(), copyWorld.Rand.Int63(); a != b {
+			t.Fatalf("第 %d 个随机数不一致: %d vs %d", i, a, b)
+		}
+	}
+}
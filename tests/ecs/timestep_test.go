@@ -0,0 +1,60 @@
+package ecs_test
+
+import (
+	"testing"
+	"time"
+
+	"spacebattle/internal/ecs"
+	"spacebattle/internal/ecs/components"
+)
+
+// TestFixedStepAdvance 累加器按整 tick 推进，余量保留给插值，卡顿时丢弃超出上限的时长
+func TestFixedStepAdvance(t *testing.T) {
+	var step ecs.FixedStep
+
+	if got := step.Advance(ecs.TickDuration / 2); got != 0 {
+		t.Fatalf("half tick advanced %d ticks, want 0", got)
+	}
+	if a := step.Alpha(0); a < 0.49 || a > 0.51 {
+		t.Fatalf("alpha after half tick = %.3f, want 0.5", a)
+	}
+	if got := step.Advance(ecs.TickDuration); got != 1 {
+		t.Fatalf("advanced %d ticks, want 1", got)
+	}
+	if a := step.Alpha(time.Hour); a != 1 {
+		t.Fatalf("alpha = %.3f, want clamped to 1", a)
+	}
+
+	if got := step.Advance(time.Second); got != 5 {
+		t.Fatalf("stall advanced %d ticks, want 5", got)
+	}
+	if a := step.Alpha(0); a != 0 {
+		t.Fatalf("alpha after stall = %.3f, want 0", a)
+	}
+
+	if v := ecs.PerSecond(2); v != 120 {
+		t.Fatalf("PerSecond(2) = %v, want 120", v)
+	}
+}
+
+// TestGameStateClock 对局计时只随模拟时钟推进，与真实时间无关
+func TestGameStateClock(t *testing.T) {
+	w := ecs.NewWorld()
+	if now := components.Now(w.ECS.World); !now.IsZero() {
+		t.Fatalf("clock without game state = %v, want zero", now)
+	}
+
+	state := components.GameState.Get(w.CreateGameState(3, 1))
+	if state.Elapsed() != 0 {
+		t.Fatalf("elapsed at start = %v, want 0", state.Elapsed())
+	}
+	for range 90 {
+		state.Advance(ecs.TickDuration)
+	}
+	if got, want := state.Elapsed(), 90*ecs.TickDuration; got != want {
+		t.Fatalf("elapsed after 90 ticks = %v, want %v", got, want)
+	}
+	if now := components.Now(w.ECS.World); !now.Equal(state.Clock) {
+		t.Fatalf("Now = %v, want game state clock %v", now, state.Clock)
+	}
+}
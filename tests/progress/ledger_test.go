@@ -0,0 +1,181 @@
+package progress_test
+
+import (
+	"errors"
+	"testing"
+
+	"spacebattle/internal/progress"
+)
+
+// checkMeritLedger 功勋流水：迁移时的期初余额、余额不足、撤销与核对（数据库只能初始化一次，由 TestMigrateLegacyKV 调用）
+func checkMeritLedger(t *testing.T) {
+	t.Helper()
+	history, err := progress.MeritHistory(0)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("期望 2 笔流水，实际 %+v (%v)", history, err)
+	}
+	if opening := history[1]; opening.Source != progress.SourceOpeningBalance || opening.Amount != 120 || opening.Balance != 120 {
+		t.Errorf("期初余额不一致: %+v", opening)
+	}
+	if reward := history[0]; reward.Source != progress.SourceBattleReward || reward.Amount != 30 || reward.Balance != 150 {
+		t.Errorf("战斗奖励不一致: %+v", reward)
+	}
+
+	// 余额不足时整笔失败，不记账
+	if err := progress.SpendMerits(1000, progress.SourceDifficultyCost, "9.00"); !errors.Is(err, progress.ErrInsufficientMerits) {
+		t.Errorf("余额不足应失败，实际 %v", err)
+	}
+	if progress.GetMerits() != 150 {
+		t.Errorf("失败的花费不应改变余额，实际 %d", progress.GetMerits())
+	}
+
+	// 连续撤销两次加点，依次向前
+	ref := progress.UpgradeRef("alpha", "fire_rate")
+	for _, cost := range []int{8, 16} {
+		if err := progress.SpendMerits(cost, progress.SourceUpgradePurchase, ref); err != nil {
+			t.Fatalf("加点失败: %v", err)
+		}
+	}
+	for _, want := range []int{-16, -8} {
+		last, ok, err := progress.LastMeritEntry()
+		if err != nil || !ok || last.Source != progress.SourceUpgradePurchase || last.Amount != want {
+			t.Fatalf("期望最近一笔为 %d 的加点，实际 %+v (%v)", want, last, err)
+		}
+		if err := progress.UndoMeritEntry(last); err != nil {
+			t.Fatalf("撤销失败: %v", err)
+		}
+		if err := progress.UndoMeritEntry(last); !errors.Is(err, progress.ErrNothingToUndo) {
+			t.Errorf("重复撤销应失败，实际 %v", err)
+		}
+	}
+	if progress.GetMerits() != 150 {
+		t.Errorf("撤销后期望功勋 150，实际 %d", progress.GetMerits())
+	}
+	if last, _, _ := progress.LastMeritEntry(); last.Source != progress.SourceBattleReward {
+		t.Errorf("撤销后最近一笔应为战斗奖励，实际 %+v", last)
+	}
+
+	// 购买战机：扣款与解锁同时生效
+	if err := progress.BuyShip("gamma", 50); err != nil || !progress.IsShipOwned("gamma") {
+		t.Errorf("购买战机失败: %v", err)
+	}
+	if err := progress.BuyShip("delta", 500); !errors.Is(err, progress.ErrInsufficientMerits) || progress.IsShipOwned("delta") {
+		t.Errorf("功勋不足时不应解锁战机，实际 %v", err)
+	}
+
+	if balance, err := progress.AuditMerits(); err != nil || balance != 100 || progress.GetMerits() != 100 {
+		t.Errorf("核对失败: 流水 %d，内存 %d (%v)", balance, progress.GetMerits(), err)
+	}
+	if history, _ := progress.MeritHistory(3); len(history) != 3 || history[0].Source != progress.SourceShipUnlock {
+		t.Errorf("最近流水不一致: %+v", history)
+	}
+}
+
+// checkUpgradeLedger 加点、减点、撤销、套用方案与天赋：记账与保存等级在同一事务中，失败时两者都不生效
+// （接在 checkMeritLedger 之后，余额 100）
+func checkUpgradeLedger(t *testing.T) {
+	t.Helper()
+	levelsOf := func() progress.UpgradeData {
+		u, err := progress.GetShipUpgrades("gamma")
+		if err != nil {
+			t.Fatalf("读取加点失败: %v", err)
+		}
+		return u
+	}
+
+	if err := progress.PurchaseUpgrade("gamma", "fire_rate", 8, progress.UpgradeData{"fire_rate": 1}); err != nil {
+		t.Fatalf("加点失败: %v", err)
+	}
+	if err := progress.PurchaseUpgrade("gamma", "fire_rate", 1000, progress.UpgradeData{"fire_rate": 2}); !errors.Is(err, progress.ErrInsufficientMerits) {
+		t.Errorf("余额不足应失败，实际 %v", err)
+	}
+	if levelsOf()["fire_rate"] != 1 || progress.GetMerits() != 92 {
+		t.Errorf("加点后期望 1 级、功勋 92，实际 %v / %d", levelsOf(), progress.GetMerits())
+	}
+
+	// 撤销加点同时降级；已撤销的记录不能再撤销，等级保持不变
+	last, ok, err := progress.LastMeritEntry()
+	if err != nil || !ok || last.Ref != progress.UpgradeRef("gamma", "fire_rate") {
+		t.Fatalf("最近一笔应为加点，实际 %+v (%v)", last, err)
+	}
+	if err := progress.UndoUpgradePurchase(last, "gamma", progress.UpgradeData{}); err != nil {
+		t.Fatalf("撤销加点失败: %v", err)
+	}
+	if err := progress.UndoUpgradePurchase(last, "gamma", progress.UpgradeData{"fire_rate": 5}); !errors.Is(err, progress.ErrNothingToUndo) {
+		t.Errorf("重复撤销应失败，实际 %v", err)
+	}
+	if levelsOf()["fire_rate"] != 0 || progress.GetMerits() != 100 {
+		t.Errorf("撤销后期望 0 级、功勋 100，实际 %v / %d", levelsOf(), progress.GetMerits())
+	}
+
+	// 减点退还
+	if err := progress.PurchaseUpgrade("gamma", "penetration", 10, progress.UpgradeData{"penetration": 1}); err != nil {
+		t.Fatalf("加点失败: %v", err)
+	}
+	if err := progress.RefundUpgrade("gamma", "penetration", 10, progress.UpgradeData{}); err != nil {
+		t.Fatalf("减点失败: %v", err)
+	}
+	if levelsOf()["penetration"] != 0 || progress.GetMerits() != 100 {
+		t.Errorf("减点后期望 0 级、功勋 100，实际 %v / %d", levelsOf(), progress.GetMerits())
+	}
+
+	// 套用方案：逐级记账，退还先于花费；功勋不足时整套不变
+	build := []progress.UpgradeStep{{Upgrade: "bullet_damage", Amount: -8}, {Upgrade: "bullet_damage", Amount: -12}}
+	if err := progress.RespecUpgrades("gamma", build, progress.UpgradeData{"bullet_damage": 2}); err != nil {
+		t.Fatalf("套用方案失败: %v", err)
+	}
+	swap := []progress.UpgradeStep{{Upgrade: "penetration", Amount: -12}, {Upgrade: "bullet_damage", Amount: 12}}
+	if err := progress.RespecUpgrades("gamma", swap, progress.UpgradeData{"bullet_damage": 1, "penetration": 1}); err != nil {
+		t.Fatalf("切换方案失败: %v", err)
+	}
+	history, err := progress.MeritHistory(4)
+	if err != nil || len(history) != 4 {
+		t.Fatalf("期望 4 笔流水，实际 %+v (%v)", history, err)
+	}
+	wantHistory := []struct {
+		source progress.MeritSource
+		ref    string
+		amount int
+	}{
+		{progress.SourceUpgradePurchase, progress.UpgradeRef("gamma", "penetration"), -12},
+		{progress.SourceUpgradeRefund, progress.UpgradeRef("gamma", "bullet_damage"), 12},
+		{progress.SourceUpgradePurchase, progress.UpgradeRef("gamma", "bullet_damage"), -12},
+		{progress.SourceUpgradePurchase, progress.UpgradeRef("gamma", "bullet_damage"), -8},
+	}
+	for i, want := range wantHistory {
+		if e := history[i]; e.Source != want.source || e.Ref != want.ref || e.Amount != want.amount {
+			t.Errorf("第 %d 笔流水 %+v，期望 %+v", i, e, want)
+		}
+	}
+	overdraw := []progress.UpgradeStep{{Upgrade: "bullet_damage", Amount: 12}, {Upgrade: "penetration", Amount: -1000}}
+	if err := progress.RespecUpgrades("gamma", overdraw, progress.UpgradeData{"penetration": 9}); !errors.Is(err, progress.ErrInsufficientMerits) {
+		t.Errorf("余额不足应失败，实际 %v", err)
+	}
+	if last, ok, err := progress.LastMeritEntry(); err != nil || !ok || last.ID != history[0].ID {
+		t.Errorf("失败的切换不应留下流水，最近一笔 %+v (%v)", last, err)
+	}
+	if levels := levelsOf(); levels["bullet_damage"] != 1 || levels["penetration"] != 1 || progress.GetMerits() != 80 {
+		t.Errorf("套用方案后期望伤害与穿透各 1 级、功勋 80，实际 %v / %d", levels, progress.GetMerits())
+	}
+
+	// 天赋：升级与重置同样与记账一同保存
+	if err := progress.PurchaseTalent("hull", 10, map[string]int{"hull": 1}); err != nil {
+		t.Fatalf("天赋升级失败: %v", err)
+	}
+	if err := progress.PurchaseTalent("hull", 1000, map[string]int{"hull": 2}); !errors.Is(err, progress.ErrInsufficientMerits) {
+		t.Errorf("余额不足应失败，实际 %v", err)
+	}
+	if talents, err := progress.GetTalents(); err != nil || talents["hull"] != 1 || progress.GetMerits() != 70 {
+		t.Errorf("天赋升级后期望 1 级、功勋 70，实际 %v / %d (%v)", talents, progress.GetMerits(), err)
+	}
+	if err := progress.ResetTalents(10); err != nil {
+		t.Fatalf("天赋重置失败: %v", err)
+	}
+	if talents, err := progress.GetTalents(); err != nil || len(talents) != 0 || progress.GetMerits() != 80 {
+		t.Errorf("天赋重置后期望清空、功勋 80，实际 %v / %d (%v)", talents, progress.GetMerits(), err)
+	}
+
+	if balance, err := progress.AuditMerits(); err != nil || balance != 80 {
+		t.Errorf("核对失败: 流水 %d (%v)", balance, err)
+	}
+}
@@ -0,0 +1,191 @@
+package progress_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spacebattle/internal/progress"
+)
+
+// copyFixture 复制旧版存档到临时目录，避免迁移改写仓库中的夹具
+func copyFixture(t *testing.T, name string) string {
+	t.Helper()
+	src, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("打开夹具失败: %v", err)
+	}
+	defer src.Close()
+
+	path := filepath.Join(t.TempDir(), name)
+	dst, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("创建临时存档失败: %v", err)
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		t.Fatalf("复制夹具失败: %v", err)
+	}
+	return path
+}
+
+// TestMigrateLegacyKV 打开只有 kv 表的旧版存档，迁移后功勋、加点与设置保持不变
+func TestMigrateLegacyKV(t *testing.T) {
+	if err := progress.Init(copyFixture(t, "legacy_progress.db")); err != nil {
+		t.Fatalf("Init 失败: %v", err)
+	}
+	if err := progress.Load(); err != nil {
+		t.Fatalf("Load 失败: %v", err)
+	}
+
+	version, err := progress.SchemaVersion()
+	if err != nil {
+		t.Fatalf("读取结构版本失败: %v", err)
+	}
+	if version != 10 {
+		t.Errorf("期望结构版本 10，实际得到 %d", version)
+	}
+
+	if got := progress.GetMerits(); got != 120 {
+		t.Errorf("期望功勋 120，实际得到 %d", got)
+	}
+
+	// 旧版保存的是属性增量，按当时的每级步长换算为等级
+	want := progress.UpgradeData{
+		"fire_rate":        3,
+		"bullets_per_shot": 2,
+		"penetration":      1,
+		"spread_narrow":    1,
+		"bullet_speed":     1,
+		"bullet_damage":    3,
+		"burst_chance":     2,
+		"enable_homing":    1,
+		"turn_rate":        1,
+	}
+	got, err := progress.GetUpgrades()
+	if err != nil {
+		t.Fatalf("读取加点失败: %v", err)
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("加点迁移不一致: 期望 %+v，实际得到 %+v", want, got)
+	}
+	// 默认战机继承旧版全局加点
+	if alpha, err := progress.GetShipUpgrades("alpha"); err != nil || !maps.Equal(alpha, want) {
+		t.Errorf("默认战机加点: 期望 %+v，实际得到 %+v (%v)", want, alpha, err)
+	}
+
+	if st := progress.GetSettings(); st.ControlMode != progress.ControlModePointer || st.Players != 2 {
+		t.Errorf("设置迁移不一致: %+v", st)
+	}
+
+	if p := progress.CurrentProfile(); p.Name != "Default" {
+		t.Errorf("期望默认档案，实际得到 %+v", p)
+	}
+
+	// 迁移后的写入落在类型化的表中
+	if err := progress.AddMerits(30, progress.SourceBattleReward, "standard"); err != nil {
+		t.Fatalf("记入功勋失败: %v", err)
+	}
+	if err := progress.Load(); err != nil || progress.GetMerits() != 150 {
+		t.Errorf("期望功勋 150，实际得到 %d (%v)", progress.GetMerits(), err)
+	}
+	checkMeritLedger(t)
+	checkUpgradeLedger(t)
+	assist := progress.RunAssist{Enabled: true, AverageEase: 0.05, Changes: []progress.AssistChange{
+		{At: 0, Level: 0},
+		{At: 20 * time.Second, Level: 0.1},
+	}}
+	if err := progress.AddRun(progress.Run{Mode: "standard", ShipID: "beta", Score: 500, BossesSpawned: 1, Victory: true, Upgrades: want, Assist: assist}); err != nil {
+		t.Fatalf("写入出击记录失败: %v", err)
+	}
+	st, err := progress.GetRunStats()
+	if err != nil || st.Runs != 1 || st.WinRate() != 1 || st.FavoriteShip != "beta" {
+		t.Errorf("出击统计不一致: %+v (%v)", st, err)
+	}
+	runs, err := progress.ListRuns(0)
+	if err != nil || len(runs) != 1 || !maps.Equal(runs[0].Upgrades, want) {
+		t.Errorf("出击记录的加点快照不一致: %+v (%v)", runs, err)
+	}
+	if len(runs) == 1 {
+		if got := runs[0].Assist; !got.Enabled || got.AverageEase != 0.05 || len(got.Changes) != 2 || got.Changes[1] != assist.Changes[1] {
+			t.Errorf("出击记录的辅助调整不一致: %+v", got)
+		}
+		if runs[0].BossesSpawned != 1 || runs[0].BossKills != 0 {
+			t.Errorf("出击记录的 Boss 出场与击杀数不一致: %d / %d", runs[0].BossesSpawned, runs[0].BossKills)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := progress.ExportRunsCSV(&buf); err != nil {
+		t.Fatalf("导出 CSV 失败: %v", err)
+	}
+	if !strings.Contains(buf.String(), "0.0:0 20.0:0.1") {
+		t.Errorf("CSV 缺少辅助调整记录: %s", buf.String())
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("期望 CSV 含表头与 1 条记录，实际 %d 行", lines)
+	}
+
+	// 成就累计计数与达成时间
+	if err := progress.SaveAchievementCounters(map[string]int{"kills_1000": 42}); err != nil {
+		t.Fatalf("写入成就计数失败: %v", err)
+	}
+	if counters, err := progress.AchievementCounters(); err != nil || counters["kills_1000"] != 42 {
+		t.Errorf("成就计数不一致: %v (%v)", counters, err)
+	}
+	if first, err := progress.UnlockAchievement("first_victory"); err != nil || !first {
+		t.Errorf("首次达成应返回 true (%v)", err)
+	}
+	if again, _ := progress.UnlockAchievement("first_victory"); again {
+		t.Error("重复达成应返回 false")
+	}
+	if unlocks, err := progress.AchievementUnlocks(); err != nil || unlocks["first_victory"].IsZero() {
+		t.Errorf("成就达成时间缺失: %v (%v)", unlocks, err)
+	}
+
+	// 挂起的出击：每个档案一条，覆盖写入，继续后删除
+	if progress.HasSuspendedRun() {
+		t.Error("新档案不应有挂起的出击")
+	}
+	for _, snapshot := range []string{"first", "second"} {
+		if err := progress.SaveSuspendedRun(progress.SuspendedRun{Options: []byte(`{"Mode":"endless"}`), Snapshot: []byte(snapshot)}); err != nil {
+			t.Fatalf("挂起出击失败: %v", err)
+		}
+	}
+	if run, ok := progress.GetSuspendedRun(); !ok || string(run.Snapshot) != "second" || string(run.Options) != `{"Mode":"endless"}` {
+		t.Errorf("挂起的出击不一致: %+v (%v)", run, ok)
+	}
+	if err := progress.ClearSuspendedRun(); err != nil || progress.HasSuspendedRun() {
+		t.Errorf("删除挂起的出击失败: %v", err)
+	}
+
+	// 加点方案：按名称覆盖，按创建顺序列出，名称需为 1-16 个字符
+	for _, p := range []progress.UpgradePreset{
+		{Name: "boss", Upgrades: progress.UpgradeData{"bullet_damage": 3}},
+		{Name: "waves", Upgrades: progress.UpgradeData{"bullets_per_shot": 2}},
+		{Name: " boss ", Upgrades: progress.UpgradeData{"bullet_damage": 4}},
+	} {
+		if err := progress.SaveUpgradePreset(p.Name, p.Upgrades); err != nil {
+			t.Fatalf("保存加点方案失败: %v", err)
+		}
+	}
+	if err := progress.SaveUpgradePreset("  ", want); !errors.Is(err, progress.ErrPresetName) {
+		t.Errorf("空名称应被拒绝，实际 %v", err)
+	}
+	presets, err := progress.UpgradePresets()
+	if err != nil || len(presets) != 2 || presets[0].Name != "boss" || presets[0].Upgrades["bullet_damage"] != 4 {
+		t.Errorf("加点方案不一致: %+v (%v)", presets, err)
+	}
+	if err := progress.DeleteUpgradePreset("boss"); err != nil {
+		t.Fatalf("删除加点方案失败: %v", err)
+	}
+	if presets, _ := progress.UpgradePresets(); len(presets) != 1 || presets[0].Name != "waves" {
+		t.Errorf("删除后的加点方案不一致: %+v", presets)
+	}
+}
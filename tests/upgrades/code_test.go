@@ -0,0 +1,90 @@
+package upgrades_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"spacebattle/internal/upgrades"
+)
+
+// TestCodeRoundTrip 加点码编码后可原样解析，忽略大小写与分隔符
+func TestCodeRoundTrip(t *testing.T) {
+	levels := upgrades.Levels{"fire_rate": 12, "penetration": 3, "bullet_damage": 200, "burst_chance": 1}
+	code := levels.Code()
+	if len(code) > 32 {
+		t.Errorf("加点码过长: %q", code)
+	}
+	for _, text := range []string{code, strings.ToLower(code), strings.ReplaceAll(code, "-", " ")} {
+		got, err := upgrades.ParseCode(text)
+		if err != nil {
+			t.Fatalf("解析 %q 失败: %v", text, err)
+		}
+		if !maps.Equal(got, levels) {
+			t.Errorf("期望 %v，实际 %v", levels, got)
+		}
+	}
+	if got, err := upgrades.ParseCode(upgrades.Levels{}.Code()); err != nil || len(got) != 0 {
+		t.Errorf("空加点: %v (%v)", got, err)
+	}
+}
+
+// TestCodeRejectsCorruption 改动任意字符都会被校验发现
+func TestCodeRejectsCorruption(t *testing.T) {
+	code := upgrades.Levels{"fire_rate": 4, "bullets_per_shot": 2}.Code()
+	corrupted := []byte(code)
+	if corrupted[0] == 'A' {
+		corrupted[0] = 'B'
+	} else {
+		corrupted[0] = 'A'
+	}
+	if _, err := upgrades.ParseCode(string(corrupted)); !errors.Is(err, upgrades.ErrCodeChecksum) && !errors.Is(err, upgrades.ErrInvalidCode) {
+		t.Errorf("损坏的加点码应被拒绝，实际 %v", err)
+	}
+	for _, text := range []string{"", "not a code!", "AAAA"} {
+		if _, err := upgrades.ParseCode(text); err == nil {
+			t.Errorf("%q 应解析失败", text)
+		}
+	}
+}
+
+// TestRespec 切换方案时按逐级成本退还与花费，目标中不可购买的等级被拒绝
+func TestRespec(t *testing.T) {
+	current := upgrades.Levels{"fire_rate": 3, "penetration": 1}
+	change, err := upgrades.Respec(current, upgrades.Levels{"fire_rate": 1, "bullets_per_shot": 2})
+	if err != nil {
+		t.Fatalf("切换失败: %v", err)
+	}
+	// 射速 3→1 退还 2+4，穿透 1→0 退还 2；子弹数 0→2 花费 3+6
+	if change.Refund != 8 || change.Cost != 9 {
+		t.Errorf("期望退还 8、花费 9，实际 %+v", change)
+	}
+	if change.Levels["fire_rate"] != 1 || change.Levels["penetration"] != 0 || change.Levels["bullets_per_shot"] != 2 {
+		t.Errorf("切换后的等级不一致: %v", change.Levels)
+	}
+	// 逐级变动：先从最高一级退还，再逐级花费
+	wantSteps := []upgrades.Step{
+		{Key: "fire_rate", Amount: 4}, {Key: "fire_rate", Amount: 2}, {Key: "penetration", Amount: 2},
+		{Key: "bullets_per_shot", Amount: -3}, {Key: "bullets_per_shot", Amount: -6},
+	}
+	if !slices.Equal(change.Steps, wantSteps) {
+		t.Errorf("逐级变动 %+v，期望 %+v", change.Steps, wantSteps)
+	}
+
+	if _, err := upgrades.Respec(current, upgrades.Levels{"enable_homing": 1}); !errors.Is(err, upgrades.ErrDisabled) {
+		t.Errorf("未开放的升级应被拒绝，实际 %v", err)
+	}
+	if _, err := upgrades.Respec(current, upgrades.Levels{"penetration": 11}); !errors.Is(err, upgrades.ErrMaxLevel) {
+		t.Errorf("超出上限应被拒绝，实际 %v", err)
+	}
+	// 已有的关闭项等级可以保留或退还
+	legacy := upgrades.Levels{"enable_homing": 1, "turn_rate": 1}
+	if change, err := upgrades.Respec(legacy, legacy); err != nil || change.Cost != 0 || change.Refund != 0 {
+		t.Errorf("保留已有等级: %+v (%v)", change, err)
+	}
+	if change, err := upgrades.Respec(legacy, upgrades.Levels{}); err != nil || change.Refund != 8+2 {
+		t.Errorf("全部退还: %+v (%v)", change, err)
+	}
+}
@@ -0,0 +1,104 @@
+package upgrades_test
+
+import (
+	"errors"
+	"testing"
+
+	"spacebattle/internal/config"
+	"spacebattle/internal/upgrades"
+)
+
+// TestRegistryCaps 各升级的最高等级由属性上限与每级增量推出，与旧版升级界面一致
+func TestRegistryCaps(t *testing.T) {
+	want := map[string]int{
+		"fire_rate":        50,
+		"bullets_per_shot": 19,
+		"penetration":      10,
+		"spread_narrow":    35,
+		"bullet_speed":     44,
+		"bullet_damage":    0,
+		"burst_chance":     20,
+		"enable_homing":    1,
+		"turn_rate":        49,
+	}
+	if len(upgrades.Registry) != len(want) {
+		t.Fatalf("期望 %d 项升级，实际 %d", len(want), len(upgrades.Registry))
+	}
+	for _, u := range upgrades.Registry {
+		if got := u.MaxLevel(); got != want[u.Key] {
+			t.Errorf("%s: 期望最高 %d 级，实际 %d", u.Key, want[u.Key], got)
+		}
+	}
+}
+
+// TestApplyClamps 加成叠加到基础射击参数，超出上限时截断
+func TestApplyClamps(t *testing.T) {
+	cfg := config.DefaultConfig()
+	fire := upgrades.Levels{"fire_rate": 4, "bullets_per_shot": 1000, "bullet_damage": 2, "enable_homing": 1, "unknown": 3}.FireSkill()
+	if fire.FireRateHz != 7 {
+		t.Errorf("期望射速 7，实际 %v", fire.FireRateHz)
+	}
+	if fire.BulletsPerShot != cfg.MaxBulletsPerShot {
+		t.Errorf("期望弹数截断为 %d，实际 %d", cfg.MaxBulletsPerShot, fire.BulletsPerShot)
+	}
+	if fire.BulletDamage != 3 || !fire.EnableHoming {
+		t.Errorf("伤害或追踪不一致: %+v", fire)
+	}
+	if base := upgrades.BaseFireSkill(); fire.SpreadDeg != base.SpreadDeg || fire.BurstInterval != base.BurstInterval {
+		t.Errorf("未加点的属性应保持基础值: %+v", fire)
+	}
+}
+
+// TestNextCostAndRefund 成本曲线、关闭购买、前置条件与退还
+func TestNextCostAndRefund(t *testing.T) {
+	levels := upgrades.Levels{"fire_rate": 3}
+	if cost, err := levels.NextCost("fire_rate"); err != nil || cost != 8 {
+		t.Errorf("期望第 4 级成本 8，实际 %d (%v)", cost, err)
+	}
+	if _, err := levels.NextCost("enable_homing"); !errors.Is(err, upgrades.ErrDisabled) {
+		t.Errorf("追踪未开放购买，实际 %v", err)
+	}
+	// 依赖关闭购买的升级同样不开放，也不出现在界面中
+	if _, err := levels.NextCost("turn_rate"); !errors.Is(err, upgrades.ErrDisabled) {
+		t.Errorf("转向依赖追踪，实际 %v", err)
+	}
+	for _, u := range levels.Visible() {
+		if u.Key == "enable_homing" || u.Key == "turn_rate" {
+			t.Errorf("%s 不应显示", u.Key)
+		}
+	}
+	if _, err := (upgrades.Levels{"penetration": 10}).NextCost("penetration"); !errors.Is(err, upgrades.ErrMaxLevel) {
+		t.Errorf("穿透已满级，实际 %v", err)
+	}
+	if _, err := levels.NextCost("missing"); !errors.Is(err, upgrades.ErrUnknownUpgrade) {
+		t.Errorf("未知升级，实际 %v", err)
+	}
+
+	if refund, ok := levels.Refund("fire_rate"); !ok || refund != 4 {
+		t.Errorf("期望退还 4，实际 %d (%v)", refund, ok)
+	}
+	if _, ok := levels.Refund("penetration"); ok {
+		t.Error("0 级不能退还")
+	}
+
+	// 已有等级的关闭项仍显示以便退还；仍被依赖的前置不能降到 0 级
+	legacy := upgrades.Levels{"enable_homing": 1, "turn_rate": 2}
+	if _, ok := legacy.Refund("enable_homing"); ok {
+		t.Error("转向仍有等级时不能退还追踪")
+	}
+	if refund, ok := legacy.Refund("turn_rate"); !ok || refund != 4 {
+		t.Errorf("期望退还转向 4，实际 %d (%v)", refund, ok)
+	}
+	visible := 0
+	for _, u := range legacy.Visible() {
+		if u.Key == "enable_homing" || u.Key == "turn_rate" {
+			visible++
+		}
+	}
+	if visible != 2 {
+		t.Errorf("有等级的关闭项应显示，实际 %d 项", visible)
+	}
+	if spent := legacy.Spent(); spent != 8+2+4 {
+		t.Errorf("期望已投入 14，实际 %d", spent)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build visual
+
+package visual_test
+
+import (
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// update 为 true 时用本次渲染结果覆盖 golden：go test -tags visual ./tests/visual -update
+var update = flag.Bool("update", false, "rewrite golden PNGs with the current rendering")
+
+const (
+	// channelTolerance 单个颜色通道允许的差值（抗锯齿与不同 GPU 的细微差异）
+	channelTolerance = 8
+	// maxMismatchRatio 超出通道容差的像素所占比例上限
+	maxMismatchRatio = 0.002
+)
+
+// capture 将离屏图像读回为 RGBA
+func capture(img *ebiten.Image) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	img.ReadPixels(out.Pix)
+	return out
+}
+
+// checkGolden 与 testdata/<name>.png 比较，差异超出容差时把实际渲染写入临时目录便于对照
+func checkGolden(t *testing.T, name string, got *image.RGBA) {
+	t.Helper()
+	path := filepath.Join(testdataDir, name+".png")
+	if *update {
+		if err := writePNG(path, got); err != nil {
+			t.Fatalf("写入 golden 失败: %v", err)
+		}
+		return
+	}
+
+	want, err := readPNG(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("缺少 golden %s：用 make update-goldens 生成并提交", path)
+	}
+	if err != nil {
+		t.Fatalf("读取 golden 失败: %v", err)
+	}
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("尺寸不一致: 期望 %v，实际 %v", want.Bounds(), got.Bounds())
+	}
+
+	mismatched := diffPixels(want, got)
+	total := got.Bounds().Dx() * got.Bounds().Dy()
+	if ratio := float64(mismatched) / float64(total); ratio > maxMismatchRatio {
+		actual := filepath.Join(os.TempDir(), "spacebattle-"+name+".actual.png")
+		_ = writePNG(actual, got)
+		t.Errorf("%s 与 golden 不一致: %d 个像素（%.3f%%）超出容差，实际渲染见 %s", name, mismatched, ratio*100, actual)
+	}
+}
+
+// diffPixels 统计任一通道差值超过 channelTolerance 的像素数
+func diffPixels(want image.Image, got *image.RGBA) int {
+	n := 0
+	b := got.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			wr, wg, wb, wa := want.At(x, y).RGBA()
+			gr, gg, gb, ga := got.At(x, y).RGBA()
+			if exceeds(wr, gr) || exceeds(wg, gg) || exceeds(wb, gb) || exceeds(wa, ga) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// exceeds 比较两个 16 位通道值在 8 位精度下的差值
+func exceeds(a, b uint32) bool {
+	a, b = a>>8, b>>8
+	if a > b {
+		return a-b > channelTolerance
+	}
+	return b-a > channelTolerance
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
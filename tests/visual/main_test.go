@@ -0,0 +1,85 @@
+//go:build visual
+
+package visual_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"spacebattle/internal/fonts"
+	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testdataDir golden 与夹具目录（切换工作目录前解析为绝对路径）
+var testdataDir string
+
+// runner 在 ebiten 游戏循环内执行全部测试：离屏绘制后读回像素必须在游戏循环启动之后
+type runner struct {
+	m    *testing.M
+	code int
+}
+
+func (r *runner) Update() error {
+	r.code = r.m.Run()
+	return ebiten.Termination
+}
+
+func (r *runner) Draw(*ebiten.Image) {}
+
+func (r *runner) Layout(int, int) (int, int) {
+	return 800, 600
+}
+
+// TestMain 以仓库根目录为工作目录加载语言包与字体，使用临时存档，然后启动游戏循环运行测试。
+// Linux 无显示环境下可通过 xvfb-run 运行
+func TestMain(m *testing.M) {
+	code, err := run(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func run(m *testing.M) (int, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return 0, err
+	}
+	testdataDir = filepath.Join(wd, "testdata")
+	if err := os.Chdir(filepath.Join(wd, "..", "..")); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.MkdirTemp("", "spacebattle-visual")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := i18n.Init(); err != nil {
+		return 0, err
+	}
+	if err := fonts.Init(); err != nil {
+		return 0, err
+	}
+	if err := progress.Init(filepath.Join(tmp, "progress.db")); err != nil {
+		return 0, err
+	}
+	if err := progress.Load(); err != nil {
+		return 0, err
+	}
+
+	r := &runner{m: m}
+	ebiten.SetWindowSize(800, 600)
+	ebiten.SetWindowTitle("visual tests")
+	if err := ebiten.RunGameWithOptions(r, &ebiten.RunGameOptions{InitUnfocused: true}); err != nil {
+		return 0, err
+	}
+	return r.code, nil
+}
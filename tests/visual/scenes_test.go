@@ -0,0 +1,85 @@
+//go:build visual
+
+package visual_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"spacebattle/internal/ecs/components"
+	"spacebattle/internal/ecs/scenes"
+	"spacebattle/internal/i18n"
+	"spacebattle/internal/progress"
+	"spacebattle/internal/ships"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// sceneCase 一个待比对的画面：构建场景后只绘制一帧（不调用 Update，避免计时与输入影响画面）
+type sceneCase struct {
+	name  string
+	build func(t *testing.T) scenes.Scene
+}
+
+// battleFromFixture 从快照夹具恢复一局固定的中局战斗
+func battleFromFixture(t *testing.T, path string) scenes.Scene {
+	t.Helper()
+	snapshot, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取夹具失败: %v", err)
+	}
+	opts := scenes.OptionsForShip(ships.Default())
+	opts.Mode = components.ModeEndless
+	options, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	battle, err := scenes.ResumeBattleScene(progress.SuspendedRun{Options: options, Snapshot: snapshot})
+	if err != nil {
+		t.Fatalf("恢复战斗失败: %v", err)
+	}
+	return battle
+}
+
+func sceneCases() []sceneCase {
+	opts := scenes.OptionsForShip(ships.Default())
+	return []sceneCase{
+		{"main_menu", func(*testing.T) scenes.Scene { return scenes.NewMainMenuScene() }},
+		{"ship_select", func(*testing.T) scenes.Scene { return scenes.NewShipSelectScene() }},
+		{"upgrade", func(*testing.T) scenes.Scene { return scenes.NewUpgradeScene(opts) }},
+		{"deploy", func(*testing.T) scenes.Scene { return scenes.NewDeployScene(opts) }},
+		{"stage_select", func(*testing.T) scenes.Scene { return scenes.NewStageSelectScene(opts) }},
+		{"daily", func(*testing.T) scenes.Scene { return scenes.NewDailySceneFor("2026-01-01") }},
+		{"talent", func(*testing.T) scenes.Scene { return scenes.NewTalentScene() }},
+		{"stats", func(*testing.T) scenes.Scene { return scenes.NewStatsScene() }},
+		{"achievements", func(*testing.T) scenes.Scene { return scenes.NewAchievementsScene() }},
+		{"profiles", func(*testing.T) scenes.Scene { return scenes.NewProfilesScene() }},
+		{"settings", func(*testing.T) scenes.Scene { return scenes.NewSettingsScene() }},
+		{"battle", func(t *testing.T) scenes.Scene {
+			return battleFromFixture(t, filepath.Join(testdataDir, "..", "..", "ecs", "testdata", "midbattle.json"))
+		}},
+		{"results", func(t *testing.T) scenes.Scene {
+			return battleFromFixture(t, filepath.Join(testdataDir, "results.json"))
+		}},
+	}
+}
+
+// TestSceneGoldens 各语言下的菜单、战斗 HUD 与结算画面与 golden 一致
+func TestSceneGoldens(t *testing.T) {
+	defer i18n.SetLanguage(i18n.GetCurrentLanguage())
+	for _, lang := range []i18n.Language{i18n.English, i18n.Chinese, i18n.Russian} {
+		for _, c := range sceneCases() {
+			name := c.name + "_" + string(lang)
+			t.Run(name, func(t *testing.T) {
+				i18n.SetLanguage(lang)
+				scene := c.build(t)
+				screen := ebiten.NewImage(800, 600)
+				defer screen.Deallocate()
+				scene.Draw(screen)
+				checkGolden(t, name, capture(screen))
+			})
+		}
+	}
+}